@@ -0,0 +1,152 @@
+package dsn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/luma-sys/go-db-store/enum"
+)
+
+// ConnConfig representa os dados de conexão usados para montar uma DSN
+type ConnConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string
+	Params   map[string]string
+}
+
+// Portas padrão de cada driver, usadas quando ConnConfig.Port não é informado
+var defaultPorts = map[enum.DatabaseDriver]int{
+	enum.DatabaseDriverOracle:   1521,
+	enum.DatabaseDriverPostgres: 5432,
+	enum.DatabaseDriverMysql:    3306,
+	enum.DatabaseDriverMariaDB:  3306,
+}
+
+// Build monta a DSN no formato esperado pelo driver informado
+//
+// Formatos gerados:
+//
+//	SQLite:          <database> sem params, file:<database>?<params> com params (":memory:" quando database é vazio)
+//	MySQL / MariaDB: <user>:<pass>@tcp(<host>:<port>)/<database>?<params>
+//	Postgres:        postgres://<user>:<pass>@<host>:<port>/<database>?<params>
+//	Oracle:          oracle://<user>:<pass>@<host>:<port>/<database>?<params>
+//
+// No MySQL o database é escapado como caminho de URL. O driver separa as credenciais no último
+// "@" e no primeiro ":" sem decodificá-las, então a senha é mantida literal (pode conter "@",
+// "/" ou ":") e um usuário com ":" retorna erro
+func Build(driver enum.DatabaseDriver, cfg ConnConfig) (string, error) {
+	if !driver.IsValid() {
+		return "", errors.New("driver inválido")
+	}
+
+	if driver == enum.DatabaseDriverSqlite {
+		return buildSqlite(cfg), nil
+	}
+
+	if cfg.Host == "" {
+		return "", fmt.Errorf("host é obrigatório para o driver %s", driver.GetValue())
+	}
+
+	if cfg.Database == "" {
+		return "", fmt.Errorf("database é obrigatório para o driver %s", driver.GetValue())
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = defaultPorts[driver]
+	}
+	address := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+
+	switch driver {
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		return buildMysql(cfg, address)
+	case enum.DatabaseDriverPostgres:
+		return buildURL("postgres", cfg, address), nil
+	case enum.DatabaseDriverOracle:
+		return buildURL("oracle", cfg, address), nil
+	default:
+		return "", fmt.Errorf("unsupported database driver to build DSN: %s", driver.GetValue())
+	}
+}
+
+func buildSqlite(cfg ConnConfig) string {
+	database := cfg.Database
+	if database == "" {
+		database = ":memory:"
+	}
+
+	if len(cfg.Params) == 0 {
+		return database
+	}
+
+	return fmt.Sprintf("file:%s?%s", database, encodeParams(cfg.Params))
+}
+
+func buildMysql(cfg ConnConfig, address string) (string, error) {
+	if strings.Contains(cfg.User, ":") {
+		return "", errors.New("usuário do MySQL não pode conter ':'")
+	}
+
+	var sb strings.Builder
+
+	if cfg.User != "" {
+		sb.WriteString(cfg.User)
+		if cfg.Password != "" {
+			sb.WriteString(":")
+			sb.WriteString(cfg.Password)
+		}
+		sb.WriteString("@")
+	}
+
+	sb.WriteString(fmt.Sprintf("tcp(%s)/%s", address, url.PathEscape(cfg.Database)))
+
+	if len(cfg.Params) > 0 {
+		sb.WriteString("?")
+		sb.WriteString(encodeParams(cfg.Params))
+	}
+
+	return sb.String(), nil
+}
+
+func buildURL(scheme string, cfg ConnConfig, address string) string {
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     address,
+		Path:     "/" + cfg.Database,
+		RawQuery: encodeParams(cfg.Params),
+	}
+
+	if cfg.User != "" {
+		if cfg.Password != "" {
+			u.User = url.UserPassword(cfg.User, cfg.Password)
+		} else {
+			u.User = url.User(cfg.User)
+		}
+	}
+
+	return u.String()
+}
+
+// encodeParams codifica os parâmetros em ordem alfabética para uma DSN determinística
+func encodeParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(params[key]))
+	}
+
+	return strings.Join(pairs, "&")
+}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -57,10 +58,47 @@ func CreateInFilterWithField(field string, values []string) bson.M {
 	}
 }
 
+// CreateElemMatchFilter cria um filtro $elemMatch para campos do tipo array de subdocumentos
+func CreateElemMatchFilter(field string, conditions bson.M) bson.M {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	return bson.M{
+		field: bson.M{"$elemMatch": conditions},
+	}
+}
+
+// CreateLikeFilter cria um filtro $regex sem diferenciar maiúsculas. O valor é usado como
+// expressão regular; para buscar o texto literal use CreateLikeFilterEscaped
 func CreateLikeFilter(value string) bson.M {
 	return bson.M{"$regex": value, "$options": "i"}
 }
 
+// CreateLikeFilterEscaped cria o filtro do CreateLikeFilter escapando os metacaracteres de
+// regex do valor, para que termos com pontuação (".", "*", "(", "[") sejam buscados literalmente
+func CreateLikeFilterEscaped(value string) bson.M {
+	return CreateLikeFilter(regexp.QuoteMeta(value))
+}
+
+// CreateStartsWithFilter cria um filtro $regex, sem diferenciar maiúsculas, para valores que
+// começam com o texto literal
+func CreateStartsWithFilter(value string) bson.M {
+	return CreateLikeFilter("^" + regexp.QuoteMeta(value))
+}
+
+// CreateEndsWithFilter cria um filtro $regex, sem diferenciar maiúsculas, para valores que
+// terminam com o texto literal
+func CreateEndsWithFilter(value string) bson.M {
+	return CreateLikeFilter(regexp.QuoteMeta(value) + "$")
+}
+
+// CreateExactCaseInsensitiveFilter cria um filtro $regex para valores iguais ao texto literal,
+// sem diferenciar maiúsculas
+func CreateExactCaseInsensitiveFilter(value string) bson.M {
+	return CreateLikeFilter("^" + regexp.QuoteMeta(value) + "$")
+}
+
 func CreateLikeFilters(value string, fields []string) []bson.D {
 	if len(fields) == 0 {
 		return nil
@@ -77,3 +115,14 @@ func CreateLikeFilters(value string, fields []string) []bson.D {
 
 	return search
 }
+
+// CreateTextSearchFilter cria um filtro de busca textual ($text)
+//
+// A coleção precisa possuir um índice de texto nos campos pesquisados.
+func CreateTextSearchFilter(query string) bson.M {
+	if query == "" {
+		return nil
+	}
+
+	return bson.M{"$text": bson.M{"$search": query}}
+}
@@ -1,6 +1,7 @@
 package mongo
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
@@ -162,6 +163,44 @@ func TestCreateInFilterWithField(t *testing.T) {
 	}
 }
 
+func TestCreateElemMatchFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      string
+		conditions bson.M
+		expected   bson.M
+	}{
+		{
+			name:       "deve retornar nil quando conditions está vazio",
+			field:      "items",
+			conditions: bson.M{},
+			expected:   nil,
+		},
+		{
+			name:       "deve criar filtro elemMatch com uma condição",
+			field:      "items",
+			conditions: bson.M{"sku": "A1"},
+			expected:   bson.M{"items": bson.M{"$elemMatch": bson.M{"sku": "A1"}}},
+		},
+		{
+			name:       "deve criar filtro elemMatch com múltiplas condições",
+			field:      "items",
+			conditions: bson.M{"sku": "A1", "qty": bson.M{"$gte": 2}},
+			expected: bson.M{"items": bson.M{"$elemMatch": bson.M{
+				"sku": "A1",
+				"qty": bson.M{"$gte": 2},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CreateElemMatchFilter(tt.field, tt.conditions)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestCreateLikeFilter(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -193,6 +232,89 @@ func TestCreateLikeFilter(t *testing.T) {
 	}
 }
 
+func TestCreateLikeFilterEscaped(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected bson.M
+	}{
+		{
+			name:     "deve manter valor sem metacaracteres",
+			value:    "test",
+			expected: bson.M{"$regex": "test", "$options": "i"},
+		},
+		{
+			name:     "deve escapar metacaracteres de regex",
+			value:    "a.b*c(d)[e]",
+			expected: bson.M{"$regex": `a\.b\*c\(d\)\[e\]`, "$options": "i"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CreateLikeFilterEscaped(tt.value)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("deve casar o termo literalmente", func(t *testing.T) {
+		pattern := regexp.MustCompile(CreateLikeFilterEscaped("v1.0 (beta)")["$regex"].(string))
+		assert.True(t, pattern.MatchString("release v1.0 (beta)"))
+		assert.False(t, pattern.MatchString("release v1x0 beta"))
+	})
+}
+
+func TestCreateAnchoredLikeFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		create   func(string) bson.M
+		value    string
+		expected bson.M
+		matches  []string
+		rejects  []string
+	}{
+		{
+			name:     "deve criar filtro que começa com o valor",
+			create:   CreateStartsWithFilter,
+			value:    "ana.",
+			expected: bson.M{"$regex": `^ana\.`, "$options": "i"},
+			matches:  []string{"ana.silva", "Ana.Souza"},
+			rejects:  []string{"mariana.silva", "anax"},
+		},
+		{
+			name:     "deve criar filtro que termina com o valor",
+			create:   CreateEndsWithFilter,
+			value:    "(br)",
+			expected: bson.M{"$regex": `\(br\)$`, "$options": "i"},
+			matches:  []string{"empresa (BR)"},
+			rejects:  []string{"empresa (br) ltda", "empresa br"},
+		},
+		{
+			name:     "deve criar filtro igual ao valor sem diferenciar maiúsculas",
+			create:   CreateExactCaseInsensitiveFilter,
+			value:    "a+b",
+			expected: bson.M{"$regex": `^a\+b$`, "$options": "i"},
+			matches:  []string{"a+b", "A+B"},
+			rejects:  []string{"aab", "a+bc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.create(tt.value)
+			assert.Equal(t, tt.expected, result)
+
+			pattern := regexp.MustCompile("(?i)" + result["$regex"].(string))
+			for _, value := range tt.matches {
+				assert.True(t, pattern.MatchString(value), value)
+			}
+			for _, value := range tt.rejects {
+				assert.False(t, pattern.MatchString(value), value)
+			}
+		})
+	}
+}
+
 func TestCreateLikeFilters(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -233,3 +355,29 @@ func TestCreateLikeFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTextSearchFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected bson.M
+	}{
+		{
+			name:     "deve retornar nil quando query está vazia",
+			query:    "",
+			expected: nil,
+		},
+		{
+			name:     "deve criar filtro de busca textual",
+			query:    "café expresso",
+			expected: bson.M{"$text": bson.M{"$search": "café expresso"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CreateTextSearchFilter(tt.query)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
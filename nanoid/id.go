@@ -1,9 +1,26 @@
 package nanoid
 
-import gonanoid "github.com/matoous/go-nanoid/v2"
+import (
+	"errors"
+	"math"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	alphabet = "123456789ABCDEFGHIJKLMNPQRSTUVWXYZ"
+
+	tinySize = 6
+
+	// maxSafeSize limita o tamanho máximo de um ID gerado por NewTinySafe
+	maxSafeSize = 21
+
+	// SafeCollisionProbability é a probabilidade máxima de colisão aceita por NewTinySafe
+	SafeCollisionProbability = 1e-6
+)
 
 func mustCreateID(size int) string {
-	return gonanoid.MustGenerate("123456789ABCDEFGHIJKLMNPQRSTUVWXYZ", size)
+	return gonanoid.MustGenerate(alphabet, size)
 }
 
 func New() string {
@@ -11,5 +28,34 @@ func New() string {
 }
 
 func NewTiny() string {
-	return mustCreateID(6)
+	return mustCreateID(tinySize)
+}
+
+// CollisionProbability retorna a probabilidade aproximada (paradoxo do aniversário)
+// de ao menos uma colisão ao gerar n IDs com o tamanho informado
+func CollisionProbability(size, n int) float64 {
+	if size <= 0 || n <= 1 {
+		return 0
+	}
+
+	space := math.Pow(float64(len(alphabet)), float64(size))
+	pairs := float64(n) * float64(n-1) / 2
+
+	return -math.Expm1(-pairs / space)
+}
+
+// NewTinySafe gera um ID curto com o menor tamanho (a partir do tamanho de NewTiny)
+// que mantém a probabilidade de colisão para n IDs abaixo de SafeCollisionProbability
+func NewTinySafe(n int) (string, error) {
+	if n <= 0 {
+		return "", errors.New("quantidade de IDs esperados deve ser maior que zero")
+	}
+
+	for size := tinySize; size <= maxSafeSize; size++ {
+		if CollisionProbability(size, n) < SafeCollisionProbability {
+			return gonanoid.Generate(alphabet, size)
+		}
+	}
+
+	return "", errors.New("quantidade de IDs esperados excede o espaço seguro do alfabeto")
 }
@@ -122,6 +122,108 @@ func TestUniqueness(t *testing.T) {
 	}
 }
 
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		n    int
+		min  float64
+		max  float64
+	}{
+		{
+			name: "deve retornar zero para um único ID",
+			size: 6,
+			n:    1,
+			min:  0,
+			max:  0,
+		},
+		{
+			name: "deve retornar zero para tamanho inválido",
+			size: 0,
+			n:    1000,
+			min:  0,
+			max:  0,
+		},
+		{
+			name: "deve calcular probabilidade baixa para 1000 IDs tiny",
+			size: 6,
+			n:    1000,
+			min:  0.0003,
+			max:  0.0004,
+		},
+		{
+			name: "deve calcular probabilidade alta para 1 milhão de IDs tiny",
+			size: 6,
+			n:    1_000_000,
+			min:  0.99,
+			max:  1,
+		},
+		{
+			name: "deve calcular probabilidade desprezível para IDs de 18 caracteres",
+			size: 18,
+			n:    1_000_000,
+			min:  0,
+			max:  1e-15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CollisionProbability(tt.size, tt.n)
+			assert.GreaterOrEqual(t, result, tt.min)
+			assert.LessOrEqual(t, result, tt.max)
+		})
+	}
+}
+
+func TestNewTinySafe(t *testing.T) {
+	tests := []struct {
+		name           string
+		n              int
+		expectedLength int
+		expectError    bool
+	}{
+		{
+			name:           "deve gerar ID com 6 caracteres para poucos IDs",
+			n:              10,
+			expectedLength: 6,
+		},
+		{
+			name:           "deve aumentar o tamanho para muitos IDs",
+			n:              1_000_000,
+			expectedLength: 12,
+		},
+		{
+			name:        "deve falhar com quantidade zero",
+			n:           0,
+			expectError: true,
+		},
+		{
+			name:        "deve falhar com quantidade negativa",
+			n:           -1,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewTinySafe(tt.n)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLength, len(result))
+			assert.Less(t, CollisionProbability(len(result), tt.n), SafeCollisionProbability)
+
+			invalidChars := regexp.MustCompile("[^123456789ABCDEFGHIJKLMNPQRSTUVWXYZ]")
+			assert.False(t, invalidChars.MatchString(result))
+		})
+	}
+}
+
 func BenchmarkNew(b *testing.B) {
 	for b.Loop() {
 		New()
@@ -1,6 +1,11 @@
 package page
 
-import "math"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+)
 
 // PageMeta representa um metadata de paginação
 type PageMeta struct {
@@ -46,17 +51,11 @@ func New[T any](items []T, page, limit, totalItems int64) *Page[T] {
 }
 
 // NewEmpty cria uma paginação vazia
+//
+// Uma paginação sem itens possui 0 páginas, mesmo comportamento de New
+// quando totalItems é 0.
 func NewEmpty[T any](page, limit int64) *Page[T] {
-	return &Page[T]{
-		Items: []T{},
-		Meta: PageMeta{
-			TotalItems:   0,
-			CurrentPage:  int(page),
-			ItemsPerPage: int(limit),
-			ItemCount:    0,
-			TotalPages:   1,
-		},
-	}
+	return New([]T{}, page, limit, 0)
 }
 
 // NewEmptyCursor cria um cursor vazio [OLD MODE]
@@ -87,6 +86,62 @@ func NewCursor[T any](items []T, limit int64, next func(item T) string) *Cursor[
 	}
 }
 
+// KeysetPage representa uma página de keyset pagination com o cursor tipado
+type KeysetPage[T, K any] struct {
+	Items []T `json:"items"`
+	Next  *K  `json:"next"`
+}
+
+// NewKeysetPage cria uma página de keyset pagination a partir de items buscados com limit+1
+// registros. Quando há mais registros, Next é a chave do último item da página, a ser usada
+// como limite exclusivo da próxima consulta (ex.: {"id__gt": next} ordenando por id ASC).
+// Diferente de NewCursor, a chave mantém o tipo K (inteiros, time.Time etc.) sem conversão para string
+func NewKeysetPage[T, K any](items []T, limit int64, key func(T) K) *KeysetPage[T, K] {
+	if items == nil {
+		items = []T{}
+	}
+
+	if limit > 0 && int64(len(items)) > limit {
+		next := key(items[limit-1])
+		return &KeysetPage[T, K]{
+			Items: items[:limit],
+			Next:  &next,
+		}
+	}
+
+	return &KeysetPage[T, K]{
+		Items: items,
+		Next:  nil,
+	}
+}
+
+// EncodeCursor codifica a chave do cursor em uma string opaca (JSON em base64 URL-safe),
+// para trafegar em query strings
+func EncodeCursor[K any](key K) (string, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("erro ao codificar cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodifica o cursor gerado por EncodeCursor para a chave tipada
+func DecodeCursor[K any](cursor string) (K, error) {
+	var key K
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return key, fmt.Errorf("cursor inválido: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &key); err != nil {
+		return key, fmt.Errorf("cursor inválido: %w", err)
+	}
+
+	return key, nil
+}
+
 // Skip retorna o offset para a paginação
 func Skip(page, limit int64) int64 {
 	return (page - 1) * limit
@@ -1,7 +1,9 @@
 package page
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 type TestItem struct {
@@ -79,7 +81,7 @@ func TestNewEmpty(t *testing.T) {
 			CurrentPage:  1,
 			ItemsPerPage: 10,
 			ItemCount:    0,
-			TotalPages:   1,
+			TotalPages:   0,
 		},
 	}
 
@@ -88,6 +90,19 @@ func TestNewEmpty(t *testing.T) {
 	}
 }
 
+func TestNewEmptyConsistency(t *testing.T) {
+	empty := NewEmpty[TestItem](1, 10)
+	fromNew := New[TestItem](nil, 1, 10, 0)
+
+	if empty.Meta != fromNew.Meta {
+		t.Errorf("NewEmpty().Meta = %v, New().Meta = %v", empty.Meta, fromNew.Meta)
+	}
+
+	if empty.Meta.TotalPages != 0 {
+		t.Errorf("NewEmpty().Meta.TotalPages = %v, want 0", empty.Meta.TotalPages)
+	}
+}
+
 func TestSkip(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -165,6 +180,106 @@ func TestNewCursor(t *testing.T) {
 	}
 }
 
+func TestNewKeysetPage(t *testing.T) {
+	items := []TestItem{
+		{ID: 1, Name: "Item 1"},
+		{ID: 2, Name: "Item 2"},
+		{ID: 3, Name: "Item 3"},
+	}
+
+	key := func(item TestItem) int {
+		return item.ID
+	}
+
+	tests := []struct {
+		name  string
+		items []TestItem
+		limit int64
+		want  *KeysetPage[TestItem, int]
+	}{
+		{
+			name:  "deve usar a chave do último item da página como próximo cursor",
+			items: items,
+			limit: 2,
+			want:  &KeysetPage[TestItem, int]{Items: items[:2], Next: intPtr(2)},
+		},
+		{
+			name:  "deve criar página sem próximo cursor",
+			items: items[:2],
+			limit: 2,
+			want:  &KeysetPage[TestItem, int]{Items: items[:2]},
+		},
+		{
+			name:  "deve criar página vazia para itens nil",
+			items: nil,
+			limit: 2,
+			want:  &KeysetPage[TestItem, int]{Items: []TestItem{}},
+		},
+		{
+			name:  "não deve paginar com limite zero",
+			items: items,
+			limit: 0,
+			want:  &KeysetPage[TestItem, int]{Items: items},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewKeysetPage(tt.items, tt.limit, key)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewKeysetPage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	t.Run("deve preservar chaves numéricas sem perda", func(t *testing.T) {
+		var key int64 = 1<<53 + 1
+
+		cursor, err := EncodeCursor(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecodeCursor[int64](cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != key {
+			t.Errorf("DecodeCursor() = %d, want %d", got, key)
+		}
+	})
+
+	t.Run("deve preservar chaves de data", func(t *testing.T) {
+		key := time.Date(2024, 5, 1, 10, 30, 0, 123456789, time.UTC)
+
+		cursor, err := EncodeCursor(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecodeCursor[time.Time](cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(key) {
+			t.Errorf("DecodeCursor() = %v, want %v", got, key)
+		}
+	})
+
+	t.Run("deve falhar com cursor inválido", func(t *testing.T) {
+		if _, err := DecodeCursor[int64]("não é base64"); err == nil {
+			t.Error("DecodeCursor() deveria falhar com base64 inválido")
+		}
+
+		cursor, _ := EncodeCursor("texto")
+		if _, err := DecodeCursor[int64](cursor); err == nil {
+			t.Error("DecodeCursor() deveria falhar com tipo diferente")
+		}
+	})
+}
+
 func TestCalculateTotalPages(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -229,3 +344,7 @@ func compareCursor[T any](a, b *Cursor[T]) bool {
 func strPtr(s string) *string {
 	return &s
 }
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,40 @@
+package page
+
+// Response envelope padrão para respostas de API paginadas
+type Response[T any] struct {
+	Success bool     `json:"success"`
+	Error   string   `json:"error,omitempty"`
+	Data    *Page[T] `json:"data,omitempty"`
+	HasNext bool     `json:"hasNext"`
+	HasPrev bool     `json:"hasPrev"`
+}
+
+// NewResponse cria um envelope de sucesso para a paginação
+//
+// Uma paginação nil resulta em uma paginação vazia, para que data nunca
+// seja omitido em respostas de sucesso.
+func NewResponse[T any](p *Page[T]) *Response[T] {
+	if p == nil {
+		p = NewEmpty[T](1, 0)
+	}
+
+	return &Response[T]{
+		Success: true,
+		Data:    p,
+		HasNext: p.Meta.CurrentPage < p.Meta.TotalPages,
+		HasPrev: p.Meta.CurrentPage > 1,
+	}
+}
+
+// NewErrorResponse cria um envelope de erro, sem paginação
+func NewErrorResponse[T any](err error) *Response[T] {
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+
+	return &Response[T]{
+		Success: false,
+		Error:   message,
+	}
+}
@@ -0,0 +1,96 @@
+package page
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        *Page[TestItem]
+		wantHasNext bool
+		wantHasPrev bool
+	}{
+		{
+			name:        "deve indicar próxima página na primeira página",
+			page:        New([]TestItem{{ID: 1}}, 1, 1, 3),
+			wantHasNext: true,
+			wantHasPrev: false,
+		},
+		{
+			name:        "deve indicar páginas anterior e próxima no meio",
+			page:        New([]TestItem{{ID: 2}}, 2, 1, 3),
+			wantHasNext: true,
+			wantHasPrev: true,
+		},
+		{
+			name:        "deve indicar apenas página anterior na última página",
+			page:        New([]TestItem{{ID: 3}}, 3, 1, 3),
+			wantHasNext: false,
+			wantHasPrev: true,
+		},
+		{
+			name:        "deve criar paginação vazia para página nil",
+			page:        nil,
+			wantHasNext: false,
+			wantHasPrev: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewResponse(tt.page)
+
+			if !got.Success {
+				t.Errorf("NewResponse().Success = false, want true")
+			}
+			if got.Data == nil {
+				t.Fatalf("NewResponse().Data = nil")
+			}
+			if got.HasNext != tt.wantHasNext {
+				t.Errorf("NewResponse().HasNext = %v, want %v", got.HasNext, tt.wantHasNext)
+			}
+			if got.HasPrev != tt.wantHasPrev {
+				t.Errorf("NewResponse().HasPrev = %v, want %v", got.HasPrev, tt.wantHasPrev)
+			}
+		})
+	}
+}
+
+func TestNewErrorResponse(t *testing.T) {
+	got := NewErrorResponse[TestItem](errors.New("falha"))
+
+	if got.Success {
+		t.Errorf("NewErrorResponse().Success = true, want false")
+	}
+	if got.Error != "falha" {
+		t.Errorf("NewErrorResponse().Error = %v, want falha", got.Error)
+	}
+	if got.Data != nil {
+		t.Errorf("NewErrorResponse().Data = %v, want nil", got.Data)
+	}
+}
+
+func TestResponseJSON(t *testing.T) {
+	data, err := json.Marshal(NewResponse(New([]TestItem{{ID: 1, Name: "Item 1"}}, 1, 10, 1)))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"success":true,"data":{"items":[{"ID":1,"Name":"Item 1"}],"meta":{"totalItems":1,"currentPage":1,"itemsPerPage":10,"itemCount":1,"totalPages":1}},"hasNext":false,"hasPrev":false}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(NewErrorResponse[TestItem](errors.New("falha")))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want = `{"success":false,"error":"falha","hasNext":false,"hasPrev":false}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
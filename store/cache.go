@@ -0,0 +1,239 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Cache armazenamento chave/valor usado pelo NewCachedStore. Pode ser implementado com
+// Redis, um LRU em memória etc. Falhas do cache devem ser tratadas como ausência da chave
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	Delete(ctx context.Context, key string)
+}
+
+// cachedStore decora um Store com cache de leitura por ID
+type cachedStore[T any] struct {
+	Store[T]
+	ttl   time.Duration
+	cache Cache
+}
+
+// NewCachedStore cria um Store que consulta o cache antes do FindById e Has e invalida a
+// chave dos registros em toda escrita por entidade ou por ID (Save, Update, UpdateFields,
+// UpdateWhere, Upsert, UpsertReturning, UpsertMany, UpsertManyWith, Delete, DeleteIfExists,
+// DeleteReturning e DeleteByIds). As entidades são identificadas pela chave primária do
+// store (o campo ID no Mongo) e serializadas com encoding/gob, que lê os campos exportados
+// independente das tags `json`, para que o registro do cache seja igual ao do store. Ponteiros
+// para o valor zero voltam do cache como nil, e entidades que o gob não codifica (ex.: campos
+// interface sem gob.Register) não são armazenadas.
+//
+// Operações por filtro (UpdateMany, DeleteOne, DeleteMany) não conhecem os IDs afetados,
+// então os registros já em cache expiram apenas pelo ttl.
+func NewCachedStore[T any](inner Store[T], ttl time.Duration, cache Cache) Store[T] {
+	return &cachedStore[T]{
+		Store: inner,
+		ttl:   ttl,
+		cache: cache,
+	}
+}
+
+// Scoped mantém o cache sobre a cópia do store decorado
+func (s *cachedStore[T]) Scoped(opts ...Option) Store[T] {
+	return &cachedStore[T]{
+		Store: s.Store.Scoped(opts...),
+		ttl:   s.ttl,
+		cache: s.cache,
+	}
+}
+
+// namedStore implementado pelos stores que resolvem o nome da tabela ou coleção por contexto,
+// como no roteamento por tenant
+type namedStore interface {
+	resolvedName(ctx context.Context) (string, error)
+}
+
+// keyedStore implementado pelos stores cuja chave primária não é necessariamente o campo ID,
+// como o SQLStore com chave primária configurável
+type keyedStore interface {
+	entityID(v reflect.Value) (any, bool)
+}
+
+// cacheKey monta a chave do registro no cache a partir do tipo da entidade, da tabela ou coleção
+// resolvida para o contexto e do ID, para que stores de tenants diferentes não compartilhem
+// registros. Retorna false quando o nome não pode ser resolvido (ex.: sem tenant); nesse caso o
+// cache é ignorado e o erro vem do store decorado
+func (s *cachedStore[T]) cacheKey(ctx context.Context, id any) (string, bool) {
+	named, ok := s.Store.(namedStore)
+	if !ok {
+		return fmt.Sprintf("%s:%v", reflect.TypeFor[T]().String(), id), true
+	}
+
+	name, err := named.resolvedName(ctx)
+	if err != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("%s:%s:%v", reflect.TypeFor[T]().String(), name, id), true
+}
+
+// forget remove do cache o registro do ID
+func (s *cachedStore[T]) forget(ctx context.Context, id any) {
+	if key, ok := s.cacheKey(ctx, id); ok {
+		s.cache.Delete(ctx, key)
+	}
+}
+
+// entityID retorna a chave primária da entidade conforme o store decorado ou, na falta, o campo ID
+func (s *cachedStore[T]) entityID(v reflect.Value) (any, bool) {
+	if keyed, ok := s.Store.(keyedStore); ok {
+		return keyed.entityID(v)
+	}
+
+	return getEntityID(v)
+}
+
+// invalidate remove do cache as entidades informadas, identificadas pela chave primária
+func (s *cachedStore[T]) invalidate(ctx context.Context, entities ...*T) {
+	for _, e := range entities {
+		if e == nil {
+			continue
+		}
+
+		if id, ok := s.entityID(reflect.ValueOf(e).Elem()); ok {
+			s.forget(ctx, id)
+		}
+	}
+}
+
+// FindById retorna o registro do cache ou, na ausência, do store decorado
+func (s *cachedStore[T]) FindById(ctx context.Context, id any) (*T, error) {
+	key, ok := s.cacheKey(ctx, id)
+	if !ok {
+		return s.Store.FindById(ctx, id)
+	}
+
+	if data, ok := s.cache.Get(ctx, key); ok {
+		var cached T
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	result, err := s.Store.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(result); err == nil {
+		s.cache.Set(ctx, key, data.Bytes(), s.ttl)
+	}
+
+	return result, nil
+}
+
+// FindByIdInto usa o FindById com cache e copia o resultado para dst
+func (s *cachedStore[T]) FindByIdInto(ctx context.Context, id any, dst *T) error {
+	result, err := s.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	*dst = *result
+	return nil
+}
+
+// Has verifica o cache antes de consultar o store decorado
+func (s *cachedStore[T]) Has(ctx context.Context, id any) bool {
+	if key, ok := s.cacheKey(ctx, id); ok {
+		if _, ok := s.cache.Get(ctx, key); ok {
+			return true
+		}
+	}
+
+	return s.Store.Has(ctx, id)
+}
+
+func (s *cachedStore[T]) Save(ctx context.Context, e *T) (*T, error) {
+	result, err := s.Store.Save(ctx, e)
+	s.invalidate(ctx, e)
+	return result, err
+}
+
+func (s *cachedStore[T]) Update(ctx context.Context, e *T) (*T, error) {
+	result, err := s.Store.Update(ctx, e)
+	s.invalidate(ctx, e)
+	return result, err
+}
+
+func (s *cachedStore[T]) UpdateFields(ctx context.Context, e *T, fields ...string) (*T, error) {
+	result, err := s.Store.UpdateFields(ctx, e, fields...)
+	s.invalidate(ctx, e)
+	return result, err
+}
+
+func (s *cachedStore[T]) UpdateWhere(ctx context.Context, e *T, extra map[string]any) (*T, error) {
+	result, err := s.Store.UpdateWhere(ctx, e, extra)
+	s.invalidate(ctx, e)
+	return result, err
+}
+
+func (s *cachedStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (*UpdateResult, error) {
+	result, err := s.Store.Upsert(ctx, e, f)
+	s.invalidate(ctx, e)
+	return result, err
+}
+
+func (s *cachedStore[T]) UpsertReturning(ctx context.Context, e *T, f []StoreUpsertFilter) (*T, error) {
+	result, err := s.Store.UpsertReturning(ctx, e, f)
+	s.invalidate(ctx, e, result)
+	return result, err
+}
+
+func (s *cachedStore[T]) UpsertMany(ctx context.Context, e []T, f []StoreUpsertFilter) (*BulkWriteResult, error) {
+	result, err := s.Store.UpsertMany(ctx, e, f)
+	for i := range e {
+		s.invalidate(ctx, &e[i])
+	}
+	return result, err
+}
+
+func (s *cachedStore[T]) UpsertManyWith(ctx context.Context, items []UpsertItem[T]) (*BulkWriteResult, error) {
+	result, err := s.Store.UpsertManyWith(ctx, items)
+	for i := range items {
+		s.invalidate(ctx, &items[i].Entity)
+	}
+	return result, err
+}
+
+func (s *cachedStore[T]) Delete(ctx context.Context, id any) error {
+	err := s.Store.Delete(ctx, id)
+	s.forget(ctx, id)
+	return err
+}
+
+func (s *cachedStore[T]) DeleteIfExists(ctx context.Context, id any) (bool, error) {
+	deleted, err := s.Store.DeleteIfExists(ctx, id)
+	s.forget(ctx, id)
+	return deleted, err
+}
+
+func (s *cachedStore[T]) DeleteReturning(ctx context.Context, id any) (*DeleteResult, error) {
+	result, err := s.Store.DeleteReturning(ctx, id)
+	s.forget(ctx, id)
+	return result, err
+}
+
+func (s *cachedStore[T]) DeleteByIds(ctx context.Context, ids []any) (*DeleteResult, error) {
+	result, err := s.Store.DeleteByIds(ctx, ids)
+	for _, id := range ids {
+		s.forget(ctx, id)
+	}
+	return result, err
+}
@@ -0,0 +1,263 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/luma-sys/go-db-store/enum"
+	"github.com/stretchr/testify/assert"
+)
+
+// testMemoryCache implementação simples de Cache para os testes
+type testMemoryCache struct {
+	items map[string][]byte
+}
+
+func newTestMemoryCache() *testMemoryCache {
+	return &testMemoryCache{items: make(map[string][]byte)}
+}
+
+func (c *testMemoryCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	value, ok := c.items[key]
+	return value, ok
+}
+
+func (c *testMemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	c.items[key] = value
+}
+
+func (c *testMemoryCache) Delete(ctx context.Context, key string) {
+	delete(c.items, key)
+}
+
+func TestCachedStore(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cache := newTestMemoryCache()
+	store := NewCachedStore(NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true), time.Minute, cache)
+	ctx := context.Background()
+
+	t.Run("deve retornar o registro do cache sem consultar o banco", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, err := store.Save(ctx, &TestSQLEntity{Name: "Em Cache", Age: 30})
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Em Cache", found.Name)
+
+		// Remove direto no banco: a leitura seguinte vem do cache
+		db.Exec("DELETE FROM test_entities")
+
+		cached, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Em Cache", cached.Name)
+		assert.Equal(t, 30, cached.Age)
+		assert.True(t, store.Has(ctx, saved.ID))
+	})
+
+	t.Run("deve invalidar o cache no Update", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Original"})
+		store.FindById(ctx, saved.ID)
+
+		saved.Name = "Atualizado"
+		_, err := store.Update(ctx, saved)
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Atualizado", found.Name)
+	})
+
+	t.Run("deve invalidar o cache no Delete", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar"})
+		store.FindById(ctx, saved.ID)
+
+		assert.NoError(t, store.Delete(ctx, saved.ID))
+
+		_, err := store.FindById(ctx, saved.ID)
+		assert.Error(t, err)
+		assert.False(t, store.Has(ctx, saved.ID))
+	})
+
+	t.Run("deve invalidar o cache no DeleteByIds", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar em Lote"})
+		store.FindById(ctx, saved.ID)
+
+		result, err := store.DeleteByIds(ctx, []any{saved.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.DeletedCount)
+		assert.False(t, store.Has(ctx, saved.ID))
+	})
+
+	t.Run("deve invalidar o cache no DeleteReturning", func(t *testing.T) {
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar com Resultado"})
+		store.FindById(ctx, saved.ID)
+
+		result, err := store.DeleteReturning(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.DeletedCount)
+		assert.False(t, store.Has(ctx, saved.ID))
+	})
+
+	t.Run("não deve armazenar registros inexistentes", func(t *testing.T) {
+		_, err := store.FindById(ctx, 99999)
+		assert.Error(t, err)
+		assert.False(t, store.Has(ctx, 99999))
+	})
+
+	t.Run("deve delegar os demais métodos ao store decorado", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		store.Save(ctx, &TestSQLEntity{Name: "Um"})
+		store.Save(ctx, &TestSQLEntity{Name: "Dois"})
+
+		count, err := store.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *count)
+	})
+}
+
+func TestCachedStore_Tenants(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, tenant := range []string{"acme", "globex"} {
+		_, err = db.Exec(fmt.Sprintf(`
+			CREATE TABLE events_%s (
+				id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL
+			);
+		`, tenant))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache := newTestMemoryCache()
+	base := NewCachedStore(NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "events_"+TenantPlaceholder, "id", true, WithTenantContextKey(testTenantKey{})), time.Minute, cache)
+	acme := base.Scoped(WithTenant("acme"))
+	globex := base.Scoped(WithTenant("globex"))
+	ctx := context.Background()
+
+	t.Run("não deve compartilhar registros entre tenants", func(t *testing.T) {
+		saved, err := acme.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Acme"})
+		assert.NoError(t, err)
+		_, err = globex.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Globex"})
+		assert.NoError(t, err)
+
+		found, err := acme.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Acme", found.Name)
+
+		found, err = globex.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Globex", found.Name)
+	})
+
+	t.Run("deve invalidar apenas o registro do tenant", func(t *testing.T) {
+		assert.NoError(t, acme.Delete(ctx, 1))
+		assert.False(t, acme.Has(ctx, 1))
+
+		found, err := globex.FindById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Globex", found.Name)
+	})
+
+	t.Run("deve ignorar o cache sem tenant", func(t *testing.T) {
+		_, err := base.FindById(ctx, 1)
+		assert.ErrorIs(t, err, ErrMissingTenant)
+	})
+}
+
+func TestCachedStore_CustomPrimaryKey(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE custom_key_entities (
+			uuid INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewCachedStore(NewSQLStore[TestSQLEntityWithCustomKey](db, enum.DatabaseDriverSqlite, "custom_key_entities", "uuid", true), time.Minute, newTestMemoryCache())
+	ctx := context.Background()
+
+	t.Run("deve invalidar o cache pela chave primária configurada", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithCustomKey{Name: "Original"})
+		assert.NoError(t, err)
+		store.FindById(ctx, saved.UUID)
+
+		_, err = store.UpsertMany(ctx, []TestSQLEntityWithCustomKey{{UUID: saved.UUID, Name: "Atualizado"}}, nil)
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.UUID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Atualizado", found.Name)
+	})
+}
+
+func TestCachedStore_HiddenJSONFields(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE accounts (
+			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			email TEXT NOT NULL,
+			password_hash TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type account struct {
+		ID           int    `db:"id" json:"id"`
+		Email        string `db:"email" json:"email"`
+		PasswordHash string `db:"password_hash" json:"-"`
+	}
+
+	store := NewCachedStore(NewSQLStore[account](db, enum.DatabaseDriverSqlite, "accounts", "id", true), time.Minute, newTestMemoryCache())
+	ctx := context.Background()
+
+	t.Run("deve retornar no cache os campos ocultos do JSON", func(t *testing.T) {
+		saved, err := store.Save(ctx, &account{Email: "joao@exemplo.com", PasswordHash: "hash"})
+		assert.NoError(t, err)
+
+		miss, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+
+		hit, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, miss, hit)
+		assert.Equal(t, "hash", hit.PasswordHash)
+	})
+}
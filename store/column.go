@@ -0,0 +1,227 @@
+package store
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	valuerType  = reflect.TypeFor[driver.Valuer]()
+)
+
+// dbColumn representa uma coluna mapeada pela tag `db` de um campo da entidade
+//
+// Opções suportadas na tag, separadas por vírgula após o nome da coluna:
+//
+//	generated: coluna preenchida pelo banco (DEFAULT, trigger ou coluna calculada),
+//	           ignorada em INSERT e UPDATE mas lida normalmente nas consultas
+//
+// Exemplo:
+//
+//	CreatedAt time.Time `db:"created_at,generated"`
+//
+// Campos de struct aninhada (exceto time.Time e tipos que implementam sql.Scanner) não são
+// colunas: a tag define o prefixo das colunas dos campos internos, gravadas em INSERT e UPDATE
+// como "prefixo_coluna" e lidas nas consultas como "prefixo.coluna" ou "prefixo_coluna":
+//
+//	Address Address `db:"address"` // City string `db:"city"` grava address_city
+type dbColumn struct {
+	name      string
+	generated bool
+}
+
+// parseDBTag retorna a coluna declarada na tag `db` do campo e se o campo é mapeado
+func parseDBTag(field reflect.StructField) (dbColumn, bool) {
+	tag := field.Tag.Get("db")
+	if tag == "" || tag == "-" || isNestedStruct(field.Type) {
+		return dbColumn{}, false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	column := dbColumn{name: name}
+
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch opt {
+		case "generated":
+			column.generated = true
+		}
+	}
+
+	return column, true
+}
+
+// sqlTimestampFields resolve as colunas dos campos CreatedAt e UpdatedAt de t pelas tags `db`,
+// usando created_at e updated_at quando o campo não existe ou não tem tag
+func sqlTimestampFields(t reflect.Type) timestampFields {
+	names := timestampFields{created: "created_at", updated: "updated_at"}
+	if field, ok := t.FieldByName("CreatedAt"); ok {
+		if column, ok := parseDBTag(field); ok {
+			names.created = column.name
+		}
+	}
+	if field, ok := t.FieldByName("UpdatedAt"); ok {
+		if column, ok := parseDBTag(field); ok {
+			names.updated = column.name
+		}
+	}
+
+	return names
+}
+
+// sqlFieldInfos descreve as colunas de t na ordem da declaração, a partir das mesmas colunas
+// gravadas pelo INSERT (columnFields): campos com db:"-", sem tag ou não exportados são omitidos
+func sqlFieldInfos(t reflect.Type, primaryKey string, generatesPK bool) []FieldInfo {
+	columns := columnFields(t)
+	infos := make([]FieldInfo, 0, len(columns))
+	for _, column := range columns {
+		// Caminho do campo Go, com as structs aninhadas separadas por ponto
+		names := make([]string, len(column.index))
+		for i := range column.index {
+			names[i] = t.FieldByIndex(column.index[:i+1]).Name
+		}
+
+		info := FieldInfo{Field: strings.Join(names, "."), Column: column.name}
+		_, opts, _ := strings.Cut(column.field.Tag.Get("db"), ",")
+		for opt := range strings.SplitSeq(opts, ",") {
+			if opt != "" {
+				info.Flags = append(info.Flags, opt)
+			}
+		}
+
+		info.PrimaryKey = len(column.index) == 1 && column.name == primaryKey
+		info.AutoIncrement = info.PrimaryKey && generatesPK
+		info.Skipped = info.AutoIncrement || column.generated
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// isNestedStruct indica se o tipo é uma struct aninhada mapeada por prefixo, e não uma coluna.
+// Tipos que implementam sql.Scanner ou driver.Valuer são colunas com conversão própria
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType &&
+		!reflect.PointerTo(t).Implements(scannerType) && !t.Implements(valuerType)
+}
+
+// nestedColumnFields indexa por coluna os campos de v, incluindo os campos de structs
+// aninhadas com tag `db`, acessíveis pelo caminho com ponto e com sublinhado
+func nestedColumnFields(v reflect.Value, prefix string, fields map[string]reflect.Value) {
+	t := v.Type()
+	for i := range v.NumField() {
+		field := t.Field(i)
+
+		if isNestedStruct(field.Type) {
+			tag, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+			if tag == "" || tag == "-" {
+				continue
+			}
+			nestedColumnFields(v.Field(i), prefix+tag+".", fields)
+			continue
+		}
+
+		column, ok := parseDBTag(field)
+		if !ok {
+			continue
+		}
+
+		path := prefix + column.name
+		fields[path] = v.Field(i)
+		if prefix != "" {
+			fields[strings.ReplaceAll(path, ".", "_")] = v.Field(i)
+		}
+	}
+}
+
+// columnField coluna gravável da entidade e o caminho até o campo, que pode estar numa struct aninhada
+type columnField struct {
+	dbColumn
+	field reflect.StructField
+	index []int
+}
+
+// columnFields retorna as colunas de t na ordem da declaração, com os campos de structs
+// aninhadas achatados como "prefixo_coluna"
+func columnFields(t reflect.Type) []columnField {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return appendColumnFields(nil, t, nil, "")
+}
+
+func appendColumnFields(fields []columnField, t reflect.Type, index []int, prefix string) []columnField {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		path := append(slices.Clone(index), i)
+
+		// Campos não exportados não podem ser lidos por reflexão para a gravação
+		if !field.IsExported() {
+			continue
+		}
+
+		if isNestedStruct(field.Type) {
+			tag, _, _ := strings.Cut(field.Tag.Get("db"), ",")
+			if tag != "" && tag != "-" {
+				fields = appendColumnFields(fields, field.Type, path, prefix+tag+"_")
+			}
+			continue
+		}
+
+		column, ok := parseDBTag(field)
+		if !ok {
+			continue
+		}
+
+		column.name = prefix + column.name
+		fields = append(fields, columnField{dbColumn: column, field: field, index: path})
+	}
+
+	return fields
+}
+
+// fieldByColumn retorna o campo da entidade mapeado para a coluna informada
+func fieldByColumn(v reflect.Value, column string) (reflect.Value, bool) {
+	for _, c := range columnFields(v.Type()) {
+		if c.name == column {
+			return v.FieldByIndex(c.index), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// entityColumns retorna as colunas mapeadas pelas tags `db` do tipo da entidade, indexadas pelo nome
+func entityColumns(t reflect.Type) map[string]dbColumn {
+	columns := make(map[string]dbColumn)
+	for _, c := range columnFields(t) {
+		columns[c.name] = c.dbColumn
+	}
+
+	return columns
+}
+
+// columnValue retorna o valor do campo para envio ao banco. Ponteiros para time.Time
+// são desreferenciados, e o ponteiro nil é enviado como NULL. Campos driver.Valuer são
+// enviados sem alteração para que o database/sql chame o Value
+func columnValue(field reflect.Value) any {
+	if field.Type().Implements(valuerType) {
+		return field.Interface()
+	}
+
+	if t, ok := field.Interface().(*time.Time); ok {
+		if t == nil {
+			return nil
+		}
+		return *t
+	}
+
+	return field.Interface()
+}
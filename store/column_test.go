@@ -0,0 +1,164 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestColumnEntity struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at,generated"`
+	Ignored   string    `db:"-"`
+	NoTag     string
+}
+
+func TestParseDBTag(t *testing.T) {
+	entityType := reflect.TypeOf(TestColumnEntity{})
+
+	tests := []struct {
+		name       string
+		field      string
+		wantColumn dbColumn
+		wantOk     bool
+	}{
+		{
+			name:       "deve retornar o nome da coluna",
+			field:      "Name",
+			wantColumn: dbColumn{name: "name"},
+			wantOk:     true,
+		},
+		{
+			name:       "deve reconhecer a opção generated",
+			field:      "CreatedAt",
+			wantColumn: dbColumn{name: "created_at", generated: true},
+			wantOk:     true,
+		},
+		{
+			name:   "deve ignorar campo com tag db:\"-\"",
+			field:  "Ignored",
+			wantOk: false,
+		},
+		{
+			name:   "deve ignorar campo sem tag",
+			field:  "NoTag",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, _ := entityType.FieldByName(tt.field)
+			column, ok := parseDBTag(field)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantColumn, column)
+		})
+	}
+}
+
+func TestFieldByColumn(t *testing.T) {
+	entity := TestColumnEntity{ID: 7, Name: "João"}
+	v := reflect.ValueOf(&entity).Elem()
+
+	t.Run("deve encontrar o campo pela coluna", func(t *testing.T) {
+		field, ok := fieldByColumn(v, "name")
+		assert.True(t, ok)
+		assert.Equal(t, "João", field.Interface())
+	})
+
+	t.Run("deve encontrar o campo com opções na tag", func(t *testing.T) {
+		_, ok := fieldByColumn(v, "created_at")
+		assert.True(t, ok)
+	})
+
+	t.Run("não deve encontrar coluna inexistente", func(t *testing.T) {
+		_, ok := fieldByColumn(v, "inexistente")
+		assert.False(t, ok)
+	})
+}
+
+func TestEntityColumns(t *testing.T) {
+	t.Run("deve retornar as colunas mapeadas da entidade", func(t *testing.T) {
+		columns := entityColumns(reflect.TypeOf(TestColumnEntity{}))
+
+		assert.Equal(t, map[string]dbColumn{
+			"id":         {name: "id"},
+			"name":       {name: "name"},
+			"created_at": {name: "created_at", generated: true},
+		}, columns)
+	})
+
+	t.Run("deve retornar mapa vazio para tipo que não é struct", func(t *testing.T) {
+		columns := entityColumns(reflect.TypeOf(""))
+		assert.Empty(t, columns)
+	})
+}
+
+func TestColumnValue(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{
+			name:     "deve retornar nil para ponteiro de time.Time nil",
+			value:    (*time.Time)(nil),
+			expected: nil,
+		},
+		{
+			name:     "deve desreferenciar ponteiro de time.Time",
+			value:    &now,
+			expected: now,
+		},
+		{
+			name:     "deve manter os demais tipos",
+			value:    "João",
+			expected: "João",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := columnValue(reflect.ValueOf(tt.value))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+type TestColumnAddress struct {
+	City string `db:"city"`
+	Zip  string `db:"zip"`
+}
+
+type TestColumnNestedEntity struct {
+	ID      int               `db:"id"`
+	Address TestColumnAddress `db:"address"`
+}
+
+func TestNestedColumnFields(t *testing.T) {
+	t.Run("deve indexar campos aninhados por ponto e sublinhado", func(t *testing.T) {
+		var entity TestColumnNestedEntity
+		fields := make(map[string]reflect.Value)
+		nestedColumnFields(reflect.ValueOf(&entity).Elem(), "", fields)
+
+		assert.Len(t, fields, 5)
+		fields["address_city"].SetString("Curitiba")
+		fields["address.zip"].SetString("80000-000")
+		assert.Equal(t, TestColumnAddress{City: "Curitiba", Zip: "80000-000"}, entity.Address)
+	})
+
+	t.Run("deve achatar a struct aninhada em colunas com prefixo", func(t *testing.T) {
+		columns := entityColumns(reflect.TypeOf(TestColumnNestedEntity{}))
+		assert.Equal(t, map[string]dbColumn{
+			"id":           {name: "id"},
+			"address_city": {name: "address_city"},
+			"address_zip":  {name: "address_zip"},
+		}, columns)
+	})
+}
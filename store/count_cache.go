@@ -0,0 +1,90 @@
+package store
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// countCache memoriza os totais do Count por tabela/coleção e filtro durante o ttl
+type countCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]countEntry
+}
+
+type countEntry struct {
+	total     int64
+	expiresAt time.Time
+}
+
+// newCountCache retorna nil quando o ttl não é positivo, desabilitando o cache
+func newCountCache(ttl time.Duration) *countCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &countCache{ttl: ttl, entries: make(map[string]countEntry)}
+}
+
+// countCacheKey identifica a consulta pelo nome resolvido da tabela e pelo filtro. Cada par
+// é codificado, em ordem de chave, com o tipo e a sintaxe Go do valor, para que filtros como
+// {"age": 1} e {"age": "1"} (ou nil e um mapa vazio) não compartilhem o total
+func countCacheKey(name string, f map[string]any) string {
+	if f == nil {
+		return name + ":nil"
+	}
+
+	var key strings.Builder
+	key.WriteString(name + ":{")
+	for _, k := range slices.Sorted(maps.Keys(f)) {
+		fmt.Fprintf(&key, "%q:%T(%#v);", k, f[k], f[k])
+	}
+	key.WriteString("}")
+
+	return key.String()
+}
+
+// get retorna o total em cache para a chave, se ainda dentro do ttl
+func (c *countCache) get(key string) (int64, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return 0, false
+	}
+
+	return entry.total, true
+}
+
+func (c *countCache) set(key string, total int64) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = countEntry{total: total, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// invalidate descarta todos os totais em cache
+func (c *countCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
@@ -0,0 +1,78 @@
+package store
+
+// filterKey chave do mapa de filtros que recebe uma expressão Filter
+const filterKey = "__filter"
+
+type filterOp int
+
+const (
+	filterCond filterOp = iota
+	filterAnd
+	filterOr
+	filterNot
+)
+
+// Filter expressão de filtro com aninhamento arbitrário de AND, OR e NOT, para consultas que
+// o mapa de filtros não expressa, como (A AND (B OR C)) AND NOT D:
+//
+//	f := store.And(
+//		store.Cond(map[string]any{"active": true}),
+//		store.Or(
+//			store.Cond(map[string]any{"age__gte": 18}),
+//			store.Cond(map[string]any{"name__like": "A%"}),
+//		),
+//		store.Not(store.Cond(map[string]any{"status": "blocked"})),
+//	)
+//	entities, err := s.FindAll(ctx, store.Where(f), store.FindOptions{})
+//
+// As folhas (Cond) usam o mesmo DSL `campo__operador` do mapa, com as chaves combinadas por AND.
+// No SQL a expressão gera cláusulas entre parênteses; no Mongo, $and, $or e $nor (ver Not
+// sobre a diferença no tratamento de campos nulos)
+type Filter struct {
+	op       filterOp
+	children []Filter
+	cond     map[string]any
+}
+
+// And casa quando todas as expressões casam. Sem expressões, casa com todos os registros
+func And(filters ...Filter) Filter {
+	return Filter{op: filterAnd, children: filters}
+}
+
+// Or casa quando ao menos uma das expressões casa. Sem expressões, não casa com nenhum registro
+func Or(filters ...Filter) Filter {
+	return Filter{op: filterOr, children: filters}
+}
+
+// Not casa quando a expressão não casa. Os bancos divergem para campos nulos: no SQL,
+// NOT (cond) segue a lógica de três valores e também exclui os registros em que a coluna é
+// NULL, enquanto no Mongo o $nor inclui os documentos com o campo nulo ou ausente. Para o
+// mesmo resultado nos dois, combine com um filtro explícito (ex.: "status__is_not_null": true)
+func Not(filter Filter) Filter {
+	return Filter{op: filterNot, children: []Filter{filter}}
+}
+
+// Cond folha da expressão com filtros no formato do mapa (ex.: {"age__gte": 18})
+func Cond(filters map[string]any) Filter {
+	return Filter{op: filterCond, cond: filters}
+}
+
+// Where retorna o mapa de filtros com a expressão, aceito por FindAll, Count e pelos demais
+// métodos de consulta. Outras chaves podem ser adicionadas ao mapa e são combinadas por AND
+func Where(filter Filter) map[string]any {
+	return map[string]any{filterKey: filter}
+}
+
+// filterExpression extrai a expressão do valor da chave __filter
+func filterExpression(value any) (Filter, bool) {
+	switch f := value.(type) {
+	case Filter:
+		return f, true
+	case *Filter:
+		if f != nil {
+			return *f, true
+		}
+	}
+
+	return Filter{}, false
+}
@@ -0,0 +1,32 @@
+package store
+
+// nestedTestFilter expressão de três níveis usada nos testes dos dois backends:
+// active AND (age >= 30 OR (name LIKE 'A%' AND score >= 80)) AND NOT score < 75
+func nestedTestFilter() Filter {
+	return And(
+		Cond(map[string]any{"active": true}),
+		Or(
+			Cond(map[string]any{"age__gte": 30}),
+			And(
+				Cond(map[string]any{"name__like": "A%"}),
+				Cond(map[string]any{"score__gte": 80}),
+			),
+		),
+		Not(Cond(map[string]any{"score__lt": 75})),
+	)
+}
+
+// nestedTestEntities registros dos testes da expressão; casam apenas Maria e Ana
+var nestedTestEntities = []struct {
+	Name   string
+	Age    int
+	Active bool
+	Score  float64
+}{
+	{"João", 25, true, 80},
+	{"Maria", 30, true, 90},
+	{"Pedro", 35, false, 70},
+	{"Ana", 28, true, 85},
+	{"Carlos", 40, false, 75},
+	{"Bruno", 45, true, 70},
+}
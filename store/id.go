@@ -0,0 +1,141 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var objectIDType = reflect.TypeFor[bson.ObjectID]()
+
+// getEntityID retorna o valor do campo ID da entidade e se ele está preenchido.
+// Entidades sem campo ID ou com o valor zero do tipo retornam (nil, false)
+func getEntityID(v reflect.Value) (any, bool) {
+	field := v.FieldByName("ID")
+	if !field.IsValid() || field.IsZero() {
+		return nil, false
+	}
+
+	return field.Interface(), true
+}
+
+// setEntityID atribui o id ao campo ID da entidade, convertendo-o para o tipo do campo
+func setEntityID(v reflect.Value, id any) error {
+	field := v.FieldByName("ID")
+	if !field.IsValid() {
+		return fmt.Errorf("entidade %s não possui campo ID", v.Type())
+	}
+
+	return setIDValue(field, id)
+}
+
+// setIDValue converte o id para o tipo do campo de chave: tipos sql.Scanner (ex.: UUID),
+// inteiros (com e sem sinal), string e bson.ObjectID (a partir do ObjectID ou da sua
+// representação hexadecimal). Os demais tipos precisam ser conversíveis a partir do id
+func setIDValue(field reflect.Value, id any) error {
+	if !field.CanSet() {
+		return fmt.Errorf("campo de id não pode ser alterado")
+	}
+
+	// A chave gerada pelo banco chega no formato bruto do driver, como nas demais colunas
+	if handled, err := scanInto(field, id); handled {
+		return err
+	}
+
+	if id == nil {
+		field.SetZero()
+		return nil
+	}
+
+	if field.Type() == objectIDType {
+		switch v := id.(type) {
+		case bson.ObjectID:
+			field.Set(reflect.ValueOf(v))
+		case string:
+			oid, err := bson.ObjectIDFromHex(v)
+			if err != nil {
+				return fmt.Errorf("id %q não é um ObjectID válido: %w", v, err)
+			}
+			field.Set(reflect.ValueOf(oid))
+		default:
+			return fmt.Errorf("não é possível converter %T para ObjectID", id)
+		}
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := idToInt64(id)
+		if err != nil {
+			return err
+		}
+		if field.OverflowInt(n) {
+			return fmt.Errorf("id %d excede o tamanho do campo %s", n, field.Type())
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := idToInt64(id)
+		if err != nil {
+			return err
+		}
+		if n < 0 || field.OverflowUint(uint64(n)) {
+			return fmt.Errorf("id %d excede o tamanho do campo %s", n, field.Type())
+		}
+		field.SetUint(uint64(n))
+	case reflect.String:
+		switch v := id.(type) {
+		case string:
+			field.SetString(v)
+		case []byte:
+			field.SetString(string(v))
+		case bson.ObjectID:
+			field.SetString(v.Hex())
+		default:
+			field.SetString(fmt.Sprint(v))
+		}
+	default:
+		value := reflect.ValueOf(id)
+		if !value.Type().ConvertibleTo(field.Type()) {
+			return fmt.Errorf("não é possível converter %T para %s", id, field.Type())
+		}
+		field.Set(value.Convert(field.Type()))
+	}
+
+	return nil
+}
+
+// idToInt64 converte ids numéricos (inclusive em texto, como retornados por alguns drivers) para int64
+func idToInt64(id any) (int64, error) {
+	switch v := id.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("não é possível converter %T para inteiro", id)
+	}
+}
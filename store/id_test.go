@@ -0,0 +1,85 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestSetEntityID(t *testing.T) {
+	oid := bson.NewObjectID()
+
+	t.Run("deve converter para campos inteiros", func(t *testing.T) {
+		var e struct{ ID int64 }
+		v := reflect.ValueOf(&e).Elem()
+
+		assert.NoError(t, setEntityID(v, 42))
+		assert.Equal(t, int64(42), e.ID)
+
+		assert.NoError(t, setEntityID(v, []byte("43")))
+		assert.Equal(t, int64(43), e.ID)
+	})
+
+	t.Run("deve rejeitar overflow em inteiros menores", func(t *testing.T) {
+		var e struct{ ID int8 }
+		assert.Error(t, setEntityID(reflect.ValueOf(&e).Elem(), int64(1000)))
+	})
+
+	t.Run("deve converter para campos string", func(t *testing.T) {
+		var e struct{ ID string }
+		v := reflect.ValueOf(&e).Elem()
+
+		assert.NoError(t, setEntityID(v, int64(7)))
+		assert.Equal(t, "7", e.ID)
+
+		assert.NoError(t, setEntityID(v, oid))
+		assert.Equal(t, oid.Hex(), e.ID)
+	})
+
+	t.Run("deve converter para campos ObjectID", func(t *testing.T) {
+		var e struct{ ID bson.ObjectID }
+		v := reflect.ValueOf(&e).Elem()
+
+		assert.NoError(t, setEntityID(v, oid))
+		assert.Equal(t, oid, e.ID)
+
+		assert.NoError(t, setEntityID(v, oid.Hex()))
+		assert.Equal(t, oid, e.ID)
+
+		assert.Error(t, setEntityID(v, "invalido"))
+		assert.Error(t, setEntityID(v, 1))
+	})
+
+	t.Run("deve converter tipos sql.Scanner e conversíveis", func(t *testing.T) {
+		var e struct{ ID TestSQLCode }
+		assert.NoError(t, setEntityID(reflect.ValueOf(&e).Elem(), []byte("KEY-7")))
+		assert.Equal(t, TestSQLCode{Prefix: "KEY", Number: 7}, e.ID)
+
+		type tenantID string
+		var named struct{ ID tenantID }
+		assert.NoError(t, setEntityID(reflect.ValueOf(&named).Elem(), "acme"))
+		assert.Equal(t, tenantID("acme"), named.ID)
+	})
+
+	t.Run("deve falhar sem campo ID", func(t *testing.T) {
+		var e struct{ Name string }
+		assert.Error(t, setEntityID(reflect.ValueOf(&e).Elem(), 1))
+	})
+}
+
+func TestGetEntityID(t *testing.T) {
+	id, ok := getEntityID(reflect.ValueOf(struct{ ID int }{ID: 5}))
+	assert.True(t, ok)
+	assert.Equal(t, 5, id)
+
+	_, ok = getEntityID(reflect.ValueOf(struct{ ID string }{}))
+	assert.False(t, ok)
+
+	_, ok = getEntityID(reflect.ValueOf(struct{ ID bson.ObjectID }{}))
+	assert.False(t, ok)
+
+	_, ok = getEntityID(reflect.ValueOf(struct{ Name string }{}))
+	assert.False(t, ok)
+}
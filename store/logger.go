@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+)
+
+// Logger registra as consultas executadas pelo store. *slog.Logger implementa a interface
+type Logger interface {
+	Debug(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// LoggerFromContext obtém o logger da requisição a partir do contexto. Retornar nil desativa o
+// registro para aquele contexto
+type LoggerFromContext func(ctx context.Context) Logger
+
+// logQuery registra a consulta no logger do contexto: em Debug com a duração ou, quando falha, em Error
+func (fn LoggerFromContext) logQuery(ctx context.Context, msg string, attrs []any, duration time.Duration, err error) {
+	logger := fn(ctx)
+	if logger == nil {
+		return
+	}
+
+	attrs = append(attrs, "duration", duration)
+	if err != nil {
+		logger.Error(msg, append(attrs, "error", err)...)
+		return
+	}
+
+	logger.Debug(msg, attrs...)
+}
+
+// loggingConn registra cada comando SQL executado na conexão. Os valores dos parâmetros não
+// são registrados, apenas a quantidade, para não expor dados das entidades
+type loggingConn struct {
+	sqlConn
+	logger LoggerFromContext
+}
+
+func (c loggingConn) log(ctx context.Context, query string, args []any, start time.Time, err error) {
+	c.logger.logQuery(ctx, "query sql", []any{"query", query, "args", len(args)}, time.Since(start), err)
+}
+
+func (c loggingConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := c.sqlConn.ExecContext(ctx, query, args...)
+	c.log(ctx, query, args, start, err)
+
+	return result, err
+}
+
+func (c loggingConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := c.sqlConn.QueryContext(ctx, query, args...)
+	c.log(ctx, query, args, start, err)
+
+	return rows, err
+}
+
+// QueryRowContext registra a consulta com o erro da execução (row.Err); o sql.ErrNoRows só
+// aparece no Scan e não é registrado como falha
+func (c loggingConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := c.sqlConn.QueryRowContext(ctx, query, args...)
+	c.log(ctx, query, args, start, row.Err())
+
+	return row
+}
+
+// PrepareContext registra apenas as falhas da preparação (ex.: coluna inexistente); a duração
+// é registrada em cada execução pelo loggingStmt (veja prepareStmt)
+func (c loggingConn) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	start := time.Now()
+	stmt, err := c.sqlConn.PrepareContext(ctx, query)
+	if err != nil {
+		c.log(ctx, query, nil, start, err)
+	}
+
+	return stmt, err
+}
+
+// prepareStmt prepara a query e envolve o statement no loggingStmt, para que cada execução
+// também seja registrada
+func (c loggingConn) prepareStmt(ctx context.Context, query string) (sqlStmt, error) {
+	stmt, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return loggingStmt{stmt: stmt, query: query, conn: c}, nil
+}
+
+// loggingStmt registra cada execução de um statement preparado pelo loggingConn
+type loggingStmt struct {
+	stmt  *sql.Stmt
+	query string
+	conn  loggingConn
+}
+
+func (s loggingStmt) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := s.stmt.ExecContext(ctx, args...)
+	s.conn.log(ctx, s.query, args, start, err)
+
+	return result, err
+}
+
+func (s loggingStmt) QueryContext(ctx context.Context, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := s.stmt.QueryContext(ctx, args...)
+	s.conn.log(ctx, s.query, args, start, err)
+
+	return rows, err
+}
+
+// QueryRowContext registra a consulta com o erro da execução (row.Err); o sql.ErrNoRows só
+// aparece no Scan e não é registrado como falha
+func (s loggingStmt) QueryRowContext(ctx context.Context, args ...any) *sql.Row {
+	start := time.Now()
+	row := s.stmt.QueryRowContext(ctx, args...)
+	s.conn.log(ctx, s.query, args, start, row.Err())
+
+	return row
+}
+
+func (s loggingStmt) Close() error {
+	return s.stmt.Close()
+}
+
+// loggingTx registra os comandos da transação como o loggingConn
+type loggingTx struct {
+	loggingConn
+	tx sqlTx
+}
+
+func (t loggingTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t loggingTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// NewMongoCommandMonitor cria um monitor de comandos que registra cada comando do Mongo no logger
+// do contexto da operação. O driver só permite monitorar comandos no client, então o monitor deve
+// ser configurado com options.Client().SetMonitor; no SQL use WithLoggerFromContext. O id do
+// comando no protocolo do driver é registrado como wire_request_id, para não conflitar com o
+// request_id de correlação do logger da requisição
+func NewMongoCommandMonitor(fn LoggerFromContext) *event.CommandMonitor {
+	attrs := func(e event.CommandFinishedEvent) []any {
+		return []any{"command", e.CommandName, "database", e.DatabaseName, "wire_request_id", e.RequestID}
+	}
+
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			fn.logQuery(ctx, "comando mongo", attrs(e.CommandFinishedEvent), e.Duration, nil)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			fn.logQuery(ctx, "comando mongo", attrs(e.CommandFinishedEvent), e.Duration, e.Failure)
+		},
+	}
+}
@@ -1,14 +1,19 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	mongoutils "github.com/luma-sys/go-db-store/mongo"
 	"github.com/luma-sys/go-db-store/page"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -18,18 +23,114 @@ import (
 )
 
 type mongoStore[T any] struct {
-	coll *mongo.Collection
+	coll       *mongo.Collection
+	collOpts   []options.Lister[options.CollectionOptions] // write concern e read preference do store
+	opts       storeOptions
+	fields     map[string]bool // campos de primeiro nível mapeados pelas tags `bson` de T
+	timestamps timestampFields // chaves do CreatedAt e UpdatedAt de T
+	counts     *countCache     // totais memorizados pelo WithCountCache (nil quando desabilitado)
 }
 
 // NewMongoStore cria um novo mongoStore
-func NewMongoStore[T any](coll *mongo.Collection) Store[T] {
-	return &mongoStore[T]{
-		coll: coll,
+func NewMongoStore[T any](coll *mongo.Collection, opts ...Option) Store[T] {
+	mustBeStruct[T]("NewMongoStore")
+
+	s := &mongoStore[T]{
+		coll:       coll,
+		opts:       newStoreOptions(opts),
+		fields:     entityBsonFields(reflect.TypeFor[T]()),
+		timestamps: bsonTimestampFields(reflect.TypeFor[T]()),
 	}
+	mustMatchReadTransform[T]("NewMongoStore", s.opts)
+	s.counts = newCountCache(s.opts.countCacheTTL)
+	s.applyCollectionOptions()
+
+	return s
+}
+
+// applyCollectionOptions obtém novamente a coleção do banco com o write concern, a read
+// preference e as opções BSON do store, quando configurados
+func (s *mongoStore[T]) applyCollectionOptions() {
+	if s.opts.writeConcern == nil && s.opts.readPreference == nil && s.opts.bsonOptions == nil && s.opts.registry == nil {
+		return
+	}
+
+	collOpts := options.Collection()
+	if s.opts.writeConcern != nil {
+		collOpts.SetWriteConcern(s.opts.writeConcern)
+	}
+	if s.opts.readPreference != nil {
+		collOpts.SetReadPreference(s.opts.readPreference)
+	}
+	if s.opts.bsonOptions != nil {
+		collOpts.SetBSONOptions(s.opts.bsonOptions)
+	}
+	if s.opts.registry != nil {
+		collOpts.SetRegistry(s.opts.registry)
+	}
+
+	s.collOpts = []options.Lister[options.CollectionOptions]{collOpts}
+	s.coll = s.coll.Database().Collection(s.coll.Name(), s.collOpts...)
+}
+
+// Scoped retorna uma cópia do store com as opções aplicadas sobre as atuais (ex.: WithTenant
+// ou WithReadPreference por requisição). A cópia compartilha a coleção e o cache do Count
+func (s *mongoStore[T]) Scoped(opts ...Option) Store[T] {
+	scoped := *s
+	scoped.opts = s.opts.with(opts)
+	mustMatchReadTransform[T]("Scoped", scoped.opts)
+	scoped.applyCollectionOptions()
+
+	return &scoped
+}
+
+// collection retorna a coleção do store, substituindo o TenantPlaceholder do nome pelo
+// tenant do contexto quando o roteamento por tenant está habilitado
+func (s *mongoStore[T]) collection(ctx context.Context) (*mongo.Collection, error) {
+	if !s.opts.routesByTenant() {
+		return s.coll, nil
+	}
+
+	name, err := s.opts.resolveTenantName(ctx, s.coll.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.coll.Database().Collection(name, s.collOpts...), nil
+}
+
+// resolvedName retorna a coleção do contexto, usada pelo NewCachedStore para separar os tenants
+func (s *mongoStore[T]) resolvedName(ctx context.Context) (string, error) {
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return coll.Name(), nil
+}
+
+// CollectionName retorna o nome da coleção configurado, sem a substituição do tenant
+func (s *mongoStore[T]) CollectionName() string {
+	return s.coll.Name()
+}
+
+// Describe retorna o banco e a coleção do store (ex.: "mongo:app/users")
+func (s *mongoStore[T]) Describe() string {
+	return fmt.Sprintf("mongo:%s/%s", s.coll.Database().Name(), s.coll.Name())
+}
+
+// DebugFields retorna o mapeamento derivado das tags `bson` de T, como gravado pelo driver
+func (s *mongoStore[T]) DebugFields() []FieldInfo {
+	return bsonFieldInfos(reflect.TypeFor[T](), "")
 }
 
-func (s *mongoStore[T]) WithTransaction(ctx context.Context, fn Transaction) (any, error) {
+func (s *mongoStore[T]) WithTransaction(ctx context.Context, fn Transaction) (_ any, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
 	wc := writeconcern.Majority()
+	if s.opts.writeConcern != nil {
+		wc = s.opts.writeConcern
+	}
 	txnOptions := options.Transaction().SetWriteConcern(wc)
 
 	session, err := s.coll.Database().Client().StartSession()
@@ -46,14 +147,36 @@ func (s *mongoStore[T]) WithTransaction(ctx context.Context, fn Transaction) (an
 }
 
 // FindAll recupera documentos com paginação e filtros
-func (s *mongoStore[T]) FindAll(ctx context.Context, f map[string]any, opts FindOptions) ([]T, error) {
+func (s *mongoStore[T]) FindAll(ctx context.Context, f map[string]any, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	s.opts.applyFindDefaults(&opts)
+	if err := s.opts.checkLimit(opts.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.validateSortField(opts.SortBy); err != nil {
+		return nil, err
+	}
+
 	opts.Initialize()
-	if opts.SortBy == "id" {
-		opts.SortBy = "_id"
+	opts.SortBy = s.sortKey(opts.SortBy)
+
+	if err := opts.validateProjection(); err != nil {
+		return nil, err
+	}
+	if err := s.validateFields("projeção", slices.Concat(opts.Fields, opts.ExcludeFields)); err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeTotalCount {
+		return s.findAllWithTotalCount(ctx, f, opts)
 	}
 
 	// Usando o filtro fornecido ou um filtro vazio se nenhum for fornecido
-	filter := s.mapToBsonD(f)
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
 	findOpts := options.Find()
 
 	// Configurando a paginação
@@ -65,407 +188,1707 @@ func (s *mongoStore[T]) FindAll(ctx context.Context, f map[string]any, opts Find
 
 	// Configurando a ordenação
 	if opts.SortBy != "" {
-		sortValue := 1
-		if opts.OrderBy == "DESC" {
-			sortValue = -1
+		findOpts.SetSort(mongoSort(opts))
+	}
+
+	// Configurando a projeção
+	if projection := mongoProjection(opts); projection != nil {
+		findOpts.SetProjection(projection)
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar documentos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results, err := s.decodeCursor(ctx, cursor)
+	if err != nil && !errors.Is(err, ErrDecode) {
+		return nil, err
+	}
+	transformEntities(s.opts, results)
+
+	return results, err
+}
+
+// decodeCursor decodifica os documentos do cursor em T. Com WithSkipDecodeErrors cada documento
+// é decodificado separadamente: os que falham são ignorados e o erro, que envolve ErrDecode, reúne
+// a falha de cada um junto aos resultados decodificados
+func (s *mongoStore[T]) decodeCursor(ctx context.Context, cursor *mongo.Cursor) ([]T, error) {
+	if !s.opts.skipDecodeErrors {
+		var results []T
+		if err := cursor.All(ctx, &results); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar documentos: %w", err)
+		}
+		if err := convertStringFields(results); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar documentos: %w", err)
+		}
+
+		return results, nil
+	}
+
+	results := make([]T, 0)
+	var decodeErrs []error
+	for cursor.Next(ctx) {
+		var item T
+		err := cursor.Decode(&item)
+		if err == nil {
+			err = applyStringConverters(reflect.ValueOf(&item).Elem())
 		}
-		findOpts.SetSort(bson.D{{Key: opts.SortBy, Value: sortValue}})
+		if err != nil {
+			decodeErrs = append(decodeErrs, fmt.Errorf("documento %v: %w", cursor.Current.Lookup("_id"), err))
+			continue
+		}
+
+		results = append(results, item)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao buscar documentos: %w", err)
+	}
+
+	if len(decodeErrs) > 0 {
+		return results, fmt.Errorf("%w: %d documentos ignorados: %w", ErrDecode, len(decodeErrs), errors.Join(decodeErrs...))
+	}
+
+	return results, nil
+}
+
+// findAllWithTotalCount recupera documentos paginados e o total do filtro em uma única
+// consulta usando $facet, gravando o total em opts.TotalCount
+func (s *mongoStore[T]) findAllWithTotalCount(ctx context.Context, f map[string]any, opts FindOptions) ([]T, error) {
+	if opts.TotalCount == nil {
+		return nil, fmt.Errorf("TotalCount é obrigatório quando IncludeTotalCount é informado")
+	}
+
+	itemsPipeline := bson.A{}
+
+	// Configurando a ordenação
+	if opts.SortBy != "" {
+		itemsPipeline = append(itemsPipeline, bson.D{{Key: "$sort", Value: mongoSort(opts)}})
+	}
+
+	// Configurando a paginação
+	if opts.Limit > 0 {
+		itemsPipeline = append(itemsPipeline,
+			bson.D{{Key: "$skip", Value: page.Skip(opts.Page, opts.Limit)}},
+			bson.D{{Key: "$limit", Value: opts.Limit}},
+		)
+	}
+
+	// Configurando a projeção
+	if projection := mongoProjection(opts); projection != nil {
+		itemsPipeline = append(itemsPipeline, bson.D{{Key: "$project", Value: projection}})
+	}
+
+	match, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "items", Value: itemsPipeline},
+			{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+		}}},
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	cursor, err := s.coll.Find(ctx, filter, findOpts)
+	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar documentos: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var results []T
-	if err = cursor.All(ctx, &results); err != nil {
+	var facets []struct {
+		Items []T `bson:"items"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(ctx, &facets); err != nil {
 		return nil, fmt.Errorf("erro ao decodificar documentos: %w", err)
 	}
+	for _, facet := range facets {
+		if err = convertStringFields(facet.Items); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar documentos: %w", err)
+		}
+	}
+
+	var results []T
+	var total int64
+	if len(facets) > 0 {
+		results = facets[0].Items
+		if len(facets[0].Total) > 0 {
+			total = facets[0].Total[0].Count
+		}
+	}
+	transformEntities(s.opts, results)
+	*opts.TotalCount = total
 
 	return results, nil
 }
 
+// FindAllJoined recupera documentos com paginação e filtros incluindo documentos
+// de outras coleções através de estágios $lookup
+//
+// Os joins são aplicados antes do $match, permitindo filtrar por campos dos documentos incluídos.
+func (s *mongoStore[T]) FindAllJoined(ctx context.Context, lookups []LookupSpec, f map[string]any, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	s.opts.applyFindDefaults(&opts)
+	if err := s.opts.checkLimit(opts.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.validateSortField(opts.SortBy); err != nil {
+		return nil, err
+	}
+
+	opts.Initialize()
+	opts.SortBy = s.sortKey(opts.SortBy)
+
+	pipeline := mongo.Pipeline{}
+
+	for _, lookup := range lookups {
+		pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: lookup.From},
+			{Key: "localField", Value: lookup.LocalField},
+			{Key: "foreignField", Value: lookup.ForeignField},
+			{Key: "as", Value: lookup.As},
+		}}})
+	}
+
+	match, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
+
+	// Configurando a ordenação
+	if opts.SortBy != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: mongoSort(opts)}})
+	}
+
+	// Configurando a paginação
+	if opts.Limit > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$skip", Value: page.Skip(opts.Page, opts.Limit)}},
+			bson.D{{Key: "$limit", Value: opts.Limit}},
+		)
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar documentos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results, err := s.decodeCursor(ctx, cursor)
+	if err != nil && !errors.Is(err, ErrDecode) {
+		return nil, err
+	}
+	transformEntities(s.opts, results)
+
+	return results, err
+}
+
+// FindLast retorna os últimos n documentos ordenados por sortField, em ordem crescente
+func (s *mongoStore[T]) FindLast(ctx context.Context, n int64, f map[string]any, sortField string) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	if n <= 0 {
+		return nil, fmt.Errorf("quantidade de documentos deve ser maior que zero")
+	}
+
+	if err := s.opts.checkLimit(n); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateSortField(sortField); err != nil {
+		return nil, err
+	}
+
+	sortField = s.sortKey(sortField)
+
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: sortField, Value: -1}}).
+		SetLimit(n)
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar documentos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results, err := s.decodeCursor(ctx, cursor)
+	if err != nil && !errors.Is(err, ErrDecode) {
+		return nil, err
+	}
+	transformEntities(s.opts, results)
+
+	slices.Reverse(results)
+
+	return results, err
+}
+
+// Search busca documentos cujo texto contém term em qualquer um dos campos informados,
+// sem diferenciar maiúsculas e minúsculas ($or de regex). Com term vazio retorna todos os documentos
+func (s *mongoStore[T]) Search(ctx context.Context, term string, fields []string, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("campos de busca são obrigatórios")
+	}
+
+	if err := s.validateFields("busca", fields); err != nil {
+		return nil, err
+	}
+
+	if term == "" {
+		return s.FindAll(ctx, nil, opts)
+	}
+
+	likeFilters := mongoutils.CreateLikeFilters(regexp.QuoteMeta(term), fields)
+
+	return s.FindAll(ctx, map[string]any{"$or": likeFilters}, opts)
+}
+
+// SearchText recupera documentos através de busca textual ($text), ordenados por relevância
+//
+// A coleção precisa possuir um índice de texto, por exemplo:
+//
+//	coll.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "name", Value: "text"}}})
+func (s *mongoStore[T]) SearchText(ctx context.Context, query string, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	if query == "" {
+		return nil, fmt.Errorf("texto de busca não pode ser vazio")
+	}
+
+	s.opts.applyFindDefaults(&opts)
+	if err := s.opts.checkLimit(opts.Limit); err != nil {
+		return nil, err
+	}
+	opts.Initialize()
+
+	filter := mongoutils.CreateTextSearchFilter(query)
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "textScore", Value: bson.M{"$meta": "textScore"}}})
+
+	if opts.Limit > 0 {
+		findOpts.SetSkip(page.Skip(opts.Page, opts.Limit))
+		findOpts.SetLimit(opts.Limit)
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar documentos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results, err := s.decodeCursor(ctx, cursor)
+	if err != nil && !errors.Is(err, ErrDecode) {
+		return nil, err
+	}
+	transformEntities(s.opts, results)
+
+	return results, err
+}
+
 // Count retorna o total de registros
-func (s *mongoStore[T]) Count(ctx context.Context, f map[string]any) (*int64, error) {
-	filter := s.mapToBsonD(f)
+func (s *mongoStore[T]) Count(ctx context.Context, f map[string]any) (_ *int64, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dentro de uma sessão (ex.: WithTransaction) o total pode incluir escritas ainda não confirmadas
+	counts := s.counts
+	if mongo.SessionFromContext(ctx) != nil {
+		counts = nil
+	}
+
+	key := countCacheKey(coll.Name(), f)
+	if total, ok := counts.get(key); ok {
+		return &total, nil
+	}
+
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
 
-	total, err := s.coll.CountDocuments(ctx, filter)
+	total, err := coll.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao quantificar documentos: %w", err)
 	}
 
+	counts.set(key, total)
+
 	return &total, nil
 }
 
+// InvalidateCount descarta os totais memorizados pelo WithCountCache
+func (s *mongoStore[T]) InvalidateCount() {
+	s.counts.invalidate()
+}
+
 // FindById recupera um documento pelo ID
-func (s *mongoStore[T]) FindById(ctx context.Context, id any) (*T, error) {
+func (s *mongoStore[T]) FindById(ctx context.Context, id any) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
 	var result T
+	if err := s.FindByIdInto(ctx, id, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FindByIdInto recupera um documento pelo ID decodificando-o em dst, que é zerado antes da
+// decodificação (e mantido quando o documento não existe). Permite reutilizar a mesma
+// entidade em buscas repetidas
+func (s *mongoStore[T]) FindByIdInto(ctx context.Context, id any, dst *T) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
 
 	filter := bson.M{"_id": id}
-	err := s.coll.FindOne(ctx, filter).Decode(&result)
-	if errors.Is(err, mongo.ErrNoDocuments) {
-		return nil, fmt.Errorf("documento não encontrado com id %s", id)
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := coll.FindOne(ctx, filter)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return fmt.Errorf("documento não encontrado com id %v: %w", id, ErrNotFound)
+	}
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("erro ao buscar documento: %w", err)
+	}
+
+	var zero T
+	*dst = zero
+
+	if err := result.Decode(dst); err != nil {
+		return fmt.Errorf("erro ao buscar documento: %w", err)
+	}
+
+	if err := applyStringConverters(reflect.ValueOf(dst).Elem()); err != nil {
+		return fmt.Errorf("erro ao decodificar documento: %w", err)
 	}
+	transformEntity(s.opts, dst)
+
+	return nil
+}
+
+// FindAllWithCount recupera os registros paginados e o total do filtro em uma única
+// consulta, usando $facet
+func (s *mongoStore[T]) FindAllWithCount(ctx context.Context, f map[string]any, opts FindOptions) (_ []T, _ int64, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return findAllWithCount[T](ctx, s, f, opts)
+}
+
+// FindPage retorna a página informada com os metadados de paginação
+func (s *mongoStore[T]) FindPage(ctx context.Context, f map[string]any, p, limit int64) (_ *page.Page[T], err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return findPage[T](ctx, s, f, p, limit)
+}
+
+func (s *mongoStore[T]) FindOne(ctx context.Context, f map[string]interface{}, opts ...FindOptions) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	findOpts := options.FindOne()
+	if len(opts) > 0 && opts[0].SortBy != "" {
+		sortOpts := opts[0]
+		if err := s.validateSortField(sortOpts.SortBy); err != nil {
+			return nil, err
+		}
+		sortOpts.SortBy = s.sortKey(sortOpts.SortBy)
+		findOpts.SetSort(mongoSort(sortOpts))
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+
+	err = coll.FindOne(ctx, filter, findOpts).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("documento não encontrado com filtro %v: %w", f, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar documento: %w", err)
+	}
+
+	if err := applyStringConverters(reflect.ValueOf(&result).Elem()); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+	}
+	transformEntity(s.opts, &result)
+
+	return &result, nil
+}
+
+// Save salva um documento
+func (s *mongoStore[T]) Save(ctx context.Context, e *T) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	now := time.Now()
+	value := reflect.ValueOf(e).Elem()
+
+	s.opts.setSaveTimestamps(value, now)
+
+	doc, err := s.insertDocument(e)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.InsertOne(ctx, doc)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao salvar documento: %w", err)
+	}
+
+	// Reflete na entidade o _id gerado pelo driver
+	if _, ok := getEntityID(value); !ok && value.FieldByName("ID").IsValid() {
+		if err := setEntityID(value, result.InsertedID); err != nil {
+			return nil, fmt.Errorf("erro ao definir id do documento: %w", err)
+		}
+	}
+
+	if s.opts.reloadAfterSave {
+		if err := coll.FindOne(ctx, bson.M{"_id": result.InsertedID}).Decode(e); err != nil {
+			return nil, fmt.Errorf("erro ao recarregar documento: %w", err)
+		}
+		if err := applyStringConverters(reflect.ValueOf(e).Elem()); err != nil {
+			return nil, fmt.Errorf("erro ao recarregar documento: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// SaveMany salva vários documentos
+func (s *mongoStore[T]) SaveMany(ctx context.Context, e []T) (_ *InsertManyResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	now := time.Now()
+
+	docs := make([]any, len(e))
+	for i, doc := range e {
+		value := reflect.ValueOf(&doc).Elem()
+
+		s.opts.setSaveTimestamps(value, now)
+
+		if docs[i], err = s.insertDocument(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := options.InsertMany()
+	opts.SetOrdered(s.opts.ordered(false))
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.InsertMany(ctx, docs, opts)
+	if err != nil {
+		if result != nil {
+			return &InsertManyResult{InsertedIDs: result.InsertedIDs}, fmt.Errorf("erro ao criar documentos: %w", err)
+		}
+		return nil, fmt.Errorf("erro ao criar documentos: %w", err)
+	}
+
+	return &InsertManyResult{InsertedIDs: result.InsertedIDs}, nil
+}
+
+// SaveManyDetailed salva vários documentos como SaveMany e retorna um InsertOneResult por
+// documento, na ordem de entrada, inclusive com os _id gerados pelo Mongo
+func (s *mongoStore[T]) SaveManyDetailed(ctx context.Context, e []T) (_ []InsertOneResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	result, err := s.SaveMany(ctx, e)
+	return result.detailed(), err
+}
+
+// SaveManyNotOrdered salva vários documentos de forma desordenada
+func (s *mongoStore[T]) SaveManyNotOrdered(ctx context.Context, e []T) (_ *InsertManyResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	now := time.Now()
+
+	docs := make([]any, len(e))
+	for i, doc := range e {
+		value := reflect.ValueOf(&doc).Elem()
+
+		s.opts.setSaveTimestamps(value, now)
+
+		if docs[i], err = s.insertDocument(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar documentos: %w", err)
+	}
+
+	return &InsertManyResult{InsertedIDs: result.InsertedIDs}, nil
+}
+
+// SaveIgnoreDuplicates insere os documentos de forma desordenada ignorando os que violam um
+// índice único (inclusive o _id). Retorna apenas os _id dos documentos inseridos; os demais
+// erros de escrita são retornados normalmente
+func (s *mongoStore[T]) SaveIgnoreDuplicates(ctx context.Context, e []T) (_ *InsertManyResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(e) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	docs := make([]any, len(e))
+	for i, doc := range e {
+		value := reflect.ValueOf(&doc).Elem()
+
+		s.opts.setSaveTimestamps(value, now)
+
+		if docs[i], err = s.insertDocument(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return &InsertManyResult{InsertedIDs: result.InsertedIDs}, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if result == nil || !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return nil, fmt.Errorf("erro ao criar documentos: %w", err)
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !isDuplicateKeyCode(writeErr.Code) {
+			return nil, fmt.Errorf("erro ao criar documentos: %w", err)
+		}
+	}
+
+	// O driver já remove do InsertedIDs os ids das escritas que falharam
+	return &InsertManyResult{InsertedIDs: result.InsertedIDs}, nil
+}
+
+// zeroDateTime valor gravado pelo driver para o time.Time zero (0001-01-01)
+var zeroDateTime = bson.NewDateTimeFromTime(time.Time{})
+
+// insertDocument retorna o documento da inserção: a própria entidade ou, com WithOmitZeroTimes,
+// o documento codificado sem os campos time.Time zerados
+func (s *mongoStore[T]) insertDocument(e any) (any, error) {
+	if !s.opts.omitZeroTimes {
+		return e, nil
+	}
+
+	var buf bytes.Buffer
+	if err := s.newEncoder(&buf).Encode(e); err != nil {
+		return nil, fmt.Errorf("erro ao codificar documento: %w", err)
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return nil, fmt.Errorf("erro ao codificar documento: %w", err)
+	}
+
+	return omitZeroTimes(doc), nil
+}
+
+// newEncoder cria o encoder BSON com as opções e o registry de WithBSONOptions, os mesmos
+// configurados na coleção, para que a codificação própria do store grave como o driver
+func (s *mongoStore[T]) newEncoder(w io.Writer) *bson.Encoder {
+	enc := bson.NewEncoder(bson.NewDocumentWriter(w))
+
+	if opts := s.opts.bsonOptions; opts != nil {
+		if opts.ErrorOnInlineDuplicates {
+			enc.ErrorOnInlineDuplicates()
+		}
+		if opts.IntMinSize {
+			enc.IntMinSize()
+		}
+		if opts.NilByteSliceAsEmpty {
+			enc.NilByteSliceAsEmpty()
+		}
+		if opts.NilMapAsEmpty {
+			enc.NilMapAsEmpty()
+		}
+		if opts.NilSliceAsEmpty {
+			enc.NilSliceAsEmpty()
+		}
+		if opts.OmitZeroStruct {
+			enc.OmitZeroStruct()
+		}
+		if opts.OmitEmpty {
+			enc.OmitEmpty()
+		}
+		if opts.StringifyMapKeysWithFmt {
+			enc.StringifyMapKeysWithFmt()
+		}
+		if opts.UseJSONStructTags {
+			enc.UseJSONStructTags()
+		}
+	}
+
+	if s.opts.registry != nil {
+		enc.SetRegistry(s.opts.registry)
+	}
+
+	return enc
+}
+
+// omitZeroTimes remove do documento, inclusive dos documentos aninhados e dos documentos dentro
+// de arrays, os campos com a data zero
+func omitZeroTimes(doc bson.D) bson.D {
+	kept := doc[:0]
+	for _, elem := range doc {
+		switch value := elem.Value.(type) {
+		case bson.DateTime:
+			if value == zeroDateTime {
+				continue
+			}
+		case bson.D:
+			elem.Value = omitZeroTimes(value)
+		case bson.A:
+			elem.Value = omitZeroTimesInArray(value)
+		}
+		kept = append(kept, elem)
+	}
+
+	return kept
+}
+
+// omitZeroTimesInArray aplica o omitZeroTimes aos documentos do array. Datas zero que são
+// elementos do próprio array são mantidas, pois removê-las mudaria a posição dos demais
+func omitZeroTimesInArray(values bson.A) bson.A {
+	for i, value := range values {
+		switch value := value.(type) {
+		case bson.D:
+			values[i] = omitZeroTimes(value)
+		case bson.A:
+			values[i] = omitZeroTimesInArray(value)
+		}
+	}
+
+	return values
+}
+
+// isDuplicateKeyCode indica se o código de erro do servidor é de chave duplicada
+func isDuplicateKeyCode(code int) bool {
+	return code == 11000 || code == 11001 || code == 12582
+}
+
+// UpdateWhere atualiza o documento como Update, mas apenas se ele também atender ao filtro
+// extra (compare-and-set). Retorna ErrNotFound quando o documento não existe e ErrConflict
+// quando ele existe, mas não atende ao filtro
+func (s *mongoStore[T]) UpdateWhere(ctx context.Context, e *T, extra map[string]any) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	now := time.Now()
+	value := reflect.ValueOf(e).Elem()
+	id, _ := getEntityID(value)
+
+	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() && s.opts.managesUpdatedAt() {
+		updated.Set(reflect.ValueOf(now))
+	}
+
+	conditions, err := s.mapToBsonD(extra)
+	if err != nil {
+		return nil, err
+	}
+	filter := append(bson.D{{Key: "_id", Value: id}}, conditions...)
+	update := bson.M{"$set": e}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated T
+	err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if len(extra) > 0 && s.Has(ctx, id) {
+			return nil, fmt.Errorf("documento com id %v: %w", id, ErrConflict)
+		}
+		return nil, fmt.Errorf("documento não encontrado com id %v: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	}
+
+	if err := applyStringConverters(reflect.ValueOf(&updated).Elem()); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// Update atualiza um documento substituindo todos os campos,
+// inclusive os que possuem o valor zero do Go. Para atualizações parciais use UpdateFields.
+func (s *mongoStore[T]) Update(ctx context.Context, e *T) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	now := time.Now()
+	value := reflect.ValueOf(e).Elem()
+	id, _ := getEntityID(value)
+
+	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() && s.opts.managesUpdatedAt() {
+		updated.Set(reflect.ValueOf(now))
+	}
+
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": e}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated T
+	err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("documento não encontrado para atualização com id %v: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	}
+
+	if err := applyStringConverters(reflect.ValueOf(&updated).Elem()); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// UpdateFields atualiza apenas os campos informados (chaves bson), preservando os demais valores do documento
+func (s *mongoStore[T]) UpdateFields(ctx context.Context, e *T, fields ...string) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("nenhum campo informado para atualização")
+	}
+
+	now := time.Now()
+	value := reflect.ValueOf(e).Elem()
+	id, _ := getEntityID(value)
+
+	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() && s.opts.managesUpdatedAt() {
+		updated.Set(reflect.ValueOf(now))
+	}
+
+	doc := s.normalizeDocForUpsert(e)
+
+	setFields := bson.M{}
+	for _, field := range fields {
+		fieldValue, ok := doc[field]
+		if !ok {
+			return nil, fmt.Errorf("campo %s não encontrado no documento", field)
+		}
+		setFields[field] = fieldValue
+	}
+
+	if updatedAt, ok := doc[s.timestamps.updated]; ok && s.opts.managesUpdatedAt() {
+		setFields[s.timestamps.updated] = updatedAt
+	}
+
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": setFields}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated T
+	err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("documento não encontrado para atualização com id %v: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	}
+
+	if err := applyStringConverters(reflect.ValueOf(&updated).Elem()); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// UpdateMany atualiza atributos de múltiplos documentos baseado em um filtro
+func (s *mongoStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(fd) == 0 {
+		return nil, fmt.Errorf("nenhum update fornecido")
+	}
+
+	now := time.Now()
+	operations := make([]mongo.WriteModel, len(fd))
+
+	for i, fb := range fd {
+		if len(fb.Filter) == 0 {
+			return nil, fmt.Errorf("filtro é obrigatório para update %d", i)
+		}
+
+		// Constrói o filtro dinamicamente
+		filter := bson.M{}
+		maps.Copy(filter, fb.Filter)
+
+		// Constrói o $set com os campos fornecidos
+		setFields := bson.M{}
+		if s.opts.managesUpdatedAt() {
+			setFields[s.timestamps.updated] = now
+		}
+
+		// Adiciona todos os campos do map
+		maps.Copy(setFields, fb.Fields)
+
+		pipeline := mongo.Pipeline{
+			{
+				{Key: "$set", Value: setFields},
+			},
+		}
+
+		operations[i] = mongo.NewUpdateManyModel().
+			SetFilter(filter).
+			SetUpdate(pipeline).
+			SetUpsert(false)
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.BulkWrite(ctx, operations)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar documentos: %w", err)
+	}
+
+	return &BulkWriteResult{
+		InsertedCount: result.InsertedCount,
+		MatchedCount:  result.MatchedCount,
+		ModifiedCount: result.ModifiedCount,
+		DeletedCount:  result.DeletedCount,
+		UpsertedCount: result.UpsertedCount,
+		UpsertedIDs:   result.UpsertedIDs,
+	}, nil
+}
+
+func (s *mongoStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (_ *UpdateResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	filter, update, err := s.upsertOperation(reflect.ValueOf(e).Elem(), f)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	}
+
+	return &UpdateResult{
+		MatchedCount:  result.MatchedCount,
+		ModifiedCount: result.ModifiedCount,
+		UpsertedCount: result.UpsertedCount,
+		UpsertedID:    result.UpsertedID,
+	}, nil
+}
+
+// UpsertReturning cria ou atualiza o documento como Upsert e retorna o documento gravado,
+// já com a mescla dos campos existentes e os valores definidos na inserção
+func (s *mongoStore[T]) UpsertReturning(ctx context.Context, e *T, f []StoreUpsertFilter) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	filter, update, err := s.upsertOperation(reflect.ValueOf(e).Elem(), f)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+	if err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	}
+
+	if err := applyStringConverters(reflect.ValueOf(&result).Elem()); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+	}
+
+	return &result, nil
+}
+
+// upsertOperation preenche os timestamps vazios da entidade e monta o filtro e o update do
+// upsert. Sem filtros, o documento é identificado pelo _id
+func (s *mongoStore[T]) upsertOperation(value reflect.Value, f []StoreUpsertFilter) (bson.D, bson.M, error) {
+	now := time.Now()
+
+	if created := value.FieldByName("CreatedAt"); created.IsValid() && s.opts.managesCreatedAt() {
+		if created.IsZero() {
+			created.Set(reflect.ValueOf(now))
+		}
+	}
+
+	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() && s.opts.managesUpdatedAt() {
+		if updated.IsZero() {
+			updated.Set(reflect.ValueOf(now))
+		}
+	}
+
+	if len(f) == 0 {
+		f = []StoreUpsertFilter{
+			{
+				UpsertFieldKey: "ID",
+				UpsertBsonKey:  "_id",
+			},
+		}
+	}
+
+	filter, err := s.convertStoreUpsertFilterToBsonD(value, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	update, err := s.upsertUpdate(value, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filter, update, nil
+}
+
+func (s *mongoStore[T]) UpsertMany(ctx context.Context, e []T, f []StoreUpsertFilter) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return s.UpsertManyWith(ctx, upsertItems(e, f))
+}
+
+// UpsertManyWith executa o upsert de cada item com os seus próprios filtros em um BulkWrite
+// ordenado. Itens sem filtros casam pelo _id
+func (s *mongoStore[T]) UpsertManyWith(ctx context.Context, items []UpsertItem[T]) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	now := time.Now()
+	operations := make([]mongo.WriteModel, len(items))
+
+	for i, item := range items {
+		value := reflect.ValueOf(&item.Entity).Elem()
+		f := item.Filters
+
+		if created := value.FieldByName("CreatedAt"); created.IsValid() && s.opts.managesCreatedAt() {
+			if created.IsZero() {
+				created.Set(reflect.ValueOf(now))
+			}
+		}
+
+		if updated := value.FieldByName("UpdatedAt"); updated.IsValid() && s.opts.managesUpdatedAt() {
+			if updated.IsZero() {
+				updated.Set(reflect.ValueOf(now))
+			}
+		}
+
+		if len(f) == 0 {
+			f = []StoreUpsertFilter{
+				{
+					UpsertFieldKey: "ID",
+					UpsertBsonKey:  "_id",
+				},
+			}
+		}
+
+		filter, err := s.convertStoreUpsertFilterToBsonD(value, f)
+		if err != nil {
+			return nil, err
+		}
+
+		update, err := s.upsertUpdate(value, f)
+		if err != nil {
+			return nil, fmt.Errorf("%w from %d", err, i)
+		}
+
+		operations[i] = mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(update).
+			SetUpsert(true)
+	}
+
+	return s.bulkWriteInBatches(ctx, operations, s.opts.ordered(true))
+}
+
+// bulkWriteInBatches envia as operações em lotes de até chunkSize operações, respeitando os
+// limites de operações e tamanho por BulkWrite do servidor, e agrega os resultados (veja writeInBatches)
+func (s *mongoStore[T]) bulkWriteInBatches(ctx context.Context, operations []mongo.WriteModel, ordered bool) (*BulkWriteResult, error) {
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.BulkWrite().SetOrdered(ordered)
+
+	return writeInBatches(operations, s.opts.chunkSize(), ordered, func(batch []mongo.WriteModel) (*mongo.BulkWriteResult, error) {
+		return coll.BulkWrite(ctx, batch, opts)
+	})
+}
+
+// writeInBatches executa write em lotes de até size operações e agrega os resultados. Os índices
+// de UpsertedIDs e Errors são relativos à lista completa de operações. Em modo ordenado a
+// execução para no primeiro lote com erro e o resultado traz o que os lotes anteriores (e a parte
+// aplicada do lote com erro) gravaram; no desordenado os erros de escrita de cada operação são
+// reunidos em Errors e o resultado é retornado junto com o erro resumido
+func writeInBatches(operations []mongo.WriteModel, size int, ordered bool, write func([]mongo.WriteModel) (*mongo.BulkWriteResult, error)) (*BulkWriteResult, error) {
+	combined := &BulkWriteResult{UpsertedIDs: make(map[int64]any)}
+
+	for start := 0; start < len(operations); start += size {
+		end := min(start+size, len(operations))
+
+		result, err := write(operations[start:end])
+		if err != nil {
+			var bulkErr mongo.BulkWriteException
+			if ordered || result == nil || !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+				combined.add(result, start)
+				return combined, fmt.Errorf("erro ao atualizar documentos: %w", err)
+			}
+
+			for _, writeErr := range bulkErr.WriteErrors {
+				combined.addError(int64(start+writeErr.Index), writeErr)
+			}
+		}
+
+		combined.add(result, start)
+	}
+
+	return combined, combined.itemsError()
+}
+
+// add soma ao resultado o BulkWrite de um lote iniciado no índice start da lista de operações
+func (r *BulkWriteResult) add(result *mongo.BulkWriteResult, start int) {
+	if result == nil {
+		return
+	}
+
+	r.InsertedCount += result.InsertedCount
+	r.MatchedCount += result.MatchedCount
+	r.ModifiedCount += result.ModifiedCount
+	r.DeletedCount += result.DeletedCount
+	r.UpsertedCount += result.UpsertedCount
+	for index, id := range result.UpsertedIDs {
+		r.UpsertedIDs[int64(start)+index] = id
+	}
+}
+
+// Delete exclui um documento, retornando ErrNotFound quando ele não existe
+func (s *mongoStore[T]) Delete(ctx context.Context, id any) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("erro ao deletar documento: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("nenhum documento encontrado com id %v: %w", id, ErrNotFound)
+	}
+
+	return nil
+}
+
+// DeleteIfExists exclui um documento pelo ID e informa se ele existia
+func (s *mongoStore[T]) DeleteIfExists(ctx context.Context, id any) (_ bool, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	result, err := s.DeleteReturning(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// DeleteReturning exclui um documento pelo ID e retorna o DeletedCount, distinguindo a
+// exclusão (1) do documento inexistente (0) sem uma consulta extra
+func (s *mongoStore[T]) DeleteReturning(ctx context.Context, id any) (_ *DeleteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao deletar documento: %w", err)
+	}
+
+	return &DeleteResult{DeletedCount: result.DeletedCount}, nil
+}
+
+// DeleteOne remove o primeiro documento que corresponde ao filtro
+func (s *mongoStore[T]) DeleteOne(ctx context.Context, f map[string]interface{}) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if f == nil || len(f) == 0 {
+		return fmt.Errorf("filtro não pode ser nulo ou vazio")
+	}
+
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return err
+	}
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := coll.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("erro ao deletar documento: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("nenhum documento encontrado com filtro %v: %w", f, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s *mongoStore[T]) DeleteMany(ctx context.Context, f map[string]any) (_ *DeleteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if f == nil {
+		return nil, fmt.Errorf("filtro não pode ser nulo")
+	}
+
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return nil, err
+	}
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.DeleteMany(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao deletar documentos: %w", err)
+	}
+
+	return &DeleteResult{result.DeletedCount}, nil
+}
+
+// DeleteManyBatch remove os documentos de cada filtro com um DeleteManyModel por filtro, em um
+// único BulkWrite (dividido pelo WithBatchSize), e retorna o DeletedCount agregado
+func (s *mongoStore[T]) DeleteManyBatch(ctx context.Context, filters []map[string]any) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("nenhum filtro fornecido")
+	}
+
+	operations := make([]mongo.WriteModel, len(filters))
+	for i, f := range filters {
+		if f == nil {
+			return nil, fmt.Errorf("filtro não pode ser nulo no delete %d", i)
+		}
+
+		filter, err := s.mapToBsonD(f)
+		if err != nil {
+			return nil, fmt.Errorf("filtro inválido no delete %d: %w", i, err)
+		}
+
+		operations[i] = mongo.NewDeleteManyModel().SetFilter(filter)
+	}
+
+	return s.bulkWriteInBatches(ctx, operations, s.opts.ordered(true))
+}
+
+// DeleteByIds remove os documentos cujos _id estão em ids usando $in.
+// Uma lista vazia não executa nenhuma operação
+func (s *mongoStore[T]) DeleteByIds(ctx context.Context, ids []any) (_ *DeleteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(ids) == 0 {
+		return &DeleteResult{DeletedCount: 0}, nil
+	}
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
 	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar documento: %w", err)
+		return nil, fmt.Errorf("erro ao deletar documentos: %w", err)
 	}
 
-	return &result, nil
+	return &DeleteResult{result.DeletedCount}, nil
 }
 
-func (s *mongoStore[T]) FindOne(ctx context.Context, f map[string]interface{}) (*T, error) {
-	var result T
-
-	err := s.coll.FindOne(ctx, f).Decode(&result)
-	if errors.Is(err, mongo.ErrNoDocuments) {
-		return nil, fmt.Errorf("documento não encontrado com filtro %v", f)
+// Has verifica se um documento existe
+func (s *mongoStore[T]) Has(ctx context.Context, id any) bool {
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return false
 	}
+
+	res, err := coll.Find(ctx, bson.M{"_id": id}, options.Find().SetLimit(1))
 	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar documento: %w", err)
+		return false
 	}
 
-	return &result, nil
+	return res.RemainingBatchLength() == 1
 }
 
-// Save salva um documento
-func (s *mongoStore[T]) Save(ctx context.Context, e *T) (*T, error) {
-	now := time.Now()
-	value := reflect.ValueOf(e).Elem()
+// HasWhere verifica se existe algum documento que corresponde ao filtro, retornando o erro
+// da consulta em vez de reportá-lo como inexistente
+func (s *mongoStore[T]) HasWhere(ctx context.Context, f map[string]any) (_ bool, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
 
-	if created := value.FieldByName("CreatedAt"); created.IsValid() {
-		created.Set(reflect.ValueOf(now))
+	filter, err := s.mapToBsonD(f)
+	if err != nil {
+		return false, err
 	}
-	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() {
-		updated.Set(reflect.ValueOf(now))
+
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return false, err
 	}
 
-	_, err := s.coll.InsertOne(ctx, e)
+	total, err := coll.CountDocuments(ctx, filter, options.Count().SetLimit(1))
 	if err != nil {
-		return nil, fmt.Errorf("erro ao salvar documento: %w", err)
+		return false, fmt.Errorf("erro ao verificar documentos: %w", err)
 	}
 
-	return e, nil
+	return total > 0, nil
 }
 
-// SaveMany salva vários documentos
-func (s *mongoStore[T]) SaveMany(ctx context.Context, e []T) (*InsertManyResult, error) {
-	now := time.Now()
+// HasAll verifica em uma única consulta quais IDs existem. O mapa retornado contém todos
+// os IDs informados, com true para os existentes
+func (s *mongoStore[T]) HasAll(ctx context.Context, ids []any) (_ map[any]bool, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
 
-	docs := make([]any, len(e))
-	for i, doc := range e {
-		value := reflect.ValueOf(&doc).Elem()
+	result := make(map[any]bool, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
 
-		if created := value.FieldByName("CreatedAt"); created.IsValid() {
-			created.Set(reflect.ValueOf(now))
-		}
-		if updated := value.FieldByName("UpdatedAt"); updated.IsValid() {
-			updated.Set(reflect.ValueOf(now))
-		}
+	findOpts := options.Find().SetProjection(bson.M{"_id": 1})
+	coll, err := s.collection(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		docs[i] = doc
+	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar documentos: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	opts := options.InsertMany()
-	opts.SetOrdered(false)
+	var docs []struct {
+		ID any `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar documentos: %w", err)
+	}
 
-	result, err := s.coll.InsertMany(ctx, docs, opts)
-	if err != nil {
-		if result != nil {
-			return &InsertManyResult{InsertedIDs: result.InsertedIDs}, fmt.Errorf("erro ao criar documentos: %w", err)
-		}
-		return nil, fmt.Errorf("erro ao criar documentos: %w", err)
+	found := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		found[fmt.Sprint(doc.ID)] = true
 	}
 
-	return &InsertManyResult{InsertedIDs: result.InsertedIDs}, nil
-}
+	for _, id := range ids {
+		result[id] = found[fmt.Sprint(id)]
+	}
 
-// SaveManyNotOrdered salva vários documentos de forma desordenada
-func (s *mongoStore[T]) SaveManyNotOrdered(ctx context.Context, e []T) (*InsertManyResult, error) {
-	now := time.Now()
+	return result, nil
+}
 
-	docs := make([]any, len(e))
-	for i, doc := range e {
-		value := reflect.ValueOf(&doc).Elem()
+// MissingIds retorna os ids informados que não existem, em uma única consulta
+func (s *mongoStore[T]) MissingIds(ctx context.Context, ids []any) (_ []any, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
 
-		if created := value.FieldByName("CreatedAt"); created.IsValid() {
-			created.Set(reflect.ValueOf(now))
-		}
-		if updated := value.FieldByName("UpdatedAt"); updated.IsValid() {
-			updated.Set(reflect.ValueOf(now))
-		}
+	return missingIds[T](ctx, s, ids)
+}
 
-		docs[i] = doc
+// AggregateInto executa o pipeline de agregação na coleção do store e decodifica
+// os resultados em R, permitindo formatos diferentes da entidade (ex.: $group)
+//
+//	type Total struct {
+//		Active bool  `bson:"_id"`
+//		Count  int64 `bson:"count"`
+//	}
+//
+//	totals, err := store.AggregateInto[Total](ctx, s, mongo.Pipeline{
+//		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$active"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+//	})
+func AggregateInto[R any, T any](ctx context.Context, s Store[T], pipeline any) ([]R, error) {
+	ms, ok := s.(*mongoStore[T])
+	if !ok {
+		return nil, fmt.Errorf("aggregation is only supported by Mongo module")
 	}
 
-	result, err := s.coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	coll, err := ms.collection(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao criar documentos: %w", err)
+		return nil, err
 	}
 
-	return &InsertManyResult{InsertedIDs: result.InsertedIDs}, nil
-}
-
-// Update atualiza um documento
-func (s *mongoStore[T]) Update(ctx context.Context, e *T) (*T, error) {
-	now := time.Now()
-	value := reflect.ValueOf(e).Elem()
-	id := value.FieldByName("ID").String()
-
-	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() {
-		updated.Set(reflect.ValueOf(now))
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao executar agregação: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	filter := bson.M{"_id": id}
-	update := bson.M{"$set": e}
-	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-
-	var updated T
-	err := s.coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
-	if errors.Is(err, mongo.ErrNoDocuments) {
-		return nil, fmt.Errorf("documento não encontrado para atualização")
+	var results []R
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar resultado da agregação: %w", err)
 	}
-	if err != nil {
-		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	if err = convertStringFields(results); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar resultado da agregação: %w", err)
 	}
 
-	return &updated, nil
+	return results, nil
 }
 
-// UpdateMany atualiza atributos de múltiplos documentos baseado em um filtro
-func (s *mongoStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate) (*BulkWriteResult, error) {
-	if len(fd) == 0 {
-		return nil, fmt.Errorf("nenhum update fornecido")
+// entityBsonFields retorna os campos de primeiro nível de T como gravados pelo driver:
+// o nome da tag `bson` ou, sem tag, o nome do campo em minúsculas. Structs com inline são expandidas
+func entityBsonFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{"_id": true}
+	if t.Kind() != reflect.Struct {
+		return fields
 	}
 
-	now := time.Now()
-	operations := make([]mongo.WriteModel, len(fd))
-
-	for i, fb := range fd {
-		if len(fb.Filter) == 0 {
-			return nil, fmt.Errorf("filtro é obrigatório para update %d", i)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
 		}
 
-		// Constrói o filtro dinamicamente
-		filter := bson.M{}
-		maps.Copy(filter, fb.Filter)
-
-		// Constrói o $set com os campos fornecidos
-		setFields := bson.M{
-			"updatedAt": now,
+		name, opts, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if name == "-" {
+			continue
 		}
 
-		// Adiciona todos os campos do map
-		maps.Copy(setFields, fb.Fields)
-
-		pipeline := mongo.Pipeline{
-			{
-				{Key: "$set", Value: setFields},
-			},
+		if strings.Contains(opts, "inline") && field.Type.Kind() == reflect.Struct {
+			maps.Copy(fields, entityBsonFields(field.Type))
+			continue
 		}
 
-		operations[i] = mongo.NewUpdateManyModel().
-			SetFilter(filter).
-			SetUpdate(pipeline).
-			SetUpsert(false)
-	}
-
-	result, err := s.coll.BulkWrite(ctx, operations)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao atualizar documentos: %w", err)
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = true
 	}
 
-	return &BulkWriteResult{
-		InsertedCount: result.InsertedCount,
-		MatchedCount:  result.MatchedCount,
-		ModifiedCount: result.ModifiedCount,
-		DeletedCount:  result.DeletedCount,
-		UpsertedCount: result.UpsertedCount,
-		UpsertedIDs:   result.UpsertedIDs,
-	}, nil
+	return fields
 }
 
-func (s *mongoStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (*UpdateResult, error) {
-	now := time.Now()
-	value := reflect.ValueOf(e).Elem()
-
-	if created := value.FieldByName("CreatedAt"); created.IsValid() {
-		if created.IsZero() {
-			created.Set(reflect.ValueOf(now))
+// bsonFieldKey resolve a chave do documento do campo Go de t, aceitando caminhos com ponto.
+// Structs com inline não acrescentam nível à chave; embutidas sem inline são subdocumentos
+func bsonFieldKey(t reflect.Type, path string) (string, bool) {
+	keys := make([]string, 0, 1)
+	for name := range strings.SplitSeq(path, ".") {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return "", false
 		}
-	}
 
-	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() {
-		if updated.IsZero() {
-			updated.Set(reflect.ValueOf(now))
+		field, ok := t.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return "", false
 		}
-	}
 
-	var id string
-	if fieldValue := value.FieldByName("ID"); fieldValue.IsValid() {
-		id = fieldValue.String()
-	}
+		// Percorre os campos embutidos que levam ao campo promovido
+		for i := range field.Index {
+			current := t.FieldByIndex(field.Index[:i+1])
 
-	if len(f) == 0 {
-		f = []StoreUpsertFilter{
-			{
-				UpsertFieldKey: "ID",
-				UpsertBsonKey:  "_id",
-			},
+			key, opts, _ := strings.Cut(current.Tag.Get("bson"), ",")
+			if key == "-" {
+				return "", false
+			}
+			if strings.Contains(opts, "inline") {
+				continue
+			}
+			if key == "" {
+				key = strings.ToLower(current.Name)
+			}
+			keys = append(keys, key)
 		}
-	}
 
-	filter, err := s.convertStoreUpsertFilterToBsonD(value, f)
-	if err != nil {
-		return nil, err
+		t = field.Type
 	}
 
-	update := bson.M{
-		"$set":         s.normalizeDocForUpsert(e),
-		"$setOnInsert": bson.M{"_id": id},
+	return strings.Join(keys, "."), true
+}
+
+// bsonTimestampFields resolve as chaves dos campos CreatedAt e UpdatedAt de t pelas tags `bson`,
+// como gravadas pelo driver, usando createdAt e updatedAt quando o campo não existe ou é ignorado
+func bsonTimestampFields(t reflect.Type) timestampFields {
+	names := timestampFields{created: "createdAt", updated: "updatedAt"}
+	if t.Kind() != reflect.Struct {
+		return names
 	}
 
-	result, err := s.coll.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
-	if err != nil {
-		return nil, fmt.Errorf("erro ao atualizar documento: %w", err)
+	key := func(fieldName, def string) string {
+		field, ok := t.FieldByName(fieldName)
+		if !ok {
+			return def
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		switch name {
+		case "-":
+			return def
+		case "":
+			return strings.ToLower(field.Name)
+		}
+
+		return name
 	}
+	names.created = key("CreatedAt", names.created)
+	names.updated = key("UpdatedAt", names.updated)
 
-	return &UpdateResult{
-		MatchedCount:  result.MatchedCount,
-		ModifiedCount: result.ModifiedCount,
-		UpsertedCount: result.UpsertedCount,
-		UpsertedID:    result.UpsertedID,
-	}, nil
+	return names
 }
 
-func (s *mongoStore[T]) UpsertMany(ctx context.Context, e []T, f []StoreUpsertFilter) (*BulkWriteResult, error) {
-	now := time.Now()
-	operations := make([]mongo.WriteModel, len(e))
+// bsonFieldInfos descreve os campos de t como mapeados pelo driver, expandindo as structs
+// com inline. Campos com bson:"-" são omitidos e os não exportados aparecem como Skipped
+func bsonFieldInfos(t reflect.Type, fieldPrefix string) []FieldInfo {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
 
-	for i, doc := range e {
-		value := reflect.ValueOf(&doc).Elem()
+	infos := make([]FieldInfo, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
 
-		if created := value.FieldByName("CreatedAt"); created.IsValid() {
-			if created.IsZero() {
-				created.Set(reflect.ValueOf(now))
-			}
+		name, opts, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if name == "-" {
+			continue
 		}
 
-		if updated := value.FieldByName("UpdatedAt"); updated.IsValid() {
-			if updated.IsZero() {
-				updated.Set(reflect.ValueOf(now))
-			}
+		if strings.Contains(opts, "inline") && field.Type.Kind() == reflect.Struct {
+			infos = append(infos, bsonFieldInfos(field.Type, fieldPrefix+field.Name+".")...)
+			continue
 		}
 
-		fieldValue := value.FieldByName("ID")
-		if !fieldValue.IsValid() {
-			return nil, fmt.Errorf("invalid id from %d", i)
+		if name == "" {
+			name = strings.ToLower(field.Name)
 		}
-		id := fieldValue.String()
 
-		if len(f) == 0 {
-			f = []StoreUpsertFilter{
-				{
-					UpsertFieldKey: "ID",
-					UpsertBsonKey:  "_id",
-				},
+		info := FieldInfo{Field: fieldPrefix + field.Name, Column: name}
+		for opt := range strings.SplitSeq(opts, ",") {
+			if opt != "" {
+				info.Flags = append(info.Flags, opt)
 			}
 		}
+		info.PrimaryKey = name == "_id"
+		info.Skipped = !field.IsExported()
 
-		filter, err := s.convertStoreUpsertFilterToBsonD(value, f)
-		if err != nil {
-			return nil, err
-		}
-
-		update := bson.M{
-			"$set":         s.normalizeDocForUpsert(doc),
-			"$setOnInsert": bson.M{"_id": id},
-		}
-
-		operations[i] = mongo.NewUpdateOneModel().
-			SetFilter(filter).
-			SetUpdate(update).
-			SetUpsert(true)
+		infos = append(infos, info)
 	}
 
-	result, err := s.coll.BulkWrite(ctx, operations)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao atualizar documentos: %w", err)
+	return infos
+}
+
+// sortKey resolve o campo de ordenação para a chave do documento: "id" vira _id e o padrão
+// "createdAt" de Initialize vira a chave do CreatedAt de T quando a entidade não tem esse campo
+func (s *mongoStore[T]) sortKey(field string) string {
+	switch {
+	case field == "id":
+		return "_id"
+	case field == defaultSortField && !s.hasField(field):
+		return s.timestamps.created
 	}
 
-	return &BulkWriteResult{
-		InsertedCount: result.InsertedCount,
-		MatchedCount:  result.MatchedCount,
-		ModifiedCount: result.ModifiedCount,
-		DeletedCount:  result.DeletedCount,
-		UpsertedCount: result.UpsertedCount,
-		UpsertedIDs:   result.UpsertedIDs,
-	}, nil
+	return field
 }
 
-// Delete exclui um documento
-func (s *mongoStore[T]) Delete(ctx context.Context, id any) error {
-	result, err := s.coll.DeleteOne(ctx, bson.M{"_id": id})
-	if err != nil {
-		return fmt.Errorf("erro ao deletar documento: %w", err)
+// validateSortField garante que o campo de ordenação é um campo mapeado da entidade ("id"
+// é aceito como alias de _id). Em caminhos com ponto apenas o primeiro nível é validado
+func (s *mongoStore[T]) validateSortField(field string) error {
+	if field == "" || field == "id" {
+		return nil
 	}
 
-	if result.DeletedCount == 0 {
-		return fmt.Errorf("nenhum documento encontrado com id %s", id)
+	if !s.hasField(s.sortKey(field)) {
+		return fmt.Errorf("%w: %s", ErrInvalidSortField, field)
 	}
 
 	return nil
 }
 
-func (s *mongoStore[T]) DeleteOne(ctx context.Context, f map[string]interface{}) error {
-	if f == nil || len(f) == 0 {
-		return fmt.Errorf("filtro não pode ser nulo ou vazio")
-	}
-
-	result, err := s.coll.DeleteOne(ctx, f)
-	if err != nil {
-		return fmt.Errorf("erro ao deletar documento: %w", err)
-	}
-
-	if result.DeletedCount == 0 {
-		return fmt.Errorf("nenhum documento encontrado com filtro %v", f)
+// validateFields garante que os campos usados na consulta (projeção, busca) são campos
+// mapeados da entidade, falhando antes de executar a consulta
+func (s *mongoStore[T]) validateFields(usage string, names []string) error {
+	for _, name := range names {
+		if !s.hasField(name) {
+			return fmt.Errorf("%w na %s: %s", ErrUnknownColumn, usage, name)
+		}
 	}
 
 	return nil
 }
 
-func (s *mongoStore[T]) DeleteMany(ctx context.Context, f map[string]any) (*DeleteResult, error) {
-	if f == nil {
-		return nil, fmt.Errorf("filtro não pode ser nulo")
+// hasField indica se o campo é mapeado na entidade. Em caminhos com ponto apenas o
+// primeiro nível é verificado
+func (s *mongoStore[T]) hasField(path string) bool {
+	root, _, _ := strings.Cut(path, ".")
+	return s.fields[root]
+}
+
+// mongoSort monta a ordenação de SortBy com _id como critério de desempate, garantindo
+// uma ordem total e estável entre páginas quando o campo ordenado possui valores repetidos
+func mongoSort(opts FindOptions) bson.D {
+	sortValue := 1
+	if opts.OrderBy == "DESC" {
+		sortValue = -1
 	}
 
-	filter := s.mapToBsonD(f)
-	result, err := s.coll.DeleteMany(ctx, filter)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao deletar documentos: %w", err)
+	sort := bson.D{{Key: opts.SortBy, Value: sortValue}}
+	if opts.SortBy != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: sortValue})
 	}
 
-	return &DeleteResult{result.DeletedCount}, nil
+	return sort
 }
 
-// Has verifica se um documento existe
-func (s *mongoStore[T]) Has(ctx context.Context, id any) bool {
-	res, err := s.coll.Find(ctx, bson.M{"_id": id}, options.Find().SetLimit(1))
-	if err != nil {
-		return false
+// mongoProjection converte Fields ({campo: 1}) ou ExcludeFields ({campo: 0}) em projeção.
+// Retorna nil quando nenhum dos dois foi informado
+func mongoProjection(opts FindOptions) bson.D {
+	fields, include := opts.Fields, 1
+	if len(fields) == 0 {
+		fields, include = opts.ExcludeFields, 0
 	}
 
-	return res.RemainingBatchLength() == 1
-}
+	if len(fields) == 0 {
+		return nil
+	}
 
-// MapToBsonD converte um mapa genérico para bson.D
-func (s *mongoStore[T]) mapToBsonD(m map[string]any) bson.D {
-	bsonD := bson.D{}
-	for key, value := range m {
-		bsonD = append(bsonD, bson.E{Key: key, Value: value})
+	projection := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		projection = append(projection, bson.E{Key: field, Value: include})
 	}
 
-	return bsonD
+	return projection
+}
+
+// MapToBsonD converte um mapa genérico para bson.D, interpretando o DSL `campo__operador`
+func (s *mongoStore[T]) mapToBsonD(m map[string]any) (bson.D, error) {
+	return buildMongoFilter(m)
 }
 
 func (s *mongoStore[T]) normalizeDocForUpsert(doc any) bson.M {
@@ -484,8 +1907,58 @@ func (s *mongoStore[T]) normalizeDocForUpsert(doc any) bson.M {
 	return normalized
 }
 
+// upsertUpdate monta o update do upsert. Quando os filtros usam o _id, ele é definido apenas na
+// inserção; caso contrário a identidade é a chave composta dos filtros: o _id é gerado pelo
+// banco e os campos da chave (de primeiro nível, fora do $or) são gravados só na inserção
+func (s *mongoStore[T]) upsertUpdate(value reflect.Value, filters []StoreUpsertFilter) (bson.M, error) {
+	set := s.normalizeDocForUpsert(value.Interface())
+	onInsert := bson.M{}
+
+	byID := slices.ContainsFunc(filters, func(f StoreUpsertFilter) bool {
+		return f.UpsertBsonKey == "_id"
+	})
+
+	if byID {
+		if !value.FieldByName("ID").IsValid() {
+			return nil, fmt.Errorf("invalid id")
+		}
+		if id, ok := getEntityID(value); ok {
+			onInsert["_id"] = id
+		}
+	} else {
+		for _, filter := range filters {
+			if filter.Or || strings.Contains(filter.UpsertBsonKey, ".") {
+				continue
+			}
+
+			if fieldValue, ok := set[filter.UpsertBsonKey]; ok {
+				onInsert[filter.UpsertBsonKey] = fieldValue
+				delete(set, filter.UpsertBsonKey)
+			}
+		}
+	}
+
+	update := bson.M{"$set": set}
+	if len(onInsert) > 0 {
+		update["$setOnInsert"] = onInsert
+	}
+
+	return update, nil
+}
+
 func getFieldValue(key string, value reflect.Value) (any, error) {
 	for k := range strings.SplitSeq(key, ".") {
+		// Campos aninhados por ponteiro (ex.: Address *Address) são percorridos pelo valor apontado
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return nil, fmt.Errorf("campo nil no caminho %s", key)
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("invalid value")
+		}
+
 		value = value.FieldByName(k)
 		if !value.IsValid() {
 			return nil, fmt.Errorf("invalid value")
@@ -494,18 +1967,32 @@ func getFieldValue(key string, value reflect.Value) (any, error) {
 	return value.Interface(), nil
 }
 
+// convertStoreUpsertFilterToBsonD monta o filtro do upsert: os filtros comuns são combinados
+// com AND e os marcados com Or são agrupados em um único $or
 func (s *mongoStore[T]) convertStoreUpsertFilterToBsonD(value reflect.Value, filters []StoreUpsertFilter) (bson.D, error) {
 	var bsonD bson.D
+	var or bson.A
 	for _, filter := range filters {
 		fieldValue, err := getFieldValue(filter.UpsertFieldKey, value)
 		if err != nil {
-			return nil, fmt.Errorf("invalid upsert field name from %s", filter.UpsertFieldKey)
+			return nil, fmt.Errorf("invalid upsert field name from %s: %w", filter.UpsertFieldKey, err)
 		}
 
-		bsonD = append(bsonD, bson.E{
+		condition := bson.E{
 			Key:   filter.UpsertBsonKey,
 			Value: fieldValue,
-		})
+		}
+
+		if filter.Or {
+			or = append(or, bson.D{condition})
+			continue
+		}
+
+		bsonD = append(bsonD, condition)
+	}
+
+	if len(or) > 0 {
+		bsonD = append(bsonD, bson.E{Key: "$or", Value: or})
 	}
 
 	return bsonD, nil
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/luma-sys/go-db-store/enum"
+)
+
+var stringConverterType = reflect.TypeFor[enum.StringConverter]()
+
+// convertStringFields aplica o enum.StringConverter nos campos dos documentos decodificados,
+// mantendo o tratamento de enums consistente com o setValue do SQL. O driver do Mongo grava
+// o valor bruto nos campos de tipo string, e o FromString normaliza e valida esse valor
+func convertStringFields[E any](items []E) error {
+	for i := range items {
+		if err := applyStringConverters(reflect.ValueOf(&items[i]).Elem()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyStringConverters percorre os campos da struct, incluindo structs aninhadas, e converte
+// os campos string (ou ponteiros para string) cujo ponteiro implementa enum.StringConverter
+func applyStringConverters(v reflect.Value) error {
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return nil
+	}
+
+	t := v.Type()
+	for i := range v.NumField() {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Struct {
+			if err := applyStringConverters(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field.Kind() != reflect.String || field.String() == "" || !field.Addr().Type().Implements(stringConverterType) {
+			continue
+		}
+
+		converted, err := field.Addr().Interface().(enum.StringConverter).FromString(field.String())
+		if err != nil {
+			return fmt.Errorf("erro ao converter campo %s: %w", t.Field(i).Name, err)
+		}
+
+		value := reflect.ValueOf(converted)
+		if value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+		if value.Type() == field.Type() {
+			field.Set(value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luma-sys/go-db-store/enum"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestConverterSettings struct {
+	Fallback enum.DatabaseDriver `bson:"fallback"`
+}
+
+type TestConverterEntity struct {
+	ID       string               `bson:"_id"`
+	Driver   enum.DatabaseDriver  `bson:"driver"`
+	Replica  *enum.DatabaseDriver `bson:"replica"`
+	Name     string               `bson:"name"`
+	Settings TestConverterSettings
+}
+
+func TestApplyStringConverters(t *testing.T) {
+	t.Run("deve normalizar campos, ponteiros e structs aninhadas", func(t *testing.T) {
+		replica := enum.DatabaseDriver(" MySQL ")
+		entity := TestConverterEntity{
+			ID:       "1",
+			Driver:   "POSTGRES",
+			Replica:  &replica,
+			Name:     " Não é enum ",
+			Settings: TestConverterSettings{Fallback: "Sqlite"},
+		}
+
+		err := applyStringConverters(reflect.ValueOf(&entity).Elem())
+		assert.NoError(t, err)
+		assert.Equal(t, enum.DatabaseDriverPostgres, entity.Driver)
+		assert.Equal(t, enum.DatabaseDriverMysql, *entity.Replica)
+		assert.Equal(t, " Não é enum ", entity.Name)
+		assert.Equal(t, enum.DatabaseDriverSqlite, entity.Settings.Fallback)
+	})
+
+	t.Run("deve ignorar valores vazios e ponteiros nil", func(t *testing.T) {
+		entity := TestConverterEntity{ID: "2"}
+
+		err := applyStringConverters(reflect.ValueOf(&entity).Elem())
+		assert.NoError(t, err)
+		assert.Empty(t, entity.Driver)
+		assert.Nil(t, entity.Replica)
+	})
+
+	t.Run("deve retornar erro para valor inválido", func(t *testing.T) {
+		entities := []TestConverterEntity{{ID: "3", Driver: "postgres"}, {ID: "4", Driver: "db2"}}
+
+		err := convertStringFields(entities)
+		assert.ErrorContains(t, err, "erro ao converter campo Driver")
+		assert.Equal(t, enum.DatabaseDriverPostgres, entities[0].Driver)
+	})
+}
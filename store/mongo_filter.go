@@ -0,0 +1,323 @@
+package store
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	mongoutils "github.com/luma-sys/go-db-store/mongo"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// buildMongoFilter converte os filtros para bson.D interpretando o mesmo DSL `campo__operador`
+// usado pelo buildWhereClause do SQL. Chaves sem operador conhecido (incluindo filtros
+// nativos como $or ou bson.M com operadores) são enviadas sem alteração.
+//
+// Operadores suportados:
+//
+//	"age__gt": 30              // {age: {$gt: 30}}
+//	"age__gte": 30             // {age: {$gte: 30}}
+//	"age__lt": 30              // {age: {$lt: 30}}
+//	"age__lte": 30             // {age: {$lte: 30}}
+//	"name": nil                // {name: null} (nulo ou ausente, como o IS NULL do SQL)
+//	"name__not": "John"        // {name: {$ne: "John"}}
+//	"name__not": nil           // {name: {$ne: null}}
+//	"name__in": []string{...}  // {name: {$in: [...]}}
+//	"name__not_in": []string{} // {name: {$nin: [...]}}
+//	"name__like": "%John%"     // {name: {$regex: "^.*John.*$"}}
+//	"name__ilike": "%john%"    // {name: {$regex: "^.*john.*$", $options: "i"}}
+//	"name__not_like": "%John%" // {name: {$not: /^.*John.*$/}}
+//	"name__is_null": true      // {name: {$eq: null}} (nulo ou ausente)
+//	"name__is_null": false     // {name: {$ne: null}}
+//	"name__is_not_null": true  // {name: {$ne: null}}
+//	"name__exists": true       // {name: {$exists: true}}
+//	"createdAt__month": 1      // {$expr: {$eq: [{$month: "$createdAt"}, 1]}}
+//	"meta.status__json_eq": "x" // {meta.status: {$eq: "x"}} (mesma chave do filtro JSON do SQL)
+//	"items__elem_match": map[string]any{"sku": "A1", "qty__gte": 2}
+//	                           // {items: {$elemMatch: {qty: {$gte: 2}, sku: "A1"}}}
+//
+// A chave especial "__or_like" busca um termo em vários campos, sem diferenciar maiúsculas,
+// combinando as condições com $or (ex.: autocomplete):
+//
+//	"__or_like": map[string]any{"term": "jo", "fields": []string{"name", "description"}}
+//	                           // {$or: [{name: {$regex: "jo", $options: "i"}}, {description: ...}]}
+//
+// Termo vazio ignora o filtro; um valor fora desse formato, sem campos ou com campos que não
+// sejam texto retorna erro. Se o filtro também tiver um $or nativo, o do __or_like é
+// incluído em um $and para que os dois sejam aplicados.
+//
+// A chave "__filter" recebe uma expressão Filter (ver Where) com AND, OR e NOT aninhados,
+// convertida em $and, $or e $nor e combinada com as demais chaves do mapa.
+//
+// O sub-filtro do elem_match usa o mesmo DSL e é aplicado a cada elemento do array (apenas Mongo).
+//
+// As partes de data (year, month, day e dow, com domingo = 0) usam $expr; várias delas são
+// combinadas com $and em um único $expr.
+//
+// Operadores aplicados ao mesmo campo são combinados em um único documento
+// (ex.: age__gte e age__lte geram {age: {$gte: ..., $lte: ...}}).
+//
+// As chaves são ordenadas em todos os níveis, então o mesmo filtro gera sempre o mesmo
+// bson.D, favorecendo a seleção de índices e a reprodutibilidade dos logs.
+func buildMongoFilter(filters map[string]any) (bson.D, error) {
+	filter := bson.D{}
+
+	// Ordena as chaves
+	keys := slices.Sorted(maps.Keys(filters))
+
+	conditions := make(map[string]bson.M)
+	var exprs bson.A
+	var expression bson.D
+	for _, key := range keys {
+		value := filters[key]
+
+		if f, ok := filterExpression(value); ok && key == filterKey {
+			e, err := buildMongoExpression(f)
+			if err != nil {
+				return nil, err
+			}
+			expression = e
+			continue
+		}
+
+		if key == orLikeKey {
+			or, err := orLikeConditions(value)
+			if err != nil {
+				return nil, err
+			}
+			if len(or) > 0 {
+				if _, hasOr := filters["$or"]; hasOr {
+					filter = append(filter, bson.E{Key: "$and", Value: bson.A{bson.D{{Key: "$or", Value: or}}}})
+				} else {
+					filter = append(filter, bson.E{Key: "$or", Value: or})
+				}
+			}
+			continue
+		}
+
+		field, operator, found := strings.Cut(key, "__")
+		if part, ok := mongoDatePart(field, operator); found && ok {
+			exprs = append(exprs, bson.M{"$eq": bson.A{part, value}})
+			continue
+		}
+
+		condition, ok := mongoCondition(operator, value)
+		if sub, isMap := value.(map[string]any); found && operator == "elem_match" && isMap {
+			// O sub-filtro usa o mesmo DSL e pode estar malformado, por isso é montado aqui
+			elemFilter, err := buildMongoFilter(sub)
+			if err != nil {
+				return nil, err
+			}
+			condition = bson.M{"$elemMatch": elemFilter}
+		}
+		if !found || !ok {
+			filter = append(filter, bson.E{Key: key, Value: value})
+			continue
+		}
+
+		if existing, ok := conditions[field]; ok {
+			maps.Copy(existing, condition)
+			continue
+		}
+
+		conditions[field] = condition
+		filter = append(filter, bson.E{Key: field, Value: condition})
+	}
+
+	// Os operadores de cada campo também são ordenados, para que o filtro gerado seja sempre o mesmo
+	for i, e := range filter {
+		if condition, ok := conditions[e.Key]; ok {
+			filter[i].Value = sortedDoc(condition)
+		}
+	}
+
+	switch len(exprs) {
+	case 0:
+	case 1:
+		filter = append(filter, bson.E{Key: "$expr", Value: exprs[0]})
+	default:
+		filter = append(filter, bson.E{Key: "$expr", Value: bson.M{"$and": exprs}})
+	}
+
+	if expression != nil {
+		if len(filter) == 0 {
+			return expression, nil
+		}
+		// A expressão pode repetir campos ou o $and das demais chaves, então os dois são combinados em um $and externo
+		return bson.D{{Key: "$and", Value: bson.A{filter, expression}}}, nil
+	}
+
+	return filter, nil
+}
+
+// buildMongoExpression converte a expressão Filter em $and, $or e $nor. O NOT usa $nor com um
+// único elemento, que nega o documento inteiro (e não campo a campo, como o $not)
+func buildMongoExpression(filter Filter) (bson.D, error) {
+	switch filter.op {
+	case filterNot:
+		child, err := buildMongoExpression(filter.children[0])
+		if err != nil {
+			return nil, err
+		}
+		return bson.D{{Key: "$nor", Value: bson.A{child}}}, nil
+	case filterAnd, filterOr:
+		if len(filter.children) == 0 {
+			if filter.op == filterOr {
+				// O $or não aceita array vazio; {$expr: false} não casa com nenhum documento
+				return bson.D{{Key: "$expr", Value: false}}, nil
+			}
+			return bson.D{}, nil
+		}
+
+		operator := "$and"
+		if filter.op == filterOr {
+			operator = "$or"
+		}
+
+		children := make(bson.A, len(filter.children))
+		for i, child := range filter.children {
+			expression, err := buildMongoExpression(child)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = expression
+		}
+
+		return bson.D{{Key: operator, Value: children}}, nil
+	default:
+		return buildMongoFilter(filter.cond)
+	}
+}
+
+// orLikeKey chave do filtro de busca de um termo em vários campos
+const orLikeKey = "__or_like"
+
+// orLikeConditions converte o valor do __or_like ({term, fields}) nas condições do $or.
+// Termo vazio retorna nenhuma condição; um valor malformado retorna erro
+func orLikeConditions(value any) ([]bson.D, error) {
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("filtro %s inválido: esperado map com term e fields, recebido %T", orLikeKey, value)
+	}
+
+	term, ok := spec["term"].(string)
+	if !ok {
+		return nil, fmt.Errorf("filtro %s inválido: term deve ser texto, recebido %T", orLikeKey, spec["term"])
+	}
+	if term == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	switch v := spec["fields"].(type) {
+	case []string:
+		fields = v
+	case []any:
+		for _, field := range v {
+			name, ok := field.(string)
+			if !ok {
+				return nil, fmt.Errorf("filtro %s inválido: campo %v não é texto", orLikeKey, field)
+			}
+			fields = append(fields, name)
+		}
+	default:
+		return nil, fmt.Errorf("filtro %s inválido: fields deve ser uma lista de campos, recebido %T", orLikeKey, v)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("filtro %s inválido: nenhum campo informado", orLikeKey)
+	}
+
+	return mongoutils.CreateLikeFilters(regexp.QuoteMeta(term), fields), nil
+}
+
+// sortedDoc converte o documento para bson.D com as chaves em ordem alfabética
+func sortedDoc(m bson.M) bson.D {
+	doc := make(bson.D, 0, len(m))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		doc = append(doc, bson.E{Key: key, Value: m[key]})
+	}
+
+	return doc
+}
+
+// mongoDatePart retorna a expressão de agregação que extrai a parte da data do campo.
+// O dia da semana (dow) é normalizado para domingo = 0, como no SQL
+func mongoDatePart(field, part string) (any, bool) {
+	path := "$" + field
+	switch part {
+	case "year":
+		return bson.M{"$year": path}, true
+	case "month":
+		return bson.M{"$month": path}, true
+	case "day":
+		return bson.M{"$dayOfMonth": path}, true
+	case "dow":
+		return bson.M{"$subtract": bson.A{bson.M{"$dayOfWeek": path}, 1}}, true
+	default:
+		return nil, false
+	}
+}
+
+// mongoCondition traduz um operador do DSL para a condição equivalente do Mongo
+func mongoCondition(operator string, value any) (bson.M, bool) {
+	switch operator {
+	case "gt":
+		return bson.M{"$gt": value}, true
+	case "gte":
+		return bson.M{"$gte": value}, true
+	case "lt":
+		return bson.M{"$lt": value}, true
+	case "lte":
+		return bson.M{"$lte": value}, true
+	case "not":
+		return bson.M{"$ne": value}, true
+	case "json_eq":
+		// Documentos já são JSON: "meta.status__json_eq" é a igualdade no caminho com ponto
+		return bson.M{"$eq": value}, true
+	case "in":
+		return bson.M{"$in": value}, true
+	case "not_in":
+		return bson.M{"$nin": value}, true
+	case "like":
+		return bson.M{"$regex": likeToRegex(value)}, true
+	case "ilike":
+		return bson.M{"$regex": likeToRegex(value), "$options": "i"}, true
+	case "not_like":
+		return bson.M{"$not": bson.Regex{Pattern: likeToRegex(value)}}, true
+	case "is_null", "is_not_null":
+		// Como no SQL, __is_null: false e __is_not_null: true são o mesmo filtro. {$eq: null} casa
+		// com campos nulos ou ausentes e é o complemento exato de {$ne: null}
+		isNull := true
+		if b, ok := value.(bool); ok {
+			isNull = b
+		}
+		if operator == "is_not_null" {
+			isNull = !isNull
+		}
+		if isNull {
+			return bson.M{"$eq": nil}, true
+		}
+		return bson.M{"$ne": nil}, true
+	case "exists":
+		exists, _ := value.(bool)
+		return bson.M{"$exists": exists}, true
+	case "elem_match":
+		// Sub-filtros no DSL (map[string]any) são convertidos pelo buildMongoFilter
+		return bson.M{"$elemMatch": value}, true
+	default:
+		return nil, false
+	}
+}
+
+// likeToRegex converte um padrão LIKE do SQL (% e _) para uma expressão regular ancorada
+func likeToRegex(value any) string {
+	pattern := regexp.QuoteMeta(fmt.Sprint(value))
+	pattern = strings.ReplaceAll(pattern, "%", ".*")
+	pattern = strings.ReplaceAll(pattern, "_", ".")
+
+	return "^" + pattern + "$"
+}
@@ -0,0 +1,920 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
+)
+
+func TestBuildMongoFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filters  map[string]any
+		expected bson.D
+	}{
+		{
+			name:     "deve retornar filtro vazio para filtro nil",
+			filters:  nil,
+			expected: bson.D{},
+		},
+		{
+			name:     "deve manter igualdade simples",
+			filters:  map[string]any{"name": "João"},
+			expected: bson.D{{Key: "name", Value: "João"}},
+		},
+		{
+			name:     "deve manter nil como igualdade a null, como o IS NULL do SQL",
+			filters:  map[string]any{"name": nil},
+			expected: bson.D{{Key: "name", Value: nil}},
+		},
+		{
+			name:     "deve converter __json_eq para igualdade no caminho com ponto",
+			filters:  map[string]any{"meta.status__json_eq": "ativo"},
+			expected: bson.D{{Key: "meta.status", Value: bson.D{{Key: "$eq", Value: "ativo"}}}},
+		},
+		{
+			name:     "deve converter __not com nil para $ne null",
+			filters:  map[string]any{"name__not": nil},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		},
+		{
+			name:     "deve converter operador __gt",
+			filters:  map[string]any{"age__gt": 30},
+			expected: bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 30}}}},
+		},
+		{
+			name:     "deve converter operador __gte",
+			filters:  map[string]any{"age__gte": 30},
+			expected: bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 30}}}},
+		},
+		{
+			name:     "deve converter operador __lt",
+			filters:  map[string]any{"age__lt": 30},
+			expected: bson.D{{Key: "age", Value: bson.D{{Key: "$lt", Value: 30}}}},
+		},
+		{
+			name:     "deve converter operador __lte",
+			filters:  map[string]any{"age__lte": 30},
+			expected: bson.D{{Key: "age", Value: bson.D{{Key: "$lte", Value: 30}}}},
+		},
+		{
+			name:     "deve converter operador __not",
+			filters:  map[string]any{"name__not": "João"},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$ne", Value: "João"}}}},
+		},
+		{
+			name:     "deve converter operador __in",
+			filters:  map[string]any{"name__in": []string{"João", "Maria"}},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$in", Value: []string{"João", "Maria"}}}}},
+		},
+		{
+			name:     "deve converter operador __not_in",
+			filters:  map[string]any{"name__not_in": []string{"João", "Maria"}},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$nin", Value: []string{"João", "Maria"}}}}},
+		},
+		{
+			name:     "deve converter operador __like para regex",
+			filters:  map[string]any{"name__like": "%Jo_o%"},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$regex", Value: "^.*Jo.o.*$"}}}},
+		},
+		{
+			name:     "deve escapar caracteres especiais no __like",
+			filters:  map[string]any{"name__like": "a.b%"},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$regex", Value: `^a\.b.*$`}}}},
+		},
+		{
+			name:     "deve converter operador __ilike para regex case-insensitive",
+			filters:  map[string]any{"name__ilike": "%joão%"},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$options", Value: "i"}, {Key: "$regex", Value: "^.*joão.*$"}}}},
+		},
+		{
+			name:     "deve converter operador __not_like",
+			filters:  map[string]any{"name__not_like": "%João%"},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$not", Value: bson.Regex{Pattern: "^.*João.*$"}}}}},
+		},
+		{
+			name:     "deve converter operador __is_null",
+			filters:  map[string]any{"name__is_null": true},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$eq", Value: nil}}}},
+		},
+		{
+			name:     "deve converter operador __is_not_null",
+			filters:  map[string]any{"name__is_not_null": true},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		},
+		{
+			name:     "deve converter operador __is_null false",
+			filters:  map[string]any{"name__is_null": false},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		},
+		{
+			name:     "deve converter operador __is_not_null false",
+			filters:  map[string]any{"name__is_not_null": false},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$eq", Value: nil}}}},
+		},
+		{
+			name:    "deve converter __is_not_null true como __is_null false",
+			filters: map[string]any{"name__is_not_null": true, "email__is_null": false},
+			expected: bson.D{
+				{Key: "email", Value: bson.D{{Key: "$ne", Value: nil}}},
+				{Key: "name", Value: bson.D{{Key: "$ne", Value: nil}}},
+			},
+		},
+		{
+			name:    "deve converter __is_null true como __is_not_null false",
+			filters: map[string]any{"name__is_null": true, "email__is_not_null": false},
+			expected: bson.D{
+				{Key: "email", Value: bson.D{{Key: "$eq", Value: nil}}},
+				{Key: "name", Value: bson.D{{Key: "$eq", Value: nil}}},
+			},
+		},
+		{
+			name:     "deve converter operador __exists",
+			filters:  map[string]any{"name__exists": false},
+			expected: bson.D{{Key: "name", Value: bson.D{{Key: "$exists", Value: false}}}},
+		},
+		{
+			name:     "deve combinar operadores do mesmo campo",
+			filters:  map[string]any{"age__gte": 18, "age__lte": 65},
+			expected: bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}, {Key: "$lte", Value: 65}}}},
+		},
+		{
+			name:     "deve manter operador desconhecido sem alteração",
+			filters:  map[string]any{"meta__custom": 1},
+			expected: bson.D{{Key: "meta__custom", Value: 1}},
+		},
+		{
+			name: "deve ordenar chaves alfabeticamente",
+			filters: map[string]any{
+				"name":    "João",
+				"age__gt": 30,
+				"$or":     bson.A{bson.M{"active": true}},
+			},
+			expected: bson.D{
+				{Key: "$or", Value: bson.A{bson.M{"active": true}}},
+				{Key: "age", Value: bson.D{{Key: "$gt", Value: 30}}},
+				{Key: "name", Value: "João"},
+			},
+		},
+		{
+			name: "deve converter operador __elem_match com sub-filtro",
+			filters: map[string]any{"items__elem_match": map[string]any{
+				"sku":      "A1",
+				"qty__gte": 2,
+			}},
+			expected: bson.D{{Key: "items", Value: bson.D{{Key: "$elemMatch", Value: bson.D{
+				{Key: "qty", Value: bson.D{{Key: "$gte", Value: 2}}},
+				{Key: "sku", Value: "A1"},
+			}}}}},
+		},
+		{
+			name:     "deve repassar __elem_match nativo sem alteração",
+			filters:  map[string]any{"items__elem_match": bson.M{"sku": "A1"}},
+			expected: bson.D{{Key: "items", Value: bson.D{{Key: "$elemMatch", Value: bson.M{"sku": "A1"}}}}},
+		},
+
+		{
+			name: "deve expandir __or_like em $or de regex case-insensitive",
+			filters: map[string]any{
+				"active":    true,
+				"__or_like": map[string]any{"term": "jo.", "fields": []string{"name", "description"}},
+			},
+			expected: bson.D{
+				{Key: "$or", Value: []bson.D{
+					{{Key: "name", Value: bson.M{"$regex": `jo\.`, "$options": "i"}}},
+					{{Key: "description", Value: bson.M{"$regex": `jo\.`, "$options": "i"}}},
+				}},
+				{Key: "active", Value: true},
+			},
+		},
+		{
+			name:     "deve ignorar __or_like com termo vazio",
+			filters:  map[string]any{"__or_like": map[string]any{"term": "", "fields": []string{"name"}}},
+			expected: bson.D{},
+		},
+		{
+			name: "deve combinar __or_like com $or nativo usando $and",
+			filters: map[string]any{
+				"$or":       bson.A{bson.M{"active": true}},
+				"__or_like": map[string]any{"term": "jo", "fields": []any{"name"}},
+			},
+			expected: bson.D{
+				{Key: "$or", Value: bson.A{bson.M{"active": true}}},
+				{Key: "$and", Value: bson.A{bson.D{{Key: "$or", Value: []bson.D{
+					{{Key: "name", Value: bson.M{"$regex": "jo", "$options": "i"}}},
+				}}}}},
+			},
+		},
+
+		{
+			name:    "deve converter parte de data em $expr",
+			filters: map[string]any{"createdAt__month": 1},
+			expected: bson.D{
+				{Key: "$expr", Value: bson.M{"$eq": bson.A{bson.M{"$month": "$createdAt"}, 1}}},
+			},
+		},
+		{
+			name:    "deve combinar partes de data com $and",
+			filters: map[string]any{"createdAt__dow": 1, "createdAt__year": 2024, "active": true},
+			expected: bson.D{
+				{Key: "active", Value: true},
+				{Key: "$expr", Value: bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{bson.M{"$subtract": bson.A{bson.M{"$dayOfWeek": "$createdAt"}, 1}}, 1}},
+					bson.M{"$eq": bson.A{bson.M{"$year": "$createdAt"}, 2024}},
+				}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := buildMongoFilter(tt.filters)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBuildMongoFilter_InvalidOrLike(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "deve rejeitar valor que não é map", value: "jo"},
+		{name: "deve rejeitar termo que não é texto", value: map[string]any{"term": 10, "fields": []string{"name"}}},
+		{name: "deve rejeitar fields ausente", value: map[string]any{"term": "jo"}},
+		{name: "deve rejeitar fields vazio", value: map[string]any{"term": "jo", "fields": []string{}}},
+		{name: "deve rejeitar campo que não é texto", value: map[string]any{"term": "jo", "fields": []any{"name", 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildMongoFilter(map[string]any{"__or_like": tt.value})
+			assert.ErrorContains(t, err, "__or_like")
+		})
+	}
+
+	t.Run("deve propagar o erro de expressões e sub-filtros", func(t *testing.T) {
+		_, err := buildMongoFilter(Where(Not(Cond(map[string]any{"__or_like": "jo"}))))
+		assert.Error(t, err)
+
+		_, err = buildMongoFilter(map[string]any{"items__elem_match": map[string]any{"__or_like": "jo"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildMongoFilter_FilterExpression(t *testing.T) {
+	t.Run("deve converter a expressão aninhada em $and, $or e $nor", func(t *testing.T) {
+		expected := bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "active", Value: true}},
+			bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 30}}}},
+				bson.D{{Key: "$and", Value: bson.A{
+					bson.D{{Key: "name", Value: bson.D{{Key: "$regex", Value: "^A.*$"}}}},
+					bson.D{{Key: "score", Value: bson.D{{Key: "$gte", Value: 80}}}},
+				}}},
+			}}},
+			bson.D{{Key: "$nor", Value: bson.A{
+				bson.D{{Key: "score", Value: bson.D{{Key: "$lt", Value: 75}}}},
+			}}},
+		}}}
+
+		result, err := buildMongoFilter(Where(nestedTestFilter()))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("deve combinar a expressão com as demais chaves em um $and externo", func(t *testing.T) {
+		filter := Where(Not(Cond(map[string]any{"name": "Ana"})))
+		filter["name__ilike"] = "a%"
+
+		result, err := buildMongoFilter(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "name", Value: bson.D{{Key: "$options", Value: "i"}, {Key: "$regex", Value: "^a.*$"}}}},
+			bson.D{{Key: "$nor", Value: bson.A{bson.D{{Key: "name", Value: "Ana"}}}}},
+		}}}, result)
+	})
+
+	t.Run("deve tratar OR vazio como nenhum documento e AND vazio como todos", func(t *testing.T) {
+		result, err := buildMongoFilter(Where(Or()))
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{{Key: "$expr", Value: false}}, result)
+
+		result, err = buildMongoFilter(Where(And()))
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{}, result)
+	})
+}
+
+func TestBuildMongoFilter_StableOrder(t *testing.T) {
+	filters := map[string]any{
+		"score__lte": 100,
+		"name":       "João",
+		"age__lte":   65,
+		"active":     true,
+		"age__gte":   18,
+		"age__not":   30,
+		"tags__in":   []string{"go"},
+		"score__gt":  10,
+	}
+
+	expected := bson.D{
+		{Key: "active", Value: true},
+		{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}, {Key: "$lte", Value: 65}, {Key: "$ne", Value: 30}}},
+		{Key: "name", Value: "João"},
+		{Key: "score", Value: bson.D{{Key: "$gt", Value: 10}, {Key: "$lte", Value: 100}}},
+		{Key: "tags", Value: bson.D{{Key: "$in", Value: []string{"go"}}}},
+	}
+
+	for range 50 {
+		result, err := buildMongoFilter(filters)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	}
+}
+
+func TestConvertStoreUpsertFilterToBsonD(t *testing.T) {
+	s := &mongoStore[TestEntity]{}
+	value := reflect.ValueOf(TestEntity{ID: "id-1", Name: "joao@example.com", Age: 30})
+
+	filter, err := s.convertStoreUpsertFilterToBsonD(value, []StoreUpsertFilter{
+		{UpsertFieldKey: "Age", UpsertBsonKey: "age"},
+		{UpsertFieldKey: "ID", UpsertBsonKey: "_id", Or: true},
+		{UpsertFieldKey: "Name", UpsertBsonKey: "name", Or: true},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, bson.D{
+		{Key: "age", Value: 30},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "_id", Value: "id-1"}},
+			bson.D{{Key: "name", Value: "joao@example.com"}},
+		}},
+	}, filter)
+}
+
+func TestMongoSort(t *testing.T) {
+	assert.Equal(t,
+		bson.D{{Key: "age", Value: -1}, {Key: "_id", Value: -1}},
+		mongoSort(FindOptions{SortBy: "age", OrderBy: "DESC"}),
+	)
+	assert.Equal(t,
+		bson.D{{Key: "_id", Value: 1}},
+		mongoSort(FindOptions{SortBy: "_id", OrderBy: "ASC"}),
+	)
+}
+
+func TestNewMongoStore_NonStructEntity(t *testing.T) {
+	assert.PanicsWithValue(t,
+		"NewMongoStore: o tipo da entidade deve ser uma struct, recebido string",
+		func() {
+			NewMongoStore[string](nil)
+		},
+	)
+}
+
+type TestSortEntity struct {
+	ID       string `bson:"_id"`
+	Name     string `bson:"name"`
+	Nickname string
+	Secret   string `bson:"-"`
+	Audit    struct {
+		CreatedBy string `bson:"createdBy"`
+	} `bson:",inline"`
+}
+
+func TestMongoValidateSortField(t *testing.T) {
+	s := NewMongoStore[TestSortEntity](nil).(*mongoStore[TestSortEntity])
+
+	tests := []struct {
+		name    string
+		field   string
+		wantErr bool
+	}{
+		{name: "deve aceitar campo vazio", field: ""},
+		{name: "deve aceitar alias id", field: "id"},
+		{name: "deve aceitar _id", field: "_id"},
+		{name: "deve aceitar tag bson", field: "name"},
+		{name: "deve aceitar nome em minúsculas sem tag", field: "nickname"},
+		{name: "deve aceitar campo inline", field: "createdBy"},
+		{name: "deve validar o primeiro nível de caminho com ponto", field: "name.first"},
+		{name: "deve rejeitar campo ignorado", field: "Secret", wantErr: true},
+		{name: "deve rejeitar campo desconhecido", field: "idade", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.validateSortField(tt.field)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSortField)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMongoTimestampFields(t *testing.T) {
+	type snakeEntity struct {
+		ID        string    `bson:"_id"`
+		CreatedAt time.Time `bson:"created_at,omitempty"`
+		UpdatedAt time.Time `bson:"updated_at"`
+	}
+	type untaggedEntity struct {
+		ID        string `bson:"_id"`
+		CreatedAt time.Time
+		UpdatedAt time.Time `bson:"-"`
+	}
+
+	t.Run("deve resolver as chaves pelas tags bson", func(t *testing.T) {
+		assert.Equal(t, timestampFields{created: "created_at", updated: "updated_at"}, bsonTimestampFields(reflect.TypeFor[snakeEntity]()))
+		assert.Equal(t, timestampFields{created: "createdAt", updated: "updatedAt"}, bsonTimestampFields(reflect.TypeFor[TestEntity]()))
+	})
+
+	t.Run("deve usar o nome do driver sem tag e o padrão com campo ignorado ou ausente", func(t *testing.T) {
+		assert.Equal(t, timestampFields{created: "createdat", updated: "updatedAt"}, bsonTimestampFields(reflect.TypeFor[untaggedEntity]()))
+		assert.Equal(t, timestampFields{created: "createdAt", updated: "updatedAt"}, bsonTimestampFields(reflect.TypeFor[TestSortEntity]()))
+	})
+
+	t.Run("deve resolver a ordenação padrão para a chave do CreatedAt", func(t *testing.T) {
+		s := NewMongoStore[snakeEntity](nil).(*mongoStore[snakeEntity])
+
+		assert.Equal(t, "created_at", s.sortKey(defaultSortField))
+		assert.Equal(t, "_id", s.sortKey("id"))
+		assert.NoError(t, s.validateSortField(defaultSortField))
+	})
+
+	t.Run("deve manter createdAt quando a entidade tem esse campo", func(t *testing.T) {
+		s := NewMongoStore[TestEntity](nil).(*mongoStore[TestEntity])
+		assert.Equal(t, "createdAt", s.sortKey(defaultSortField))
+	})
+}
+
+func TestMongoInsertDocument_OmitZeroTimes(t *testing.T) {
+	type endereco struct {
+		Street     string    `bson:"street"`
+		VerifiedAt time.Time `bson:"verifiedAt"`
+	}
+	type cliente struct {
+		ID        string    `bson:"_id"`
+		Name      string    `bson:"name"`
+		DeletedAt time.Time `bson:"deletedAt"`
+		CreatedAt time.Time `bson:"createdAt"`
+		Address   endereco  `bson:"address"`
+	}
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	e := &cliente{ID: "c-1", Name: "Ana", CreatedAt: createdAt, Address: endereco{Street: "Rua A"}}
+
+	t.Run("deve remover os campos time.Time zerados, inclusive aninhados", func(t *testing.T) {
+		s := NewMongoStore[cliente](nil, WithOmitZeroTimes()).(*mongoStore[cliente])
+
+		doc, err := s.insertDocument(e)
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{
+			{Key: "_id", Value: "c-1"},
+			{Key: "name", Value: "Ana"},
+			{Key: "createdAt", Value: bson.NewDateTimeFromTime(createdAt)},
+			{Key: "address", Value: bson.D{{Key: "street", Value: "Rua A"}}},
+		}, doc)
+	})
+
+	t.Run("deve remover os campos time.Time zerados dos documentos em arrays", func(t *testing.T) {
+		type pedido struct {
+			ID    string     `bson:"_id"`
+			Items []endereco `bson:"items"`
+		}
+		s := NewMongoStore[pedido](nil, WithOmitZeroTimes()).(*mongoStore[pedido])
+
+		doc, err := s.insertDocument(&pedido{ID: "p-1", Items: []endereco{{Street: "Rua A"}, {Street: "Rua B", VerifiedAt: createdAt}}})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{
+			{Key: "_id", Value: "p-1"},
+			{Key: "items", Value: bson.A{
+				bson.D{{Key: "street", Value: "Rua A"}},
+				bson.D{{Key: "street", Value: "Rua B"}, {Key: "verifiedAt", Value: bson.NewDateTimeFromTime(createdAt)}},
+			}},
+		}, doc)
+	})
+
+	t.Run("deve codificar com as opções de WithBSONOptions", func(t *testing.T) {
+		type evento struct {
+			ID        string    `json:"id" bson:"_id"`
+			Kind      string    `json:"kind"`
+			CreatedAt time.Time `json:"createdAt"`
+		}
+
+		// O client não conecta até a primeira operação, então não é necessário um servidor
+		client, err := mongo.Connect(options.Client().ApplyURI("mongodb://localhost:27017"))
+		assert.NoError(t, err)
+		defer client.Disconnect(context.Background())
+
+		coll := client.Database("app").Collection("events")
+		s := NewMongoStore[evento](coll, WithOmitZeroTimes(),
+			WithBSONOptions(&options.BSONOptions{UseJSONStructTags: true}, nil)).(*mongoStore[evento])
+
+		doc, err := s.insertDocument(&evento{ID: "e-1", Kind: "login"})
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{{Key: "_id", Value: "e-1"}, {Key: "kind", Value: "login"}}, doc)
+	})
+
+	t.Run("deve inserir a própria entidade por padrão", func(t *testing.T) {
+		s := NewMongoStore[cliente](nil).(*mongoStore[cliente])
+
+		doc, err := s.insertDocument(e)
+		assert.NoError(t, err)
+		assert.Same(t, e, doc)
+	})
+}
+
+func TestMongoFindAll_MaxLimit(t *testing.T) {
+	// Sem coleção: a validação precisa falhar antes de qualquer consulta
+	store := NewMongoStore[TestSortEntity](nil, WithMaxLimit(50))
+	ctx := context.Background()
+
+	_, err := store.FindAll(ctx, nil, FindOptions{Limit: 51})
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+	_, err = store.SearchText(ctx, "termo", FindOptions{Limit: 51})
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+	_, err = store.FindLast(ctx, 51, nil, "_id")
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+	_, err = NewMongoStore[TestSortEntity](nil).FindAll(ctx, nil, FindOptions{Limit: DefaultMaxLimit + 1})
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+}
+
+func TestNewMongoCommandMonitor(t *testing.T) {
+	type loggerKey struct{}
+	var entries []string
+
+	monitor := NewMongoCommandMonitor(func(ctx context.Context) Logger {
+		logger, _ := ctx.Value(loggerKey{}).(Logger)
+		return logger
+	})
+	ctx := context.WithValue(context.Background(), loggerKey{}, recordingLogger{fields: []any{"request_id", "req-42"}, entries: &entries})
+	finished := event.CommandFinishedEvent{CommandName: "find", DatabaseName: "app", Duration: time.Millisecond}
+
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: finished})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: finished, Failure: errors.New("timeout")})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished})
+
+	assert.Len(t, entries, 2)
+	assert.True(t, strings.HasPrefix(entries[0], "DEBUG"), entries[0])
+	assert.True(t, strings.HasPrefix(entries[1], "ERROR"), entries[1])
+	for _, entry := range entries {
+		assert.Contains(t, entry, "find")
+		assert.Contains(t, entry, "req-42")
+		// O id do protocolo do driver não pode sobrescrever o request_id da requisição
+		assert.Contains(t, entry, "wire_request_id")
+	}
+	assert.Contains(t, entries[1], "timeout")
+}
+
+func TestUpsertBy(t *testing.T) {
+	type Endereco struct {
+		City string `bson:"cidade"`
+	}
+	type Cliente struct {
+		ID       string    `bson:"_id"`
+		Email    string    `bson:"email_address"`
+		Document string    // sem tag: nome em minúsculas
+		Address  Endereco  `bson:"address"`
+		Billing  *Endereco `bson:"billing"`
+		Endereco           // embutida sem inline: subdocumento
+		Secret   string    `bson:"-"`
+	}
+
+	t.Run("deve resolver a chave pela tag bson", func(t *testing.T) {
+		assert.Equal(t, []StoreUpsertFilter{
+			{UpsertFieldKey: "Email", UpsertBsonKey: "email_address"},
+			{UpsertFieldKey: "Document", UpsertBsonKey: "document"},
+			{UpsertFieldKey: "ID", UpsertBsonKey: "_id"},
+		}, MustUpsertBy[Cliente]("Email", "Document", "ID"))
+	})
+
+	t.Run("deve resolver campos aninhados, embutidos e inline", func(t *testing.T) {
+		assert.Equal(t, "address.cidade", MustUpsertBy[Cliente]("Address.City")[0].UpsertBsonKey)
+		assert.Equal(t, "endereco.cidade", MustUpsertBy[Cliente]("City")[0].UpsertBsonKey)
+		assert.Equal(t, "createdBy", MustUpsertBy[TestSortEntity]("Audit.CreatedBy")[0].UpsertBsonKey)
+	})
+
+	t.Run("deve usar a chave resolvida no filtro do upsert", func(t *testing.T) {
+		s := &mongoStore[Cliente]{}
+		e := Cliente{Email: "ana@exemplo.com", Address: Endereco{City: "Recife"}}
+
+		filter, err := s.convertStoreUpsertFilterToBsonD(reflect.ValueOf(e), MustUpsertBy[Cliente]("Email", "Address.City"))
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{
+			{Key: "email_address", Value: "ana@exemplo.com"},
+			{Key: "address.cidade", Value: "Recife"},
+		}, filter)
+	})
+
+	t.Run("deve resolver campos aninhados por ponteiro", func(t *testing.T) {
+		s := &mongoStore[Cliente]{}
+		filters := MustUpsertBy[Cliente]("Billing.City")
+		assert.Equal(t, "billing.cidade", filters[0].UpsertBsonKey)
+
+		filter, err := s.convertStoreUpsertFilterToBsonD(reflect.ValueOf(Cliente{Billing: &Endereco{City: "Natal"}}), filters)
+		assert.NoError(t, err)
+		assert.Equal(t, bson.D{{Key: "billing.cidade", Value: "Natal"}}, filter)
+
+		_, err = s.convertStoreUpsertFilterToBsonD(reflect.ValueOf(Cliente{}), filters)
+		assert.ErrorContains(t, err, "Billing.City")
+	})
+
+	t.Run("deve retornar erro com campo inexistente ou ignorado", func(t *testing.T) {
+		for _, field := range []string{"Telefone", "Secret", "Email.Domain"} {
+			filters, err := UpsertBy[Cliente](field)
+			assert.ErrorIs(t, err, ErrUnknownColumn, field)
+			assert.Nil(t, filters)
+		}
+	})
+
+	t.Run("deve entrar em pânico no MustUpsertBy com campo inexistente", func(t *testing.T) {
+		assert.Panics(t, func() { MustUpsertBy[Cliente]("Telefone") })
+	})
+}
+
+func TestMongoDecodeCursor_SkipDecodeErrors(t *testing.T) {
+	ctx := context.Background()
+	documents := func() *mongo.Cursor {
+		cursor, err := mongo.NewCursorFromDocuments([]any{
+			bson.D{{Key: "_id", Value: "a"}, {Key: "name", Value: "Ana"}, {Key: "age", Value: 30}},
+			bson.D{{Key: "_id", Value: "b"}, {Key: "name", Value: "Bruno"}, {Key: "age", Value: "trinta"}},
+			bson.D{{Key: "_id", Value: "c"}, {Key: "name", Value: "Carla"}, {Key: "age", Value: 25}},
+		}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cursor
+	}
+
+	t.Run("deve retornar os documentos válidos e o erro dos demais", func(t *testing.T) {
+		s := NewMongoStore[TestEntity](nil, WithSkipDecodeErrors()).(*mongoStore[TestEntity])
+
+		results, err := s.decodeCursor(ctx, documents())
+		assert.ErrorIs(t, err, ErrDecode)
+		assert.ErrorContains(t, err, "1 documentos ignorados")
+		assert.ErrorContains(t, err, `documento "b"`)
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, "Ana", results[0].Name)
+		assert.Equal(t, "Carla", results[1].Name)
+	})
+
+	t.Run("deve falhar a listagem inteira por padrão", func(t *testing.T) {
+		s := NewMongoStore[TestEntity](nil).(*mongoStore[TestEntity])
+
+		results, err := s.decodeCursor(ctx, documents())
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrDecode)
+		assert.Nil(t, results)
+	})
+}
+
+func TestMongoFindAll_UnknownProjectionField(t *testing.T) {
+	// Sem coleção: a validação precisa falhar antes de qualquer consulta
+	store := NewMongoStore[TestSortEntity](nil)
+	ctx := context.Background()
+
+	_, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"name", "email"}})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	_, err = store.FindAll(ctx, nil, FindOptions{ExcludeFields: []string{"senha"}})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	_, err = store.Search(ctx, "termo", []string{"apelido"}, FindOptions{})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+}
+
+func TestNewMongoStore_WriteConcernAndReadPreference(t *testing.T) {
+	// O client não conecta até a primeira operação, então não é necessário um servidor
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://localhost:27017"))
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	coll := client.Database("app").Collection("events")
+	wc := writeconcern.W1()
+	rp := readpref.SecondaryPreferred()
+
+	t.Run("deve aplicar as configurações na coleção", func(t *testing.T) {
+		s := NewMongoStore[TestEntity](coll, WithWriteConcern(wc), WithReadPreference(rp)).(*mongoStore[TestEntity])
+
+		assert.Len(t, s.collOpts, 1)
+		var applied options.CollectionOptions
+		for _, set := range s.collOpts[0].List() {
+			assert.NoError(t, set(&applied))
+		}
+		assert.Same(t, wc, applied.WriteConcern)
+		assert.Same(t, rp, applied.ReadPreference)
+		assert.Equal(t, "events", s.coll.Name())
+	})
+
+	t.Run("deve manter a coleção original sem configurações", func(t *testing.T) {
+		s := NewMongoStore[TestEntity](coll).(*mongoStore[TestEntity])
+
+		assert.Empty(t, s.collOpts)
+		assert.Same(t, coll, s.coll)
+	})
+}
+
+func TestMongoTenantCollection(t *testing.T) {
+	// O client não conecta até a primeira operação, então não é necessário um servidor
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://localhost:27017"))
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	coll := client.Database("app").Collection("events_" + TenantPlaceholder)
+	store := NewMongoStore[TestEntity](coll, WithTenantContextKey(testTenantKey{}))
+	ctx := context.Background()
+
+	t.Run("deve resolver a coleção do tenant", func(t *testing.T) {
+		acmeCtx := context.WithValue(ctx, testTenantKey{}, "acme")
+		resolved, err := store.(*mongoStore[TestEntity]).collection(acmeCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, "events_acme", resolved.Name())
+	})
+
+	t.Run("deve falhar sem tenant antes de chegar ao banco", func(t *testing.T) {
+		_, err := store.Save(ctx, &TestEntity{ID: "1", Name: "Sem Tenant"})
+		assert.ErrorIs(t, err, ErrMissingTenant)
+
+		_, err = store.Count(ctx, nil)
+		assert.ErrorIs(t, err, ErrMissingTenant)
+		assert.False(t, store.Has(ctx, "1"))
+	})
+
+	t.Run("deve falhar com tenant inválido", func(t *testing.T) {
+		invalidCtx := context.WithValue(ctx, testTenantKey{}, "acme.users")
+		_, err := store.Save(invalidCtx, &TestEntity{ID: "1", Name: "Inválido"})
+		assert.ErrorIs(t, err, ErrInvalidTenant)
+	})
+}
+
+type TestTenantUserEntity struct {
+	ID     bson.ObjectID `bson:"_id,omitempty"`
+	Tenant string        `bson:"tenant"`
+	Email  string        `bson:"email"`
+	Name   string        `bson:"name"`
+}
+
+func TestMongoUpsertUpdate(t *testing.T) {
+	t.Run("deve definir o _id na inserção quando o filtro usa o _id", func(t *testing.T) {
+		s := &mongoStore[TestEntity]{}
+		value := reflect.ValueOf(TestEntity{ID: "id-1", Name: "João"})
+
+		update, err := s.upsertUpdate(value, []StoreUpsertFilter{{UpsertFieldKey: "ID", UpsertBsonKey: "_id"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"_id": "id-1"}, update["$setOnInsert"])
+		assert.Equal(t, "João", update["$set"].(bson.M)["name"])
+	})
+
+	t.Run("deve gravar a chave composta apenas na inserção sem forçar o _id", func(t *testing.T) {
+		s := &mongoStore[TestTenantUserEntity]{}
+		value := reflect.ValueOf(TestTenantUserEntity{Tenant: "acme", Email: "joao@example.com", Name: "João"})
+
+		update, err := s.upsertUpdate(value, []StoreUpsertFilter{
+			{UpsertFieldKey: "Tenant", UpsertBsonKey: "tenant"},
+			{UpsertFieldKey: "Email", UpsertBsonKey: "email"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"tenant": "acme", "email": "joao@example.com"}, update["$setOnInsert"])
+		assert.Equal(t, bson.M{"name": "João"}, update["$set"])
+	})
+
+	t.Run("deve manter no $set os campos dos filtros Or", func(t *testing.T) {
+		s := &mongoStore[TestTenantUserEntity]{}
+		value := reflect.ValueOf(TestTenantUserEntity{Tenant: "acme", Email: "joao@example.com", Name: "João"})
+
+		update, err := s.upsertUpdate(value, []StoreUpsertFilter{
+			{UpsertFieldKey: "Tenant", UpsertBsonKey: "tenant"},
+			{UpsertFieldKey: "Email", UpsertBsonKey: "email", Or: true},
+			{UpsertFieldKey: "Name", UpsertBsonKey: "name", Or: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, bson.M{"tenant": "acme"}, update["$setOnInsert"])
+		assert.Equal(t, bson.M{"email": "joao@example.com", "name": "João"}, update["$set"])
+	})
+}
+
+func TestMongoDebugFields(t *testing.T) {
+	type Audit struct {
+		By string `bson:"by"`
+	}
+	type entity struct {
+		ID      bson.ObjectID `bson:"_id,omitempty"`
+		Name    string
+		Email   string `bson:"email"`
+		Ignored string `bson:"-"`
+		Audit   Audit  `bson:",inline"`
+	}
+
+	s := &mongoStore[entity]{}
+	assert.Equal(t, []FieldInfo{
+		{Field: "ID", Column: "_id", Flags: []string{"omitempty"}, PrimaryKey: true},
+		{Field: "Name", Column: "name"},
+		{Field: "Email", Column: "email"},
+		{Field: "Audit.By", Column: "by"},
+	}, s.DebugFields())
+}
+
+func TestWriteInBatches(t *testing.T) {
+	operations := []mongo.WriteModel{
+		mongo.NewUpdateOneModel(),
+		mongo.NewUpdateOneModel(),
+		mongo.NewUpdateOneModel(),
+	}
+
+	failSecondBatch := func() func([]mongo.WriteModel) (*mongo.BulkWriteResult, error) {
+		batches := 0
+		return func(batch []mongo.WriteModel) (*mongo.BulkWriteResult, error) {
+			batches++
+			if batches == 2 {
+				return &mongo.BulkWriteResult{}, mongo.BulkWriteException{
+					WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: 0, Code: 11000, Message: "duplicate key"}}},
+				}
+			}
+
+			return &mongo.BulkWriteResult{UpsertedCount: 2, UpsertedIDs: map[int64]any{0: "a", 1: "b"}}, nil
+		}
+	}
+
+	t.Run("deve retornar o resultado dos lotes anteriores quando um lote ordenado falha", func(t *testing.T) {
+		result, err := writeInBatches(operations, 2, true, failSecondBatch())
+
+		assert.Error(t, err)
+		if assert.NotNil(t, result) {
+			assert.Equal(t, int64(2), result.UpsertedCount)
+			assert.Equal(t, map[int64]any{0: "a", 1: "b"}, result.UpsertedIDs)
+		}
+	})
+
+	t.Run("deve reunir os erros por operação no modo desordenado", func(t *testing.T) {
+		result, err := writeInBatches(operations, 2, false, failSecondBatch())
+
+		assert.Error(t, err)
+		if assert.NotNil(t, result) {
+			assert.Equal(t, int64(2), result.UpsertedCount)
+			assert.Contains(t, result.Errors, int64(2))
+		}
+	})
+}
+
+func TestNewMongoStore_ReadTransformType(t *testing.T) {
+	assert.NotPanics(t, func() {
+		NewMongoStore[TestEntity](nil, WithReadTransform(func(*TestEntity) {}))
+	})
+	assert.Panics(t, func() {
+		NewMongoStore[TestEntity](nil, WithReadTransform(func(*TestSQLEntity) {}))
+	})
+}
+
+func TestMongoCount_WithCountCacheInSession(t *testing.T) {
+	// O client não conecta até a primeira operação; o timeout curto faz o CountDocuments falhar
+	// rápido sem servidor, mostrando quando o total não veio do cache
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://localhost:27017/?serverSelectionTimeoutMS=50"))
+	assert.NoError(t, err)
+	defer client.Disconnect(context.Background())
+
+	coll := client.Database("app").Collection("events")
+	s := NewMongoStore[TestEntity](coll, WithCountCache(time.Minute)).(*mongoStore[TestEntity])
+	filter := map[string]any{"active": true}
+	s.counts.set(countCacheKey(coll.Name(), filter), 7)
+
+	t.Run("deve usar o total memorizado fora de uma sessão", func(t *testing.T) {
+		total, err := s.Count(context.Background(), filter)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), *total)
+	})
+
+	t.Run("não deve usar nem gravar o cache dentro de uma sessão", func(t *testing.T) {
+		session, err := client.StartSession()
+		assert.NoError(t, err)
+		defer session.EndSession(context.Background())
+
+		_, err = s.Count(mongo.NewSessionContext(context.Background(), session), filter)
+		assert.Error(t, err)
+
+		total, ok := s.counts.get(countCacheKey(coll.Name(), filter))
+		assert.True(t, ok)
+		assert.Equal(t, int64(7), total)
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/luma-sys/go-db-store/enum"
+	mongoutils "github.com/luma-sys/go-db-store/mongo"
 	"github.com/stretchr/testify/assert"
 	"github.com/tryvium-travels/memongo"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -192,6 +194,20 @@ func TestMongoSave(t *testing.T) {
 				assert.True(t, time.Since(result.UpdatedAt) < time.Minute)
 			},
 		},
+		{
+			name: "deve preservar CreatedAt informado e atualizar UpdatedAt",
+			input: &TestEntity{
+				ID:        "historico",
+				Name:      "Importado",
+				CreatedAt: time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC),
+			},
+			check: func(t *testing.T, result *TestEntity) {
+				found, err := store.FindById(ctx, "historico")
+				assert.NoError(t, err)
+				assert.True(t, found.CreatedAt.Equal(time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)))
+				assert.True(t, time.Since(found.UpdatedAt) < time.Minute)
+			},
+		},
 		{
 			name: "deve salvar documento com campos vazios",
 			input: &TestEntity{
@@ -255,6 +271,77 @@ func TestMongoSave(t *testing.T) {
 	}
 }
 
+func TestMongoSave_ReloadAfterSave(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection, WithReloadAfterSave())
+	ctx := context.Background()
+
+	result, err := store.Save(ctx, &TestEntity{ID: "1", Name: "Com Reload", Tags: []string{"a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result.ID)
+	assert.Equal(t, "Com Reload", result.Name)
+	assert.Equal(t, []string{"a"}, result.Tags)
+	assert.False(t, result.CreatedAt.IsZero())
+}
+
+type testTenantKey struct{}
+
+func TestMongoSave_TenantRouting(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	db := collection.Database()
+	store := NewMongoStore[TestEntity](db.Collection("events_"+TenantPlaceholder), WithTenantContextKey(testTenantKey{}))
+	acmeCtx := context.WithValue(context.Background(), testTenantKey{}, "acme")
+	globexCtx := context.WithValue(context.Background(), testTenantKey{}, "globex")
+	defer db.Collection("events_acme").Drop(context.Background())
+	defer db.Collection("events_globex").Drop(context.Background())
+
+	_, err := store.Save(acmeCtx, &TestEntity{ID: "1", Name: "Acme"})
+	assert.NoError(t, err)
+	_, err = store.Save(globexCtx, &TestEntity{ID: "1", Name: "Globex"})
+	assert.NoError(t, err)
+
+	acmeCount, err := db.Collection("events_acme").CountDocuments(context.Background(), bson.M{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), acmeCount)
+
+	found, err := store.FindById(globexCtx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Globex", found.Name)
+}
+
+func TestMongoSave_TenantRoutingWithoutTenant(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	db := collection.Database()
+	store := NewMongoStore[TestEntity](db.Collection("events_"+TenantPlaceholder), WithTenantContextKey(testTenantKey{}))
+	ctx := context.Background()
+
+	t.Run("deve falhar sem tenant no contexto", func(t *testing.T) {
+		_, err := store.Save(ctx, &TestEntity{ID: "1", Name: "Sem Tenant"})
+		assert.ErrorIs(t, err, ErrMissingTenant)
+
+		_, err = store.FindAll(ctx, nil, FindOptions{})
+		assert.ErrorIs(t, err, ErrMissingTenant)
+	})
+
+	t.Run("deve falhar com tenant inválido", func(t *testing.T) {
+		invalidCtx := context.WithValue(ctx, testTenantKey{}, "acme.users")
+		_, err := store.Save(invalidCtx, &TestEntity{ID: "1", Name: "Inválido"})
+		assert.ErrorIs(t, err, ErrInvalidTenant)
+	})
+
+	t.Run("não deve criar a coleção com o placeholder", func(t *testing.T) {
+		names, err := db.ListCollectionNames(ctx, bson.M{"name": "events_" + TenantPlaceholder})
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+	})
+}
+
 func TestMongoSave_DuplicateID(t *testing.T) {
 	collection, cleanup := setupMongoTest(t)
 	defer cleanup()
@@ -307,6 +394,20 @@ func TestMongoSaveMany(t *testing.T) {
 				assert.Equal(t, 2, len(result.InsertedIDs))
 			},
 		},
+		{
+			name: "deve preservar CreatedAt informado",
+			input: []TestEntity{
+				{ID: "20", Name: "Importado", CreatedAt: time.Date(2020, 3, 15, 10, 0, 0, 0, time.UTC)},
+				{ID: "21", Name: "Novo"},
+			},
+			check: func(t *testing.T, result *InsertManyResult) {
+				imported, _ := store.FindById(ctx, "20")
+				assert.Equal(t, 2020, imported.CreatedAt.Year())
+
+				created, _ := store.FindById(ctx, "21")
+				assert.True(t, time.Since(created.CreatedAt) < time.Minute)
+			},
+		},
 		{
 			name:  "deve retornar nil para slice vazio",
 			input: []TestEntity{},
@@ -365,6 +466,77 @@ func TestMongoSaveMany_PartialFailure(t *testing.T) {
 	assert.GreaterOrEqual(t, len(result.InsertedIDs), 2)
 }
 
+type TestAutoIDEntity struct {
+	ID   bson.ObjectID `bson:"_id,omitempty"`
+	Name string        `bson:"name"`
+}
+
+func TestMongoSave_GeneratedID(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestAutoIDEntity](collection)
+	ctx := context.Background()
+
+	saved, err := store.Save(ctx, &TestAutoIDEntity{Name: "Gerado"})
+	assert.NoError(t, err)
+	assert.False(t, saved.ID.IsZero())
+
+	found, err := store.FindById(ctx, saved.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Gerado", found.Name)
+}
+
+func TestMongoSaveIgnoreDuplicates(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	_, err := store.Save(ctx, &TestEntity{ID: "existente", Name: "Original"})
+	assert.NoError(t, err)
+
+	result, err := store.SaveIgnoreDuplicates(ctx, []TestEntity{
+		{ID: "novo-1", Name: "Novo 1"},
+		{ID: "existente", Name: "Duplicado"},
+		{ID: "novo-2", Name: "Novo 2"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"novo-1", "novo-2"}, result.InsertedIDs)
+
+	found, err := store.FindById(ctx, "existente")
+	assert.NoError(t, err)
+	assert.Equal(t, "Original", found.Name)
+
+	count, _ := store.Count(ctx, map[string]any{})
+	assert.Equal(t, int64(3), *count)
+}
+
+func TestMongoSaveManyDetailed(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestAutoIDEntity](collection)
+	ctx := context.Background()
+
+	input := []TestAutoIDEntity{{Name: "Primeiro"}, {Name: "Segundo"}, {Name: "Terceiro"}}
+
+	results, err := store.SaveManyDetailed(ctx, input)
+	assert.NoError(t, err)
+	assert.Len(t, results, len(input))
+
+	for i, result := range results {
+		id, ok := result.InsertedID.(bson.ObjectID)
+		assert.True(t, ok)
+
+		found, err := store.FindById(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, input[i].Name, found.Name)
+	}
+}
+
 // ==================== TESTES SAVE MANY NOT ORDERED ====================
 
 func TestMongoSaveManyNotOrdered(t *testing.T) {
@@ -459,6 +631,279 @@ func TestMongoFindById(t *testing.T) {
 	}
 }
 
+func TestMongoFindById_StringConverter(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestConverterEntity](collection)
+	ctx := context.Background()
+
+	_, err := collection.InsertOne(ctx, bson.M{"_id": "enum-1", "driver": " POSTGRES ", "replica": "MariaDB"})
+	assert.NoError(t, err)
+
+	found, err := store.FindById(ctx, "enum-1")
+	assert.NoError(t, err)
+	assert.Equal(t, enum.DatabaseDriverPostgres, found.Driver)
+	assert.Equal(t, enum.DatabaseDriverMariaDB, *found.Replica)
+
+	results, err := store.FindAll(ctx, nil, FindOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, enum.DatabaseDriverPostgres, results[0].Driver)
+}
+
+type TestOrderItem struct {
+	Sku string `bson:"sku"`
+	Qty int    `bson:"qty"`
+}
+
+type TestOrderWithItems struct {
+	ID    string          `bson:"_id"`
+	Items []TestOrderItem `bson:"items"`
+}
+
+func TestMongoFindAll_ElemMatch(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestOrderWithItems](collection)
+	ctx := context.Background()
+
+	// O pedido-2 tem sku A1 e qty 5, mas em elementos diferentes
+	_, err := store.SaveMany(ctx, []TestOrderWithItems{
+		{ID: "pedido-1", Items: []TestOrderItem{{Sku: "A1", Qty: 5}, {Sku: "B2", Qty: 1}}},
+		{ID: "pedido-2", Items: []TestOrderItem{{Sku: "A1", Qty: 1}, {Sku: "B2", Qty: 5}}},
+	})
+	assert.NoError(t, err)
+
+	results, err := store.FindAll(ctx, map[string]any{
+		"items__elem_match": map[string]any{"sku": "A1", "qty__gte": 2},
+	}, FindOptions{SortBy: "_id"})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "pedido-1", results[0].ID)
+}
+
+type TestNicknameEntity struct {
+	ID       string  `bson:"_id"`
+	Nickname *string `bson:"nickname,omitempty"`
+}
+
+func TestMongoFindAll_IsNull(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestNicknameEntity](collection)
+	ctx := context.Background()
+
+	// Campo nulo, campo ausente e campo preenchido
+	_, err := collection.InsertMany(ctx, []any{
+		bson.D{{Key: "_id", Value: "nulo"}, {Key: "nickname", Value: nil}},
+		bson.D{{Key: "_id", Value: "ausente"}},
+		bson.D{{Key: "_id", Value: "preenchido"}, {Key: "nickname", Value: "Zé"}},
+	})
+	assert.NoError(t, err)
+
+	ids := func(results []TestNicknameEntity) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	t.Run("deve casar campos nulos e ausentes com __is_null", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"nickname__is_null": true}, FindOptions{SortBy: "_id"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ausente", "nulo"}, ids(results))
+	})
+
+	t.Run("deve casar o complemento com __is_null false", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"nickname__is_null": false}, FindOptions{SortBy: "_id"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"preenchido"}, ids(results))
+	})
+}
+
+type TestProductEntity struct {
+	ID          string `bson:"_id"`
+	Name        string `bson:"name"`
+	Description string `bson:"description"`
+}
+
+func TestMongoFindAll_OrLike(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestProductEntity](collection)
+	ctx := context.Background()
+
+	_, err := store.SaveMany(ctx, []TestProductEntity{
+		{ID: "p1", Name: "Cadeira Gamer", Description: "Encosto reclinável"},
+		{ID: "p2", Name: "Mesa", Description: "Ideal para setup GAMER"},
+		{ID: "p3", Name: "Monitor", Description: "144hz"},
+	})
+	assert.NoError(t, err)
+
+	results, err := store.FindAll(ctx, map[string]any{
+		"__or_like": map[string]any{"term": "gamer", "fields": []string{"name", "description"}},
+	}, FindOptions{SortBy: "_id"})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "p1", results[0].ID)
+	assert.Equal(t, "p2", results[1].ID)
+}
+
+func TestMongoFindByIdInto(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "João", Age: 30, Tags: []string{"go"}})
+	store.Save(ctx, &TestEntity{ID: "2", Name: "Maria", Age: 25})
+
+	var dst TestEntity
+
+	err := store.FindByIdInto(ctx, "1", &dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "João", dst.Name)
+
+	err = store.FindByIdInto(ctx, "2", &dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria", dst.Name)
+	assert.Equal(t, 25, dst.Age)
+	assert.Empty(t, dst.Tags)
+
+	err = store.FindByIdInto(ctx, "nao-existe", &dst)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "Maria", dst.Name)
+
+	_, err = store.FindById(ctx, "nao-existe")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMongoWithReadTransform(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection, WithReadTransform(func(e *TestEntity) {
+		e.Name = strings.ToUpper(e.Name)
+	}))
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "joão", Age: 30})
+	store.Save(ctx, &TestEntity{ID: "2", Name: "maria", Age: 25})
+
+	found, err := store.FindById(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "JOÃO", found.Name)
+
+	found, err = store.FindOne(ctx, map[string]any{"age": 25})
+	assert.NoError(t, err)
+	assert.Equal(t, "MARIA", found.Name)
+
+	all, err := store.FindAll(ctx, nil, FindOptions{SortBy: "age", OrderBy: "asc"})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "MARIA", all[0].Name)
+	assert.Equal(t, "JOÃO", all[1].Name)
+}
+
+func TestMongoFindAll_FilterExpression(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i, e := range nestedTestEntities {
+		_, err := store.Save(ctx, &TestEntity{ID: fmt.Sprint(i + 1), Name: e.Name, Age: e.Age, Active: e.Active, Score: e.Score})
+		assert.NoError(t, err)
+	}
+
+	results, err := store.FindAll(ctx, Where(nestedTestFilter()), FindOptions{SortBy: "name", OrderBy: "ASC"})
+	assert.NoError(t, err)
+
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name
+	}
+	// Mesmo resultado do TestSQLFindAll_FilterExpression
+	assert.Equal(t, []string{"Ana", "Maria"}, names)
+}
+
+func TestMongoSave_WithOmitZeroTimes(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	type agendamento struct {
+		ID          string    `bson:"_id"`
+		Title       string    `bson:"title"`
+		ConfirmedAt time.Time `bson:"confirmedAt"`
+		CreatedAt   time.Time `bson:"createdAt"`
+		UpdatedAt   time.Time `bson:"updatedAt"`
+	}
+
+	store := NewMongoStore[agendamento](collection, WithOmitZeroTimes())
+	ctx := context.Background()
+
+	_, err := store.Save(ctx, &agendamento{ID: "ag-1", Title: "Pendente"})
+	assert.NoError(t, err)
+
+	confirmedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	_, err = store.SaveMany(ctx, []agendamento{{ID: "ag-2", Title: "Confirmado", ConfirmedAt: confirmedAt}, {ID: "ag-3", Title: "Pendente"}})
+	assert.NoError(t, err)
+
+	missing, err := store.FindAll(ctx, map[string]any{"confirmedAt__exists": false}, FindOptions{SortBy: "_id"})
+	assert.NoError(t, err)
+	assert.Len(t, missing, 2)
+	assert.Equal(t, "ag-1", missing[0].ID)
+	assert.True(t, missing[0].ConfirmedAt.IsZero())
+	assert.False(t, missing[0].CreatedAt.IsZero())
+
+	found, err := store.FindById(ctx, "ag-2")
+	assert.NoError(t, err)
+	assert.True(t, found.ConfirmedAt.Equal(confirmedAt))
+
+	t.Run("deve gravar a data zero por padrão", func(t *testing.T) {
+		plain := NewMongoStore[agendamento](collection)
+
+		_, err := plain.Save(ctx, &agendamento{ID: "ag-4", Title: "Pendente"})
+		assert.NoError(t, err)
+
+		count, err := plain.Count(ctx, map[string]any{"confirmedAt__exists": false})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *count)
+	})
+}
+
+func TestMongoFindAll_WithSkipDecodeErrors(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection, WithSkipDecodeErrors())
+	ctx := context.Background()
+
+	_, err := collection.InsertMany(ctx, []any{
+		bson.D{{Key: "_id", Value: "a"}, {Key: "name", Value: "Ana"}, {Key: "age", Value: 30}},
+		bson.D{{Key: "_id", Value: "b"}, {Key: "name", Value: "Bruno"}, {Key: "age", Value: "trinta"}},
+		bson.D{{Key: "_id", Value: "c"}, {Key: "name", Value: "Carla"}, {Key: "age", Value: 25}},
+	})
+	assert.NoError(t, err)
+
+	results, err := store.FindAll(ctx, nil, FindOptions{SortBy: "_id"})
+	assert.ErrorIs(t, err, ErrDecode)
+	assert.ErrorContains(t, err, `documento "b"`)
+	assert.Len(t, results, 2)
+
+	_, err = NewMongoStore[TestEntity](collection).FindAll(ctx, nil, FindOptions{})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDecode)
+}
+
 // ==================== TESTES FIND ONE ====================
 
 func TestMongoFindOne(t *testing.T) {
@@ -552,6 +997,21 @@ func TestMongoFindOne(t *testing.T) {
 				assert.Contains(t, result.Name, "João")
 			},
 		},
+		{
+			name:   "deve encontrar documento com operador __gt",
+			filter: map[string]interface{}{"age__gt": 30},
+			check: func(t *testing.T, result *TestEntity) {
+				assert.Equal(t, "3", result.ID)
+				assert.Greater(t, result.Age, 30)
+			},
+		},
+		{
+			name:   "deve encontrar documento com expressão Where",
+			filter: Where(And(Cond(map[string]any{"active": true}), Cond(map[string]any{"score__gte": 85}))),
+			check: func(t *testing.T, result *TestEntity) {
+				assert.Equal(t, "2", result.ID)
+			},
+		},
 		{
 			name:    "deve retornar erro quando não encontra documento",
 			filter:  map[string]interface{}{"name": "Não Existe"},
@@ -600,6 +1060,45 @@ func TestMongoFindOne(t *testing.T) {
 		})
 	}
 }
+func TestMongoFindOne_Sorted(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	testDocs := []TestEntity{
+		{ID: "1", Name: "João Silva", Age: 25, Active: true, Score: 80},
+		{ID: "2", Name: "Maria Santos", Age: 30, Active: true, Score: 90},
+		{ID: "3", Name: "Pedro Costa", Age: 35, Active: false, Score: 70},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	t.Run("deve retornar o registro de maior score", func(t *testing.T) {
+		result, err := store.FindOne(ctx, map[string]any{}, FindOptions{SortBy: "score", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Maria Santos", result.Name)
+	})
+
+	t.Run("deve retornar o registro de menor idade", func(t *testing.T) {
+		result, err := store.FindOne(ctx, map[string]any{}, FindOptions{SortBy: "age", OrderBy: "ASC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "João Silva", result.Name)
+	})
+
+	t.Run("deve combinar filtro e ordenação", func(t *testing.T) {
+		result, err := store.FindOne(ctx, map[string]any{"active": true}, FindOptions{SortBy: "age", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Maria Santos", result.Name)
+	})
+
+	t.Run("deve rejeitar campo de ordenação não mapeado", func(t *testing.T) {
+		_, err := store.FindOne(ctx, map[string]any{}, FindOptions{SortBy: "inexistente"})
+		assert.ErrorIs(t, err, ErrInvalidSortField)
+	})
+}
 
 // ==================== TESTES FIND ALL ====================
 
@@ -809,6 +1308,297 @@ func TestMongoFindAll(t *testing.T) {
 	}
 }
 
+func TestMongoFindAll_IncludeTotalCount(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i := range 7 {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("%d", i), Name: fmt.Sprintf("Doc %d", i), Active: i%2 == 0})
+	}
+
+	t.Run("deve respeitar o limite e retornar o total do filtro", func(t *testing.T) {
+		var total int64
+		results, err := store.FindAll(ctx, map[string]any{"active": true}, FindOptions{
+			Page:              1,
+			Limit:             2,
+			IncludeTotalCount: true,
+			TotalCount:        &total,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, int64(4), total)
+	})
+
+	t.Run("deve retornar total zero sem resultados", func(t *testing.T) {
+		var total int64
+		results, err := store.FindAll(ctx, map[string]any{"name": "Inexistente"}, FindOptions{
+			IncludeTotalCount: true,
+			TotalCount:        &total,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("deve retornar erro sem TotalCount", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{IncludeTotalCount: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestMongoFindLast(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i := 1; i <= 6; i++ {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("%d", i), Name: fmt.Sprintf("Documento %d", i), Age: i * 10})
+	}
+
+	t.Run("deve retornar os últimos documentos em ordem crescente", func(t *testing.T) {
+		results, err := store.FindLast(ctx, 3, nil, "age")
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, []int{40, 50, 60}, []int{results[0].Age, results[1].Age, results[2].Age})
+	})
+
+	t.Run("deve retornar erro para quantidade inválida", func(t *testing.T) {
+		_, err := store.FindLast(ctx, 0, nil, "age")
+		assert.Error(t, err)
+	})
+}
+
+func TestMongoFindAll_Projection(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "Projeção", Age: 30, Tags: []string{"grande"}})
+
+	t.Run("deve retornar apenas os campos informados em Fields", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"name"}})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Projeção", results[0].Name)
+		assert.Zero(t, results[0].Age)
+	})
+
+	t.Run("deve omitir os campos informados em ExcludeFields", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{ExcludeFields: []string{"tags"}})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, 30, results[0].Age)
+		assert.Empty(t, results[0].Tags)
+	})
+
+	t.Run("deve retornar erro ao informar Fields e ExcludeFields juntos", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"name"}, ExcludeFields: []string{"tags"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestMongoFindAll_StableSortWithTies(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i := range 9 {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("tie-%d", i), Name: "Empate", Age: 20 + i%3})
+	}
+
+	seen := make(map[string]bool)
+	for p := int64(1); p <= 3; p++ {
+		results, err := store.FindAll(ctx, nil, FindOptions{Page: p, Limit: 3, SortBy: "age", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+
+		for _, r := range results {
+			assert.False(t, seen[r.ID], "documento %s repetido entre páginas", r.ID)
+			seen[r.ID] = true
+		}
+	}
+	assert.Len(t, seen, 9)
+}
+
+func TestMongoFindPage(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i := range 25 {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("page-%02d", i), Name: "Página", Age: 20 + i})
+	}
+
+	result, err := store.FindPage(ctx, nil, 2, 10)
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 10)
+	assert.Equal(t, 2, result.Meta.CurrentPage)
+	assert.Equal(t, 3, result.Meta.TotalPages)
+	assert.Equal(t, 10, result.Meta.ItemCount)
+	assert.Equal(t, 25, result.Meta.TotalItems)
+}
+
+func TestMongoFindAllWithCount(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i := range 23 {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("count-%02d", i), Name: "Total", Age: i})
+	}
+
+	results, total, err := store.FindAllWithCount(ctx, map[string]any{"age__gte": 3}, FindOptions{Page: 1, Limit: 10, SortBy: "age"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 10)
+	assert.Equal(t, int64(20), total)
+}
+
+// ==================== TESTES FIND ALL JOINED ====================
+
+type TestOrderEntity struct {
+	ID         string       `bson:"_id"`
+	CustomerID string       `bson:"customerId"`
+	Total      float64      `bson:"total"`
+	Customer   []TestEntity `bson:"customer"`
+	CreatedAt  time.Time    `bson:"createdAt"`
+	UpdatedAt  time.Time    `bson:"updatedAt"`
+}
+
+func TestMongoFindAllJoined(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	customers := collection.Database().Collection("customers")
+	defer customers.Drop(ctx)
+
+	customerStore := NewMongoStore[TestEntity](customers)
+	customerStore.Save(ctx, &TestEntity{ID: "c1", Name: "João"})
+	customerStore.Save(ctx, &TestEntity{ID: "c2", Name: "Maria"})
+
+	store := NewMongoStore[TestOrderEntity](collection)
+	store.Save(ctx, &TestOrderEntity{ID: "o1", CustomerID: "c1", Total: 100})
+	store.Save(ctx, &TestOrderEntity{ID: "o2", CustomerID: "c2", Total: 200})
+	store.Save(ctx, &TestOrderEntity{ID: "o3", CustomerID: "c1", Total: 300})
+
+	lookups := []LookupSpec{
+		{From: "customers", LocalField: "customerId", ForeignField: "_id", As: "customer"},
+	}
+
+	t.Run("deve preencher o campo com o documento relacionado", func(t *testing.T) {
+		results, err := store.FindAllJoined(ctx, lookups, map[string]any{"_id": "o2"}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Len(t, results[0].Customer, 1)
+		assert.Equal(t, "Maria", results[0].Customer[0].Name)
+	})
+
+	t.Run("deve filtrar por campo do documento relacionado", func(t *testing.T) {
+		results, err := store.FindAllJoined(ctx, lookups, map[string]any{"customer.name": "João"}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("deve paginar e ordenar os resultados", func(t *testing.T) {
+		results, err := store.FindAllJoined(ctx, lookups, nil, FindOptions{Page: 1, Limit: 2, SortBy: "total", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "o3", results[0].ID)
+		assert.Equal(t, "o2", results[1].ID)
+	})
+}
+
+// ==================== TESTES SEARCH TEXT ====================
+
+func TestMongoSearch(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "João Silva", Tags: []string{"cliente"}})
+	store.Save(ctx, &TestEntity{ID: "2", Name: "Maria Souza", Tags: []string{"silva"}})
+	store.Save(ctx, &TestEntity{ID: "3", Name: "Pedro Costa", Tags: []string{"fornecedor"}})
+
+	t.Run("deve buscar em múltiplos campos sem diferenciar maiúsculas e minúsculas", func(t *testing.T) {
+		results, err := store.Search(ctx, "SILVA", []string{"name", "tags"}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("deve tratar caracteres especiais do termo literalmente", func(t *testing.T) {
+		results, err := store.Search(ctx, ".*", []string{"name"}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("deve retornar erro sem campos", func(t *testing.T) {
+		_, err := store.Search(ctx, "silva", nil, FindOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestMongoSearchText(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "name", Value: "text"}},
+	})
+	assert.NoError(t, err)
+
+	testDocs := []TestEntity{
+		{ID: "1", Name: "Café expresso"},
+		{ID: "2", Name: "Café com leite"},
+		{ID: "3", Name: "Chá verde"},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	t.Run("deve retornar documentos que contém o termo", func(t *testing.T) {
+		results, err := store.SearchText(ctx, "leite", FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "2", results[0].ID)
+	})
+
+	t.Run("deve ordenar por relevância", func(t *testing.T) {
+		results, err := store.SearchText(ctx, "café expresso", FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "1", results[0].ID)
+	})
+
+	t.Run("deve respeitar o limite", func(t *testing.T) {
+		results, err := store.SearchText(ctx, "café", FindOptions{Limit: 1})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("deve retornar erro para texto vazio", func(t *testing.T) {
+		_, err := store.SearchText(ctx, "", FindOptions{})
+		assert.Error(t, err)
+	})
+}
+
 // ==================== TESTES COUNT ====================
 
 func TestMongoCount(t *testing.T) {
@@ -918,6 +1708,71 @@ func TestMongoHas(t *testing.T) {
 	}
 }
 
+func TestMongoHasAll(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "Primeiro"})
+	store.Save(ctx, &TestEntity{ID: "2", Name: "Segundo"})
+
+	t.Run("deve informar quais IDs existem", func(t *testing.T) {
+		result, err := store.HasAll(ctx, []any{"1", "2", "nao-existe"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[any]bool{"1": true, "2": true, "nao-existe": false}, result)
+	})
+
+	t.Run("deve retornar mapa vazio sem IDs", func(t *testing.T) {
+		result, err := store.HasAll(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestMongoMissingIds(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "Primeiro"})
+	store.Save(ctx, &TestEntity{ID: "2", Name: "Segundo"})
+
+	missing, err := store.MissingIds(ctx, []any{"nao-existe", "1", "outro", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"nao-existe", "outro"}, missing)
+}
+
+func TestMongoHasWhere(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "Existe", Age: 30})
+
+	t.Run("deve retornar true quando algum documento corresponde", func(t *testing.T) {
+		result, err := store.HasWhere(ctx, map[string]any{"name": "Existe", "age__gte": 18})
+		assert.NoError(t, err)
+		assert.True(t, result)
+	})
+
+	t.Run("deve retornar false quando nenhum documento corresponde", func(t *testing.T) {
+		result, err := store.HasWhere(ctx, map[string]any{"name": "Não Existe"})
+		assert.NoError(t, err)
+		assert.False(t, result)
+	})
+
+	t.Run("deve propagar o erro da consulta", func(t *testing.T) {
+		_, err := store.HasWhere(ctx, map[string]any{"age": bson.M{"$invalid": 1}})
+		assert.Error(t, err)
+	})
+}
+
 // ==================== TESTES UPDATE ====================
 
 func TestMongoUpdate(t *testing.T) {
@@ -1026,25 +1881,149 @@ func TestMongoUpdate(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			entity := tt.setup()
-			toUpdate := tt.update(entity)
+		t.Run(tt.name, func(t *testing.T) {
+			entity := tt.setup()
+			toUpdate := tt.update(entity)
+
+			result, err := store.Update(ctx, toUpdate)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestMongoWithManagedTimestamps(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection, WithManagedTimestamps(false, false))
+	ctx := context.Background()
+
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	saved, err := store.Save(ctx, &TestEntity{ID: "1", Name: "João"})
+	assert.NoError(t, err)
+	assert.True(t, saved.CreatedAt.IsZero())
+	assert.True(t, saved.UpdatedAt.IsZero())
+
+	saved.UpdatedAt = past
+	updated, err := store.Update(ctx, saved)
+	assert.NoError(t, err)
+	assert.True(t, updated.UpdatedAt.Equal(past))
+
+	_, err = store.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"_id": "1"}, Fields: map[string]any{"age": 40}}})
+	assert.NoError(t, err)
+
+	found, err := store.FindById(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 40, found.Age)
+	assert.True(t, found.UpdatedAt.Equal(past))
+}
+
+// ==================== TESTES UPDATE FIELDS ====================
+
+func TestMongoUpdateFields(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	t.Run("deve atualizar apenas o nome preservando os demais campos", func(t *testing.T) {
+		store.Save(ctx, &TestEntity{ID: "1", Name: "Original", Age: 30, Score: 80.5, Active: true})
+
+		result, err := store.UpdateFields(ctx, &TestEntity{ID: "1", Name: "Atualizado"}, "name")
+		assert.NoError(t, err)
+		assert.Equal(t, "Atualizado", result.Name)
+		assert.Equal(t, 30, result.Age)
+		assert.Equal(t, 80.5, result.Score)
+		assert.True(t, result.Active)
+	})
+
+	t.Run("deve retornar erro sem campos informados", func(t *testing.T) {
+		_, err := store.UpdateFields(ctx, &TestEntity{ID: "1", Name: "Teste"})
+		assert.Error(t, err)
+	})
+
+	t.Run("deve retornar erro para campo inexistente", func(t *testing.T) {
+		_, err := store.UpdateFields(ctx, &TestEntity{ID: "1"}, "inexistente")
+		assert.Error(t, err)
+	})
+
+	t.Run("deve retornar erro para documento inexistente", func(t *testing.T) {
+		_, err := store.UpdateFields(ctx, &TestEntity{ID: "nao-existe", Name: "Teste"}, "name")
+		assert.Error(t, err)
+	})
+}
+
+func TestMongoErrNotFound(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"FindById", func() error { _, err := store.FindById(ctx, "nao-existe"); return err }},
+		{"FindByIdInto", func() error { return store.FindByIdInto(ctx, "nao-existe", &TestEntity{}) }},
+		{"FindOne", func() error { _, err := store.FindOne(ctx, map[string]any{"name": "Inexistente"}); return err }},
+		{"Update", func() error { _, err := store.Update(ctx, &TestEntity{ID: "nao-existe", Name: "Teste"}); return err }},
+		{"UpdateFields", func() error {
+			_, err := store.UpdateFields(ctx, &TestEntity{ID: "nao-existe", Name: "Teste"}, "name")
+			return err
+		}},
+		{"Delete", func() error { return store.Delete(ctx, "nao-existe") }},
+		{"DeleteOne", func() error { return store.DeleteOne(ctx, map[string]any{"name": "Inexistente"}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run("deve retornar ErrNotFound no "+tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.call(), ErrNotFound)
+		})
+	}
+}
 
-			result, err := store.Update(ctx, toUpdate)
+func TestMongoUpdateWhere(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
 
-			assert.NoError(t, err)
-			assert.NotNil(t, result)
+	t.Run("deve atualizar quando o documento atende ao filtro extra", func(t *testing.T) {
+		store.Save(ctx, &TestEntity{ID: "1", Name: "Pendente", Age: 30})
 
-			if tt.check != nil {
-				tt.check(t, result)
-			}
-		})
-	}
+		result, err := store.UpdateWhere(ctx, &TestEntity{ID: "1", Name: "Processado", Age: 30}, map[string]any{"name": "Pendente"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Processado", result.Name)
+	})
+
+	t.Run("deve retornar ErrConflict quando o filtro extra não é atendido", func(t *testing.T) {
+		store.Save(ctx, &TestEntity{ID: "2", Name: "Processado"})
+
+		_, err := store.UpdateWhere(ctx, &TestEntity{ID: "2", Name: "Outro"}, map[string]any{"name": "Pendente"})
+		assert.ErrorIs(t, err, ErrConflict)
+
+		found, _ := store.FindById(ctx, "2")
+		assert.Equal(t, "Processado", found.Name)
+	})
+
+	t.Run("deve retornar ErrNotFound para documento inexistente", func(t *testing.T) {
+		_, err := store.UpdateWhere(ctx, &TestEntity{ID: "nao-existe", Name: "Teste"}, map[string]any{"name": "Pendente"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
 }
 
 // ==================== TESTES UPDATE MANY ====================
@@ -1392,6 +2371,31 @@ func TestMongoUpsert(t *testing.T) {
 				assert.NotZero(t, found.CreatedAt)
 			},
 		},
+		{
+			name: "deve atualizar pela chave secundária com filtros Or sem duplicar",
+			setup: func() {
+				store.Save(ctx, &TestEntity{ID: "or-original", Name: "joao@example.com", Age: 20})
+			},
+			input: &TestEntity{
+				ID:   "or-outro-id",
+				Name: "joao@example.com",
+				Age:  30,
+			},
+			filters: []StoreUpsertFilter{
+				{UpsertFieldKey: "ID", UpsertBsonKey: "_id", Or: true},
+				{UpsertFieldKey: "Name", UpsertBsonKey: "name", Or: true},
+			},
+			check: func(t *testing.T, result *UpdateResult) {
+				assert.Equal(t, int64(1), result.MatchedCount)
+				assert.Equal(t, int64(0), result.UpsertedCount)
+
+				count, _ := store.Count(ctx, bson.M{})
+				assert.Equal(t, int64(1), *count)
+
+				found, _ := store.FindById(ctx, "or-original")
+				assert.Equal(t, 30, found.Age)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1416,6 +2420,99 @@ func TestMongoUpsert(t *testing.T) {
 	}
 }
 
+func TestMongoUpsert_CompoundKey(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestTenantUserEntity](collection)
+	ctx := context.Background()
+
+	filters := []StoreUpsertFilter{
+		{UpsertFieldKey: "Tenant", UpsertBsonKey: "tenant"},
+		{UpsertFieldKey: "Email", UpsertBsonKey: "email"},
+	}
+
+	result, err := store.Upsert(ctx, &TestTenantUserEntity{Tenant: "acme", Email: "joao@example.com", Name: "João"}, filters)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.UpsertedCount)
+	assert.IsType(t, bson.ObjectID{}, result.UpsertedID)
+
+	result, err = store.Upsert(ctx, &TestTenantUserEntity{Tenant: "acme", Email: "joao@example.com", Name: "João Silva"}, filters)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+	assert.Equal(t, int64(0), result.UpsertedCount)
+
+	// Mesmo e-mail em outro tenant gera um novo documento
+	result, err = store.Upsert(ctx, &TestTenantUserEntity{Tenant: "globex", Email: "joao@example.com", Name: "João"}, filters)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.UpsertedCount)
+
+	found, err := store.FindOne(ctx, map[string]any{"tenant": "acme", "email": "joao@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "João Silva", found.Name)
+	assert.False(t, found.ID.IsZero())
+
+	count, _ := store.Count(ctx, map[string]any{})
+	assert.Equal(t, int64(2), *count)
+}
+
+func TestMongoUpsertReturning(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	_, err := store.Save(ctx, &TestEntity{ID: "ret-1", Name: "Original", Age: 20})
+	assert.NoError(t, err)
+
+	// Upsert pela chave composta: o documento existente é atualizado e mantém o _id original
+	result, err := store.UpsertReturning(ctx, &TestEntity{Name: "Original", Age: 30}, []StoreUpsertFilter{
+		{UpsertFieldKey: "Name", UpsertBsonKey: "name"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ret-1", result.ID)
+	assert.Equal(t, 30, result.Age)
+
+	count, _ := store.Count(ctx, map[string]any{})
+	assert.Equal(t, int64(1), *count)
+}
+
+func TestMongoUpsertManyWith(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "João", Age: 20})
+	store.Save(ctx, &TestEntity{ID: "2", Name: "Maria", Age: 25})
+
+	byName := []StoreUpsertFilter{{UpsertFieldKey: "Name", UpsertBsonKey: "name"}}
+
+	result, err := store.UpsertManyWith(ctx, []UpsertItem[TestEntity]{
+		{Entity: TestEntity{ID: "1", Name: "João Atualizado", Age: 21}},
+		{Entity: TestEntity{ID: "outro", Name: "Maria", Age: 26}, Filters: byName},
+		{Entity: TestEntity{ID: "3", Name: "Pedro", Age: 30}, Filters: byName},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.MatchedCount)
+	assert.Equal(t, int64(1), result.UpsertedCount)
+
+	found, err := store.FindById(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "João Atualizado", found.Name)
+
+	found, err = store.FindById(ctx, "2")
+	assert.NoError(t, err)
+	assert.Equal(t, 26, found.Age)
+
+	found, err = store.FindById(ctx, "3")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pedro", found.Name)
+}
+
 // ==================== TESTES UPSERT MANY ====================
 
 func TestMongoUpsertMany(t *testing.T) {
@@ -1530,6 +2627,83 @@ func TestMongoUpsertMany(t *testing.T) {
 	}
 }
 
+func TestMongoUpsertMany_Batches(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection, WithBatchSize(2))
+	ctx := context.Background()
+
+	_, err := store.Save(ctx, &TestEntity{ID: "batch-2", Name: "Original", Age: 20})
+	assert.NoError(t, err)
+
+	input := []TestEntity{
+		{ID: "batch-1", Name: "Doc 1", Age: 25},
+		{ID: "batch-2", Name: "Atualizado", Age: 30},
+		{ID: "batch-3", Name: "Doc 3", Age: 35},
+		{ID: "batch-4", Name: "Doc 4", Age: 40},
+		{ID: "batch-5", Name: "Doc 5", Age: 45},
+	}
+
+	result, err := store.UpsertMany(ctx, input, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(4), result.UpsertedCount)
+	assert.Equal(t, int64(1), result.ModifiedCount)
+	assert.Len(t, result.UpsertedIDs, 4)
+	assert.Equal(t, "batch-5", result.UpsertedIDs[4])
+	assert.NotContains(t, result.UpsertedIDs, int64(1))
+
+	count, err := store.Count(ctx, bson.M{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), *count)
+}
+func TestMongoUpsertMany_OrderedWrites(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	assert.NoError(t, err)
+
+	store := NewMongoStore[TestEntity](collection)
+	store.Save(ctx, &TestEntity{ID: "existente", Name: "Duplicado"})
+
+	// O segundo documento viola o índice único de name
+	batch := func(prefix string) []TestEntity {
+		return []TestEntity{
+			{ID: prefix + "-1", Name: prefix + " 1"},
+			{ID: prefix + "-2", Name: "Duplicado"},
+			{ID: prefix + "-3", Name: prefix + " 3"},
+		}
+	}
+
+	t.Run("deve parar no primeiro erro por padrão", func(t *testing.T) {
+		_, err := store.UpsertMany(ctx, batch("ordenado"), nil)
+		assert.Error(t, err)
+
+		has := store.Has(ctx, "ordenado-3")
+		assert.False(t, has)
+	})
+
+	t.Run("deve gravar os demais documentos sem ordenação", func(t *testing.T) {
+		result, err := store.Scoped(WithOrderedWrites(false)).UpsertMany(ctx, batch("desordenado"), nil)
+		assert.Error(t, err)
+
+		has := store.Has(ctx, "desordenado-3")
+		assert.True(t, has)
+
+		// O resultado reporta o erro pelo índice do documento e conta os upserts aplicados
+		assert.NotNil(t, result)
+		assert.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors, int64(1))
+		assert.Equal(t, int64(2), result.UpsertedCount)
+	})
+}
+
 // ==================== TESTES DELETE ====================
 
 func TestMongoDelete(t *testing.T) {
@@ -1592,7 +2766,7 @@ func TestMongoDelete(t *testing.T) {
 			err := store.Delete(ctx, tt.id)
 
 			if tt.wantErr {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, ErrNotFound)
 				return
 			}
 
@@ -1605,6 +2779,50 @@ func TestMongoDelete(t *testing.T) {
 	}
 }
 
+// ==================== TESTES DELETE IF EXISTS ====================
+
+func TestMongoDeleteIfExists(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	t.Run("deve retornar true ao remover documento existente", func(t *testing.T) {
+		store.Save(ctx, &TestEntity{ID: "1", Name: "Para Deletar"})
+
+		deleted, err := store.DeleteIfExists(ctx, "1")
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.False(t, store.Has(ctx, "1"))
+	})
+
+	t.Run("deve retornar false sem erro para documento inexistente", func(t *testing.T) {
+		deleted, err := store.DeleteIfExists(ctx, "nao-existe")
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+}
+
+func TestMongoDeleteReturning(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestEntity{ID: "1", Name: "Para Deletar"})
+
+	result, err := store.DeleteReturning(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.DeletedCount)
+	assert.False(t, store.Has(ctx, "1"))
+
+	result, err = store.DeleteReturning(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.DeletedCount)
+}
+
 // ==================== TESTES DELETE ONE ====================
 
 func TestMongoDeleteOne(t *testing.T) {
@@ -1725,6 +2943,18 @@ func TestMongoDeleteOne(t *testing.T) {
 				assert.True(t, store.Has(ctx, "3"))
 			},
 		},
+		{
+			name: "deve deletar com operador do DSL __lt",
+			setup: func() {
+				store.Save(ctx, &TestEntity{ID: "1", Name: "Doc", Age: 20})
+				store.Save(ctx, &TestEntity{ID: "2", Name: "Doc", Age: 30})
+			},
+			filter: map[string]interface{}{"age__lt": 25},
+			check: func(t *testing.T) {
+				assert.False(t, store.Has(ctx, "1"))
+				assert.True(t, store.Has(ctx, "2"))
+			},
+		},
 		{
 			name: "deve retornar erro quando nenhum documento é encontrado",
 			setup: func() {
@@ -1886,6 +3116,74 @@ func TestMongoDeleteMany(t *testing.T) {
 	}
 }
 
+// ==================== TESTES DESCRIBE ====================
+
+func TestMongoDescribe(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+
+	named, ok := store.(interface{ CollectionName() string })
+	assert.True(t, ok)
+	assert.Equal(t, collection.Name(), named.CollectionName())
+	assert.Equal(t, "mongo:"+collection.Database().Name()+"/"+collection.Name(), store.Describe())
+}
+
+// ==================== TESTES DELETE BY IDS ====================
+
+func TestMongoDeleteByIds(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i := range 5 {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("lote-%d", i), Name: "Lote"})
+	}
+
+	result, err := store.DeleteByIds(ctx, []any{"lote-0", "lote-2", "lote-4"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.DeletedCount)
+
+	count, _ := store.Count(ctx, bson.M{})
+	assert.Equal(t, int64(2), *count)
+
+	result, err = store.DeleteByIds(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.DeletedCount)
+}
+
+func TestMongoDeleteManyBatch(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	for i, name := range []string{"Ana", "Ana", "Bruno", "Carla", "Davi"} {
+		store.Save(ctx, &TestEntity{ID: fmt.Sprintf("lote-%d", i), Name: name})
+	}
+
+	result, err := store.DeleteManyBatch(ctx, []map[string]any{
+		{"name": "Ana"},
+		{"name__in": []string{"Bruno", "Inexistente"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.DeletedCount)
+
+	count, _ := store.Count(ctx, bson.M{})
+	assert.Equal(t, int64(2), *count)
+
+	_, err = store.DeleteManyBatch(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = store.DeleteManyBatch(ctx, []map[string]any{{"name": "Carla"}, nil})
+	assert.Error(t, err)
+	assert.True(t, store.Has(ctx, "lote-3"))
+}
+
 // ==================== TESTES WITH TRANSACTION ====================
 
 func TestMongoWithTransaction(t *testing.T) {
@@ -1951,6 +3249,72 @@ func TestMongoWithTransaction(t *testing.T) {
 	})
 }
 
+// ==================== TESTES AGGREGATE INTO ====================
+
+func TestMongoAggregateInto(t *testing.T) {
+	collection, cleanup := setupMongoTest(t)
+	defer cleanup()
+
+	store := NewMongoStore[TestEntity](collection)
+	ctx := context.Background()
+
+	testDocs := []TestEntity{
+		{ID: "1", Name: "João", Active: true, Score: 10},
+		{ID: "2", Name: "Maria", Active: true, Score: 20},
+		{ID: "3", Name: "Pedro", Active: false, Score: 5},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	type ActiveTotal struct {
+		Active bool    `bson:"_id"`
+		Count  int64   `bson:"count"`
+		Score  float64 `bson:"score"`
+	}
+
+	t.Run("deve decodificar o resultado do $group em outro tipo", func(t *testing.T) {
+		results, err := AggregateInto[ActiveTotal](ctx, store, mongo.Pipeline{
+			{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: "$active"},
+				{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+				{Key: "score", Value: bson.D{{Key: "$sum", Value: "$score"}}},
+			}}},
+			{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []ActiveTotal{
+			{Active: true, Count: 2, Score: 30},
+			{Active: false, Count: 1, Score: 5},
+		}, results)
+	})
+
+	t.Run("deve retornar erro para pipeline inválido", func(t *testing.T) {
+		_, err := AggregateInto[ActiveTotal](ctx, store, mongo.Pipeline{
+			{{Key: "$invalid", Value: 1}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+// ==================== TESTES MAP TO BSON D ====================
+
+func TestMongoMapToBsonD(t *testing.T) {
+	store := &mongoStore[TestEntity]{}
+
+	t.Run("deve manter filtro $elemMatch sem alteração", func(t *testing.T) {
+		elemMatch := mongoutils.CreateElemMatchFilter("items", bson.M{"sku": "A1", "qty": bson.M{"$gte": 2}})
+
+		result, err := store.mapToBsonD(elemMatch)
+		assert.NoError(t, err)
+
+		assert.Equal(t, bson.D{{Key: "items", Value: bson.M{"$elemMatch": bson.M{
+			"sku": "A1",
+			"qty": bson.M{"$gte": 2},
+		}}}}, result)
+	})
+}
+
 // ==================== TESTES DE EDGE CASES ====================
 
 func TestMongoEdgeCases(t *testing.T) {
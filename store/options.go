@@ -0,0 +1,428 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
+)
+
+// TenantPlaceholder trecho do nome da tabela ou coleção substituído pelo tenant do contexto
+const TenantPlaceholder = "{tenant}"
+
+// DefaultBatchSize quantidade de operações enviadas por lote quando WithBatchSize não é informado
+const DefaultBatchSize = 1000
+
+// DefaultMaxLimit maior FindOptions.Limit aceito quando WithMaxLimit não é informado
+const DefaultMaxLimit int64 = 100_000
+
+// tenantPattern restringe os tenants a identificadores seguros para compor nomes de tabela
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Option configura comportamentos opcionais de um store
+type Option func(*storeOptions)
+
+// storeOptions reúne as configurações opcionais compartilhadas pelos stores
+type storeOptions struct {
+	reloadAfterSave bool
+	pkMode          PKMode
+	tenantKey       any
+	tenant          string
+	batchSize       int
+	writeConcern    *writeconcern.WriteConcern
+	readPreference  *readpref.ReadPref
+	bsonOptions     *options.BSONOptions
+	registry        *bson.Registry
+	countCacheTTL   time.Duration
+	timeLayouts     []string
+	findDefaults    FindOptions
+	readTransform   any
+	saveCheckpoint  int
+	readDB          *sql.DB
+	orderedWrites   *bool
+	// unmanagedCreatedAt e unmanagedUpdatedAt desativam o preenchimento dos timestamps pelo store
+	unmanagedCreatedAt bool
+	unmanagedUpdatedAt bool
+	requestIDKey       any
+	omitZeroTimes      bool
+	// maxLimit maior Limit aceito nas consultas: 0 usa DefaultMaxLimit e negativo desativa a verificação
+	maxLimit int64
+	logger   LoggerFromContext
+	// skipDecodeErrors ignora, nas listagens do Mongo, os documentos que não decodificam em T
+	skipDecodeErrors bool
+}
+
+func newStoreOptions(opts []Option) storeOptions {
+	var o storeOptions
+	return o.with(opts)
+}
+
+// with retorna uma cópia das configurações com as opções aplicadas por cima
+func (o storeOptions) with(opts []Option) storeOptions {
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// routesByTenant indica se o nome da tabela ou coleção depende do tenant
+func (o storeOptions) routesByTenant() bool {
+	return o.tenantKey != nil || o.tenant != ""
+}
+
+// WithReloadAfterSave recarrega o registro após o Save, refletindo na entidade os
+// valores preenchidos pelo banco (DEFAULT, triggers, colunas calculadas)
+func WithReloadAfterSave() Option {
+	return func(o *storeOptions) {
+		o.reloadAfterSave = true
+	}
+}
+
+// WithPKMode define como a chave primária é gerada no SQL, sobrescrevendo o
+// parâmetro autoincrement do NewSQLStore
+func WithPKMode(mode PKMode) Option {
+	return func(o *storeOptions) {
+		o.pkMode = mode
+	}
+}
+
+// WithTenantContextKey habilita o roteamento multi-tenant: o valor lido do contexto com a
+// chave informada substitui o TenantPlaceholder no nome da tabela ou coleção (ex.: "events_{tenant}").
+// Sem tenant no contexto as operações falham com ErrMissingTenant, e com ErrInvalidTenant quando
+// ele tem caracteres fora de [A-Za-z0-9_], sem chegar ao banco
+func WithTenantContextKey(key any) Option {
+	return func(o *storeOptions) {
+		o.tenantKey = key
+	}
+}
+
+// WithTenant fixa o tenant que substitui o TenantPlaceholder no nome da tabela ou coleção,
+// com prioridade sobre o tenant do contexto. Útil com Scoped para um store por requisição
+func WithTenant(tenant string) Option {
+	return func(o *storeOptions) {
+		o.tenant = tenant
+	}
+}
+
+// WithRequestIDContextKey inclui nos erros do store o id de correlação lido do contexto com a
+// chave informada, ligando as falhas do banco à requisição de origem nos logs. Os erros são
+// envolvidos em RequestError, preservando errors.Is e errors.As sobre o erro original
+func WithRequestIDContextKey(key any) Option {
+	return func(o *storeOptions) {
+		o.requestIDKey = key
+	}
+}
+
+// tagRequestID envolve *err em RequestError com o id da requisição presente no contexto.
+// Erros já identificados (ex.: métodos que delegam a outros) não são envolvidos novamente
+func (o storeOptions) tagRequestID(ctx context.Context, err *error) {
+	if *err == nil || o.requestIDKey == nil {
+		return
+	}
+
+	value := ctx.Value(o.requestIDKey)
+	if value == nil {
+		return
+	}
+
+	var tagged *RequestError
+	if errors.As(*err, &tagged) {
+		return
+	}
+
+	*err = &RequestError{RequestID: fmt.Sprint(value), Err: *err}
+}
+
+// WithLoggerFromContext registra os comandos SQL do store (query, quantidade de parâmetros e
+// duração em Debug; falhas em Error) no logger obtido do contexto de cada operação, permitindo
+// logs com os campos da requisição (ex.: id de correlação) sem um logger global. Sem a opção
+// nenhum registro é feito. No Mongo o rastreamento é feito no client, com NewMongoCommandMonitor
+func WithLoggerFromContext(fn func(ctx context.Context) Logger) Option {
+	return func(o *storeOptions) {
+		o.logger = fn
+	}
+}
+
+// WithSkipDecodeErrors faz as listagens do Mongo (FindAll, Search, FindAllJoined, FindLast e
+// SearchText) decodificarem documento a documento: os que não decodificam em T (ex.: campo com
+// tipo divergente) são ignorados e os demais retornados junto a um erro que envolve ErrDecode
+// com a falha de cada documento. Não se aplica ao FindAll com IncludeTotalCount, cujos itens
+// chegam em um único documento do $facet
+//
+//	items, err := s.FindAll(ctx, f, opts)
+//	if err != nil && !errors.Is(err, store.ErrDecode) {
+//		return err
+//	}
+func WithSkipDecodeErrors() Option {
+	return func(o *storeOptions) {
+		o.skipDecodeErrors = true
+	}
+}
+
+// WithBatchSize define quantas operações são enviadas por lote nas escritas em massa
+// (ex.: UpsertMany no Mongo), mantendo cada BulkWrite abaixo dos limites do servidor
+func WithBatchSize(size int) Option {
+	return func(o *storeOptions) {
+		o.batchSize = size
+	}
+}
+
+// WithWriteConcern define o write concern do Mongo usado pelas escritas do store e pelas
+// transações do WithTransaction (o padrão das transações é majority)
+func WithWriteConcern(wc *writeconcern.WriteConcern) Option {
+	return func(o *storeOptions) {
+		o.writeConcern = wc
+	}
+}
+
+// WithBSONOptions define as BSONOptions e o registry do Mongo (qualquer um pode ser nil) usados
+// pela coleção do store e pela codificação própria do WithOmitZeroTimes. O driver não expõe
+// as opções de uma coleção já criada, então codecs próprios devem ser informados aqui, e não
+// apenas na coleção, para que todas as escritas os usem
+func WithBSONOptions(bsonOpts *options.BSONOptions, registry *bson.Registry) Option {
+	return func(o *storeOptions) {
+		o.bsonOptions = bsonOpts
+		o.registry = registry
+	}
+}
+
+// WithReadPreference define a preferência de leitura do Mongo usada pelas consultas do store.
+// Não se aplica às transações, que sempre leem do primário
+func WithReadPreference(rp *readpref.ReadPref) Option {
+	return func(o *storeOptions) {
+		o.readPreference = rp
+	}
+}
+
+// WithCountCache memoriza o resultado do Count por filtro durante o ttl, evitando repetir a
+// contagem em tabelas pouco alteradas. O cache é descartado a cada escrita feita pelo store
+// (ou explicitamente com InvalidateCount); escritas externas só são refletidas após o ttl
+func WithCountCache(ttl time.Duration) Option {
+	return func(o *storeOptions) {
+		o.countCacheTTL = ttl
+	}
+}
+
+// WithTimeLayouts define os layouts (no formato do pacote time) dos textos de data retornados
+// pelo driver SQL, tentados em ordem na leitura antes dos formatos conhecidos
+func WithTimeLayouts(layouts ...string) Option {
+	return func(o *storeOptions) {
+		o.timeLayouts = layouts
+	}
+}
+
+// WithDefaultFindOptions define os valores usados nas consultas paginadas (FindAll, Search etc.)
+// quando o chamador não informa Limit, SortBy ou OrderBy, substituindo os padrões do Initialize.
+// Com um Limit padrão, o Limit zero deixa de retornar todos os registros
+func WithDefaultFindOptions(defaults FindOptions) Option {
+	return func(o *storeOptions) {
+		o.findDefaults = defaults
+	}
+}
+
+// WithSaveCheckpoint faz o SaveMany do SQL confirmar a transação a cada every registros, de
+// modo que uma falha descarte apenas o lote corrente. Troca a atomicidade da importação pela
+// possibilidade de retomá-la a partir de InsertManyResult.CommittedCount. Ignorado com WithTx
+func WithSaveCheckpoint(every int) Option {
+	return func(o *storeOptions) {
+		o.saveCheckpoint = every
+	}
+}
+
+// WithReadDB direciona as consultas do SQLStore (FindById, FindOne, FindAll, Count, Has etc.)
+// para uma conexão somente leitura, como uma réplica. As escritas, as transações e as leituras
+// feitas logo após uma escrita (ex.: WithReloadAfterSave) continuam no banco principal
+func WithReadDB(db *sql.DB) Option {
+	return func(o *storeOptions) {
+		o.readDB = db
+	}
+}
+
+// WithOrderedWrites escolhe entre escritas em massa ordenadas (param no primeiro erro) e
+// desordenadas (melhor esforço: os demais registros são gravados e os erros reunidos no final).
+// Sem a opção cada método mantém o seu padrão. Mapeamento por backend:
+//
+//   - Mongo: define o ordered do InsertMany do SaveMany (padrão desordenado) e do BulkWrite do
+//     UpsertMany e UpsertManyWith (padrão ordenado). SaveManyNotOrdered é sempre desordenado
+//   - SQL: no UpsertMany e UpsertManyWith, o modo desordenado executa cada registro sob um
+//     savepoint da transação, de modo que uma falha desfaz apenas aquele registro. O SaveMany
+//     sempre grava em ordem e qualquer erro desfaz o lote (veja WithSaveCheckpoint)
+//
+// No modo desordenado do UpsertMany os erros de cada registro ficam em BulkWriteResult.Errors,
+// pelo índice de entrada, e o resultado é retornado junto com um erro que os resume
+//
+// Por chamada, use com Scoped: store.Scoped(WithOrderedWrites(false)).UpsertMany(...)
+func WithOrderedWrites(ordered bool) Option {
+	return func(o *storeOptions) {
+		o.orderedWrites = &ordered
+	}
+}
+
+// ordered retorna a ordenação configurada com WithOrderedWrites ou o padrão do método
+func (o storeOptions) ordered(def bool) bool {
+	if o.orderedWrites == nil {
+		return def
+	}
+
+	return *o.orderedWrites
+}
+
+// WithManagedTimestamps define se o store preenche created_at (CreatedAt) e updated_at
+// (UpdatedAt). Por padrão os dois são gerenciados; desative quando o banco mantém a coluna
+// (DEFAULT ou trigger) ou quando a aplicação informa os valores. Com updated desativado, as
+// inserções (Save, SaveMany e derivados) gravam o UpdatedAt da entidade e Update, UpdateWhere,
+// UpdateFields, UpdateMany e Upsert não acrescentam o updated_at ao SET
+func WithManagedTimestamps(created, updated bool) Option {
+	return func(o *storeOptions) {
+		o.unmanagedCreatedAt = !created
+		o.unmanagedUpdatedAt = !updated
+	}
+}
+
+// managesCreatedAt indica se o store preenche o created_at
+func (o storeOptions) managesCreatedAt() bool {
+	return !o.unmanagedCreatedAt
+}
+
+// managesUpdatedAt indica se o store preenche o updated_at
+func (o storeOptions) managesUpdatedAt() bool {
+	return !o.unmanagedUpdatedAt
+}
+
+// WithOmitZeroTimes omite, nas inserções do Mongo (Save, SaveMany e derivados), os campos
+// time.Time com o valor zero, que ficam ausentes no documento em vez de gravados como
+// 0001-01-01. Vale também para documentos aninhados, inclusive dentro de arrays; sem efeito no
+// SQL, que grava NULL apenas em campos ponteiro. A entidade é codificada pelo store com as
+// opções de WithBSONOptions
+func WithOmitZeroTimes() Option {
+	return func(o *storeOptions) {
+		o.omitZeroTimes = true
+	}
+}
+
+// WithReadTransform aplica fn a cada entidade logo após a decodificação nas leituras do store
+// (FindById, FindOne, FindAll e derivados), centralizando campos derivados ou a ocultação de
+// dados. O tipo T deve ser o mesmo do store: uma transformação de outro tipo é erro de
+// programação e causa pânico na construção do store (ou no Scoped)
+func WithReadTransform[T any](fn func(*T)) Option {
+	return func(o *storeOptions) {
+		o.readTransform = fn
+	}
+}
+
+// applyFindDefaults preenche os campos não informados de opts com os padrões do store
+func (o storeOptions) applyFindDefaults(opts *FindOptions) {
+	if opts.Limit <= 0 && o.findDefaults.Limit > 0 {
+		opts.Limit = o.findDefaults.Limit
+	}
+	if opts.SortBy == "" {
+		opts.SortBy = o.findDefaults.SortBy
+	}
+	if opts.OrderBy == "" {
+		opts.OrderBy = o.findDefaults.OrderBy
+	}
+}
+
+// WithMaxLimit define o maior FindOptions.Limit aceito por FindAll, FindAllRaw, FindAllJoined,
+// Search e SearchText, e o maior n do FindLast; limites maiores falham com ErrLimitTooLarge antes de consultar o banco,
+// evitando que um Limit absurdo (ex.: math.MaxInt64) leia a tabela inteira. O padrão é
+// DefaultMaxLimit; limit <= 0 desativa a verificação. Limit 0 (sem paginação) não é afetado
+func WithMaxLimit(limit int64) Option {
+	return func(o *storeOptions) {
+		if limit <= 0 {
+			limit = -1
+		}
+		o.maxLimit = limit
+	}
+}
+
+// checkLimit rejeita limites acima do WithMaxLimit
+func (o storeOptions) checkLimit(limit int64) error {
+	maxLimit := o.maxLimit
+	if maxLimit == 0 {
+		maxLimit = DefaultMaxLimit
+	}
+
+	if maxLimit > 0 && limit > maxLimit {
+		return fmt.Errorf("%w: %d (máximo %d)", ErrLimitTooLarge, limit, maxLimit)
+	}
+
+	return nil
+}
+
+// chunkSize retorna o tamanho de lote configurado ou DefaultBatchSize
+func (o storeOptions) chunkSize() int {
+	if o.batchSize <= 0 {
+		return DefaultBatchSize
+	}
+
+	return o.batchSize
+}
+
+// resolveTenantName substitui o TenantPlaceholder de name pelo tenant fixado com WithTenant
+// ou, na ausência dele, pelo tenant presente no contexto. Retorna ErrMissingTenant sem tenant
+// e ErrInvalidTenant quando ele não atende ao tenantPattern, em vez de manter o placeholder:
+// no Mongo a coleção "events_{tenant}" seria criada e compartilhada entre os tenants
+func (o storeOptions) resolveTenantName(ctx context.Context, name string) (string, error) {
+	if !o.routesByTenant() || !strings.Contains(name, TenantPlaceholder) {
+		return name, nil
+	}
+
+	tenant := o.tenant
+	if tenant == "" {
+		if value := ctx.Value(o.tenantKey); value != nil {
+			tenant = fmt.Sprint(value)
+		}
+	}
+
+	if tenant == "" {
+		return "", fmt.Errorf("%w para %s", ErrMissingTenant, name)
+	}
+
+	if !tenantPattern.MatchString(tenant) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTenant, tenant)
+	}
+
+	return strings.ReplaceAll(name, TenantPlaceholder, tenant), nil
+}
+
+// mustMatchReadTransform interrompe a construção do store quando a transformação de
+// WithReadTransform não é do tipo da entidade, o que a faria ser ignorada em silêncio
+func mustMatchReadTransform[T any](constructor string, o storeOptions) {
+	if o.readTransform == nil {
+		return
+	}
+
+	if _, ok := o.readTransform.(func(*T)); !ok {
+		panic(fmt.Sprintf("%s: WithReadTransform recebeu %T, esperado func(*%s)", constructor, o.readTransform, reflect.TypeFor[T]()))
+	}
+}
+
+// transformEntity aplica a transformação de WithReadTransform, se configurada para o tipo T
+func transformEntity[T any](o storeOptions, entity *T) {
+	if fn, ok := o.readTransform.(func(*T)); ok && fn != nil {
+		fn(entity)
+	}
+}
+
+// transformEntities aplica a transformação de WithReadTransform a cada entidade da lista
+func transformEntities[T any](o storeOptions, entities []T) {
+	fn, ok := o.readTransform.(func(*T))
+	if !ok || fn == nil {
+		return
+	}
+
+	for i := range entities {
+		fn(&entities[i])
+	}
+}
@@ -0,0 +1,262 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/luma-sys/go-db-store/enum"
+)
+
+// schemaColumn coluna de T com o tipo inferido para o driver
+type schemaColumn struct {
+	name       string
+	definition string
+}
+
+// EnsureSchema sincroniza a tabela com as tags `db` de T: cria a tabela quando ela não existe
+// e adiciona as colunas que faltam. É conservador: nunca remove nem altera colunas existentes,
+// e as colunas adicionadas aceitam NULL para não falhar em tabelas com registros.
+//
+// Os tipos são inferidos do tipo Go do campo (inteiros, float, bool, string, time.Time e
+// []byte); os demais (enums, sql.Scanner etc.) são criados como texto. Colunas generated do
+// tipo time.Time recebem DEFAULT CURRENT_TIMESTAMP. Em PKModeServerDefault o DEFAULT da chave
+// primária não pode ser inferido, então a tabela deve ser criada manualmente
+func (s *SQLStore[T]) EnsureSchema(ctx context.Context) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+
+	columns := s.schemaColumns(reflect.TypeFor[T]())
+
+	exists, err := s.tableExists(ctx, table)
+	if err != nil {
+		return fmt.Errorf("erro ao verificar a tabela %s: %w", table, err)
+	}
+
+	if !exists {
+		return s.createTable(ctx, table, columns)
+	}
+
+	existing, err := s.existingColumns(ctx, table)
+	if err != nil {
+		return fmt.Errorf("erro ao ler as colunas da tabela %s: %w", table, err)
+	}
+
+	for _, column := range columns {
+		if existing[strings.ToLower(column.name)] {
+			continue
+		}
+
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column.name, column.definition)
+		if s.driver == enum.DatabaseDriverOracle {
+			query = fmt.Sprintf("ALTER TABLE %s ADD (%s %s)", table, column.name, column.definition)
+		}
+
+		if _, err := s.conn().ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("erro ao adicionar coluna %s: %w", column.name, err)
+		}
+	}
+
+	return nil
+}
+
+// tableExists consulta o catálogo do banco para saber se a tabela existe, para que falhas de
+// conexão ou permissão não sejam confundidas com a tabela ausente. Nomes qualificados
+// ("schema.tabela") são procurados no schema informado
+func (s *SQLStore[T]) tableExists(ctx context.Context, table string) (bool, error) {
+	quote := func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+
+	schema, name, qualified := strings.Cut(table, ".")
+	if !qualified {
+		name = table
+	}
+
+	var query string
+	switch s.driver {
+	case enum.DatabaseDriverSqlite:
+		catalog := "sqlite_master"
+		if qualified {
+			catalog = schema + ".sqlite_master"
+		}
+		query = fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE type = 'table' AND name = %s", catalog, quote(name))
+	case enum.DatabaseDriverPostgres:
+		query = fmt.Sprintf("SELECT COUNT(*) FROM (SELECT to_regclass(%s) AS t) r WHERE t IS NOT NULL", quote(table))
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		owner := "DATABASE()"
+		if qualified {
+			owner = quote(schema)
+		}
+		query = fmt.Sprintf("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = %s AND table_name = %s", owner, quote(name))
+	case enum.DatabaseDriverOracle:
+		owner := "SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')"
+		if qualified {
+			owner = "UPPER(" + quote(schema) + ")"
+		}
+		query = fmt.Sprintf("SELECT COUNT(*) FROM all_tables WHERE owner = %s AND table_name = UPPER(%s)", owner, quote(name))
+	default:
+		return false, fmt.Errorf("EnsureSchema não suportado para o driver %s", s.driver)
+	}
+
+	var count int64
+	if err := s.conn().QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// existingColumns retorna as colunas atuais da tabela, em minúsculas
+func (s *SQLStore[T]) existingColumns(ctx context.Context, table string) (map[string]bool, error) {
+	rows, err := s.conn().QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[strings.ToLower(name)] = true
+	}
+
+	return existing, nil
+}
+
+func (s *SQLStore[T]) createTable(ctx context.Context, table string, columns []schemaColumn) error {
+	if s.pkMode == PKModeServerDefault {
+		return fmt.Errorf("tabela %s não existe e o DEFAULT da chave primária não pode ser inferido em PKModeServerDefault", table)
+	}
+
+	definitions := make([]string, len(columns))
+	for i, column := range columns {
+		definitions[i] = column.name + " " + column.definition
+	}
+
+	// Oracle não suporta IF NOT EXISTS; a tabela só é criada depois de confirmada a ausência no catálogo
+	query := "CREATE TABLE IF NOT EXISTS %s (%s)"
+	if s.driver == enum.DatabaseDriverOracle {
+		query = "CREATE TABLE %s (%s)"
+	}
+
+	if _, err := s.conn().ExecContext(ctx, fmt.Sprintf(query, table, strings.Join(definitions, ", "))); err != nil {
+		return fmt.Errorf("erro ao criar tabela %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// schemaColumns retorna as colunas de t com a definição para o driver. Campos de structs
+// aninhadas geram as colunas "prefixo_coluna" gravadas pelo store
+func (s *SQLStore[T]) schemaColumns(t reflect.Type) []schemaColumn {
+	columns := make([]schemaColumn, 0, t.NumField())
+	for _, column := range columnFields(t) {
+		field := column.field
+		if !field.IsExported() {
+			continue
+		}
+
+		if column.name == s.primaryKey {
+			columns = append(columns, schemaColumn{name: column.name, definition: s.primaryKeyType(field.Type)})
+			continue
+		}
+
+		definition := s.columnType(field.Type)
+		if column.generated && indirectType(field.Type) == timeType {
+			definition += " DEFAULT " + s.currentTimestamp()
+		}
+
+		columns = append(columns, schemaColumn{name: column.name, definition: definition})
+	}
+
+	return columns
+}
+
+// currentTimestamp retorna o DEFAULT do instante atual com a mesma precisão da coluna de data
+func (s *SQLStore[T]) currentTimestamp() string {
+	if s.driver == enum.DatabaseDriverMysql || s.driver == enum.DatabaseDriverMariaDB {
+		return "CURRENT_TIMESTAMP(6)"
+	}
+
+	return "CURRENT_TIMESTAMP"
+}
+
+// primaryKeyType retorna a definição da chave primária, com a geração pelo banco em PKModeAutoIncrement
+func (s *SQLStore[T]) primaryKeyType(t reflect.Type) string {
+	if s.pkMode != PKModeAutoIncrement {
+		return s.columnType(t) + " PRIMARY KEY"
+	}
+
+	switch s.driver {
+	case enum.DatabaseDriverSqlite:
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		return "BIGINT AUTO_INCREMENT PRIMARY KEY"
+	case enum.DatabaseDriverOracle:
+		return "NUMBER(19) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+	default:
+		return "BIGSERIAL PRIMARY KEY"
+	}
+}
+
+// columnType mapeia o tipo Go para o tipo de coluna do driver
+func (s *SQLStore[T]) columnType(t reflect.Type) string {
+	t = indirectType(t)
+
+	var kind string
+	switch {
+	case t == timeType:
+		kind = "time"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		kind = "bytes"
+	case t.Kind() == reflect.Bool:
+		kind = "bool"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		kind = "int"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		kind = "float"
+	default:
+		kind = "string"
+	}
+
+	types := map[enum.DatabaseDriver]map[string]string{
+		enum.DatabaseDriverSqlite: {
+			"time": "TIMESTAMP", "bytes": "BLOB", "bool": "BOOLEAN", "int": "INTEGER", "float": "REAL", "string": "TEXT",
+		},
+		enum.DatabaseDriverPostgres: {
+			"time": "TIMESTAMP", "bytes": "BYTEA", "bool": "BOOLEAN", "int": "BIGINT", "float": "DOUBLE PRECISION", "string": "TEXT",
+		},
+		enum.DatabaseDriverMysql: {
+			"time": "DATETIME(6)", "bytes": "BLOB", "bool": "BOOLEAN", "int": "BIGINT", "float": "DOUBLE", "string": "VARCHAR(255)",
+		},
+		enum.DatabaseDriverOracle: {
+			"time": "TIMESTAMP", "bytes": "BLOB", "bool": "NUMBER(1)", "int": "NUMBER(19)", "float": "BINARY_DOUBLE", "string": "VARCHAR2(4000)",
+		},
+	}
+	types[enum.DatabaseDriverMariaDB] = types[enum.DatabaseDriverMysql]
+
+	if driverTypes, ok := types[s.driver]; ok {
+		return driverTypes[kind]
+	}
+
+	return types[enum.DatabaseDriverPostgres][kind]
+}
+
+// indirectType retorna o tipo apontado por ponteiros
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
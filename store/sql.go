@@ -3,8 +3,15 @@ package store
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"math/big"
 	"reflect"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,235 +21,398 @@ import (
 	"github.com/luma-sys/go-db-store/page"
 )
 
+// PKMode define como a chave primária de um registro novo é preenchida
+type PKMode int
+
+const (
+	// PKModeClientProvided a chave é informada pela aplicação e enviada no INSERT
+	PKModeClientProvided PKMode = iota + 1
+	// PKModeAutoIncrement a chave é gerada pelo banco e lida com LastInsertId
+	PKModeAutoIncrement
+	// PKModeServerDefault a chave é gerada por um DEFAULT da coluna (ex.: UUID) e lida
+	// com RETURNING nos bancos que suportam (Postgres, SQLite e MariaDB)
+	PKModeServerDefault
+)
+
 type SQLStore[T any] struct {
-	db            *sql.DB
-	driver        enum.DatabaseDriver
-	tableName     string
-	primaryKey    string
-	autoincrement bool
+	db         *sql.DB
+	tx         *sql.Tx // transação externa definida pelo WithTx
+	driver     enum.DatabaseDriver
+	tableName  string
+	primaryKey string
+	pkMode     PKMode
+	opts       storeOptions
+	columns    map[string]dbColumn // colunas mapeadas pelas tags `db` de T
+	timestamps timestampFields     // colunas do CreatedAt e UpdatedAt de T
+	counts     *countCache         // totais memorizados pelo WithCountCache (nil quando desabilitado)
 }
 
-func NewSQLStore[T any](db *sql.DB, driver enum.DatabaseDriver, tableName string, primaryKey string, autoincrement bool) Store[T] {
-	return &SQLStore[T]{
-		db:            db,
-		driver:        driver,
-		tableName:     tableName,
-		primaryKey:    primaryKey,
-		autoincrement: autoincrement,
-	}
-}
+func NewSQLStore[T any](db *sql.DB, driver enum.DatabaseDriver, tableName string, primaryKey string, autoincrement bool, opts ...Option) Store[T] {
+	mustBeStruct[T]("NewSQLStore")
 
-// WithTransaction para SQL usa uma simples transação
-func (s *SQLStore[T]) WithTransaction(ctx context.Context, fn Transaction) (any, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
+	options := newStoreOptions(opts)
+	mustMatchReadTransform[T]("NewSQLStore", options)
 
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
+	pkMode := options.pkMode
+	if pkMode == 0 {
+		pkMode = PKModeClientProvided
+		if autoincrement {
+			pkMode = PKModeAutoIncrement
 		}
-	}()
+	}
 
-	result, err := fn(tx)
-	if err != nil {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			return nil, fmt.Errorf("transaction error: %w, rollback error: %v", err, rollbackErr)
-		}
-		return nil, fmt.Errorf("transaction error: %w", err)
+	return &SQLStore[T]{
+		db:         db,
+		driver:     driver,
+		tableName:  tableName,
+		primaryKey: primaryKey,
+		pkMode:     pkMode,
+		opts:       options,
+		columns:    entityColumns(reflect.TypeFor[T]()),
+		timestamps: sqlTimestampFields(reflect.TypeFor[T]()),
+		counts:     newCountCache(options.countCacheTTL),
 	}
+}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("erro ao fazer commit: %w", err)
+// Scoped retorna uma cópia do store com as opções aplicadas sobre as atuais (ex.: WithTenant
+// por requisição). A cópia compartilha a conexão e o cache do Count
+func (s *SQLStore[T]) Scoped(opts ...Option) Store[T] {
+	scoped := *s
+	scoped.opts = s.opts.with(opts)
+	mustMatchReadTransform[T]("Scoped", scoped.opts)
+	if scoped.opts.pkMode != 0 {
+		scoped.pkMode = scoped.opts.pkMode
 	}
 
-	return result, nil
+	return &scoped
 }
 
-func (s *SQLStore[T]) Has(ctx context.Context, id any) bool {
-	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s = ?)", s.tableName, s.primaryKey)
+// TableName retorna o nome da tabela configurado, sem a substituição do tenant
+func (s *SQLStore[T]) TableName() string {
+	return s.tableName
+}
 
-	var exists bool
-	err := s.db.QueryRowContext(ctx, query, id).Scan(&exists)
+// Driver retorna o driver do banco de dados do store
+func (s *SQLStore[T]) Driver() enum.DatabaseDriver {
+	return s.driver
+}
 
-	return err == nil && exists
+// Describe retorna o driver e a tabela do store (ex.: "sql:postgres/users")
+func (s *SQLStore[T]) Describe() string {
+	return fmt.Sprintf("sql:%s/%s", s.driver, s.tableName)
 }
 
-// Count retorna o número de registros baseado em uma consulta
-func (s *SQLStore[T]) Count(ctx context.Context, q map[string]any) (*int64, error) {
-	whereClause, values := s.buildWhereClause(q)
-	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", s.tableName)
-	query += whereClause
+// DebugFields retorna o mapeamento derivado das tags `db` de T: as colunas lidas nas consultas
+// e, marcadas como Skipped, as que não são gravadas no INSERT (generated e chave primária gerada
+// pelo banco). Campos não exportados são ignorados, como nas escritas
+func (s *SQLStore[T]) DebugFields() []FieldInfo {
+	return sqlFieldInfos(reflect.TypeFor[T](), s.primaryKey, s.generatesPK())
+}
 
-	var count int64
-	err := s.db.QueryRowContext(ctx, query, values...).Scan(&count)
-	if err != nil {
-		return nil, err
-	}
+// sqlExecutor operações comuns entre *sql.DB e *sql.Tx
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
 
-	return &count, nil
+// sqlConn operações comuns ao *sql.DB e ao *sql.Tx usadas pelo store
+type sqlConn interface {
+	sqlExecutor
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
 }
 
-// FindById busca um registro por ID
-func (s *SQLStore[T]) FindById(ctx context.Context, id any) (*T, error) {
-	query := fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", s.tableName, s.primaryKey)
+// sqlTx transação usada pelas operações em lote do store
+type sqlTx interface {
+	sqlConn
+	Commit() error
+	Rollback() error
+}
 
-	stmt, err := s.db.Prepare(query)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao preparar query: %v", err)
-	}
-	defer stmt.Close()
+// sharedTx adapta a transação externa do WithTx: o commit e o rollback ficam com quem a abriu
+type sharedTx struct {
+	*sql.Tx
+}
 
-	rows, err := stmt.Query(id)
-	if err != nil {
-		return nil, fmt.Errorf("error querying room: %w", err)
-	}
-	defer rows.Close()
+func (sharedTx) Commit() error   { return nil }
+func (sharedTx) Rollback() error { return nil }
 
-	if rows.Next() {
-		return s.parseRow(rows)
+// conn retorna a transação do WithTx ou, na ausência dela, o pool de conexões
+func (s *SQLStore[T]) conn() sqlConn {
+	if s.tx != nil {
+		return s.traced(s.tx)
 	}
 
-	return nil, fmt.Errorf("registro não encontrado")
+	return s.traced(s.db)
 }
 
-func (s *SQLStore[T]) FindOne(ctx context.Context, f map[string]interface{}) (*T, error) {
-	whereClause, values := s.buildWhereClause(f)
-	query := fmt.Sprintf("SELECT * FROM %s", s.tableName)
-	query += whereClause
-
-	// Oracle não suporta LIMIT, usa FETCH FIRST
-	if s.driver == enum.DatabaseDriverOracle {
-		query += " FETCH FIRST 1 ROWS ONLY"
-	} else {
-		query += " LIMIT 1"
+// traced envolve a conexão no loggingConn quando WithLoggerFromContext está configurado
+func (s *SQLStore[T]) traced(conn sqlConn) sqlConn {
+	if s.opts.logger == nil {
+		return conn
 	}
 
-	stmt, err := s.db.Prepare(query)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao preparar query: %v", err)
+	return loggingConn{sqlConn: conn, logger: s.opts.logger}
+}
+
+// readConn retorna a conexão das consultas: a réplica de WithReadDB quando configurada, exceto
+// dentro de uma transação do WithTx, que sempre lê do primário
+func (s *SQLStore[T]) readConn() sqlConn {
+	if s.tx == nil && s.opts.readDB != nil {
+		return s.traced(s.opts.readDB)
 	}
-	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, values...)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar documento: %w", err)
+	return s.conn()
+}
+
+// primary retorna uma cópia do store que também lê do primário, usada nas leituras feitas logo
+// após uma escrita, que ainda podem não ter sido replicadas
+func (s *SQLStore[T]) primary() *SQLStore[T] {
+	if s.opts.readDB == nil {
+		return s
 	}
-	defer rows.Close()
 
-	if rows.Next() {
-		result, err := s.parseRow(rows)
+	scoped := *s
+	scoped.opts.readDB = nil
+
+	return &scoped
+}
+
+// begin inicia uma transação no banco ou, com WithTx, reutiliza a transação externa
+func (s *SQLStore[T]) begin(ctx context.Context) (sqlTx, error) {
+	var tx sqlTx = sharedTx{Tx: s.tx}
+	if s.tx == nil {
+		dbTx, err := s.db.BeginTx(ctx, nil)
 		if err != nil {
-			return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+			return nil, err
 		}
-		return result, nil
+		tx = dbTx
+	}
+
+	if s.opts.logger == nil {
+		return tx, nil
 	}
 
-	return nil, fmt.Errorf("documento não encontrado com filtro %v", f)
+	return loggingTx{loggingConn: loggingConn{sqlConn: tx, logger: s.opts.logger}, tx: tx}, nil
 }
 
-// FindAll busca registros com paginação
-func (s *SQLStore[T]) FindAll(ctx context.Context, f map[string]any, opts FindOptions) ([]T, error) {
-	opts.Initialize()
+// WithTx retorna uma cópia do store que executa todas as operações na transação informada,
+// permitindo compor uma unidade de trabalho entre vários stores. O commit e o rollback
+// continuam com quem abriu a transação; as operações em lote não abrem transações próprias
+func (s *SQLStore[T]) WithTx(tx *sql.Tx) Store[T] {
+	scoped := *s
+	scoped.tx = tx
 
-	whereClause, values := s.buildWhereClause(f)
-	query := fmt.Sprintf("SELECT * FROM %s", s.tableName)
-	query += whereClause
+	return &scoped
+}
 
-	if opts.Limit > 0 {
-		skip := page.Skip(opts.Page, opts.Limit)
+// generatesPK indica se a chave primária é preenchida pelo banco e deve ficar fora do INSERT
+func (s *SQLStore[T]) generatesPK() bool {
+	return s.pkMode != PKModeClientProvided
+}
 
-		if s.driver == enum.DatabaseDriverOracle {
-			query = fmt.Sprintf("%s OFFSET :1 ROWS FETCH FIRST :2 ROWS ONLY", query)
-			values = append(values, skip, opts.Limit)
-		} else {
-			query = fmt.Sprintf("%s LIMIT ? OFFSET ?", query)
-			values = append(values, opts.Limit, skip)
-		}
+// supportsReturning indica se o driver aceita INSERT ... RETURNING
+func (s *SQLStore[T]) supportsReturning() bool {
+	switch s.driver {
+	case enum.DatabaseDriverPostgres, enum.DatabaseDriverSqlite, enum.DatabaseDriverMariaDB:
+		return true
+	default:
+		return false
 	}
+}
 
-	stmt, err := s.db.Prepare(query)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao preparar query: %v", err)
+// sqlStmt operações do *sql.Stmt usadas pelo store
+type sqlStmt interface {
+	ExecContext(ctx context.Context, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, args ...any) *sql.Row
+	Close() error
+}
+
+// stmtPreparer implementado pelas conexões que envolvem os statements que preparam (loggingConn)
+type stmtPreparer interface {
+	prepareStmt(ctx context.Context, query string) (sqlStmt, error)
+}
+
+// prepare prepara a query na conexão. Com WithLoggerFromContext, além da preparação, cada
+// execução do statement é registrada
+func prepare(ctx context.Context, conn sqlConn, query string) (sqlStmt, error) {
+	if preparer, ok := conn.(stmtPreparer); ok {
+		return preparer.prepareStmt(ctx, query)
 	}
-	defer stmt.Close()
 
-	// Executa a query
-	rows, err := stmt.Query(values...)
+	stmt, err := conn.PrepareContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("error querying %s: %w", s.tableName, err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	// Processa os resultados
-	var results []T
-	for rows.Next() {
-		record, err := s.parseRow(rows)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, *record)
-	}
+	return stmt, nil
+}
 
-	return results, nil
+// stmtExecutor adapta um sqlStmt para sqlExecutor, reaproveitando a query já preparada
+type stmtExecutor struct {
+	stmt sqlStmt
 }
 
-// Save insere um novo registro
-func (s *SQLStore[T]) Save(ctx context.Context, e *T) (*T, error) {
-	// Implementação genérica requer reflexão
-	v := reflect.ValueOf(e).Elem()
+func (e stmtExecutor) ExecContext(ctx context.Context, _ string, args ...any) (sql.Result, error) {
+	return e.stmt.ExecContext(ctx, args...)
+}
+
+func (e stmtExecutor) QueryRowContext(ctx context.Context, _ string, args ...any) *sql.Row {
+	return e.stmt.QueryRowContext(ctx, args...)
+}
+
+// returnsPK indica se o INSERT deve usar RETURNING para ler a chave gerada
+func (s *SQLStore[T]) returnsPK() bool {
+	return s.pkMode == PKModeServerDefault && s.supportsReturning()
+}
+
+// insertColumns retorna as colunas e valores usados no INSERT da entidade, ignorando
+// campos sem tag, colunas preenchidas pelo banco e a chave primária gerada
+func (s *SQLStore[T]) insertColumns(v reflect.Value) ([]string, []any) {
 	fields := make([]string, 0)
-	placeholders := make([]string, 0)
 	values := make([]any, 0)
 
-	for i := range v.NumField() {
-		field := v.Type().Field(i)
-		fieldName := field.Tag.Get("db")
-
-		// Ignorar campos com tag `db:"-"`
-		if fieldName == "-" {
+	for _, column := range columnFields(v.Type()) {
+		if column.generated {
 			continue
 		}
 
-		if fieldName == s.primaryKey && s.autoincrement {
+		if column.name == s.primaryKey && s.generatesPK() {
 			continue
 		}
 
-		fields = append(fields, fieldName)
-		placeholders = append(placeholders, "?")
-		values = append(values, v.Field(i).Interface())
+		fields = append(fields, column.name)
+		values = append(values, columnValue(v.FieldByIndex(column.index)))
+	}
+
+	return fields, values
+}
+
+// checkInsertColumns falha com ErrNoMappedColumns quando não há colunas para o INSERT,
+// em vez de enviar ao banco um "INSERT INTO t () VALUES ()" com erro de sintaxe
+func (s *SQLStore[T]) checkInsertColumns(fields []string) error {
+	if len(fields) > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: adicione tags `db` aos campos exportados de %s (ex.: db:\"name\")", ErrNoMappedColumns, reflect.TypeFor[T]())
+}
+
+// insertQuery monta o INSERT na tabela para as colunas informadas
+func (s *SQLStore[T]) insertQuery(table string, fields []string) string {
+	placeholders := make([]string, len(fields))
+	for i := range fields {
+		placeholders[i] = "?"
 	}
 
 	query := fmt.Sprintf(
 		"INSERT INTO %s (%s) VALUES (%s)",
-		s.tableName,
+		table,
 		strings.Join(fields, ", "),
 		strings.Join(placeholders, ", "),
 	)
 
-	result, err := s.db.ExecContext(ctx, query, values...)
+	if s.returnsPK() {
+		query += " RETURNING " + s.primaryKey
+	}
+
+	return query
+}
+
+// insertRow executa o INSERT e lê a chave gerada conforme o PKMode, atribuindo-a ao
+// campo da chave primária da entidade. Retorna a chave gerada ou nil quando não há
+func (s *SQLStore[T]) insertRow(ctx context.Context, exec sqlExecutor, query string, values []any, v reflect.Value) (any, error) {
+	if s.pkMode == PKModeServerDefault {
+		if !s.returnsPK() {
+			_, err := exec.ExecContext(ctx, query, values...)
+			return nil, err
+		}
+
+		var generated any
+		if err := exec.QueryRowContext(ctx, query, values...).Scan(&generated); err != nil {
+			return nil, err
+		}
+
+		if idField, ok := fieldByColumn(v, s.primaryKey); ok {
+			if err := setIDValue(idField, generated); err != nil {
+				return nil, fmt.Errorf("erro ao converter coluna %s: %w", s.primaryKey, err)
+			}
+		}
+
+		return generated, nil
+	}
+
+	result, err := exec.ExecContext(ctx, query, values...)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.pkMode != PKModeAutoIncrement {
+		return nil, nil
+	}
+
 	// Definir ID gerado se suportado (Oracle não suporta LastInsertId)
-	if lastID, err := result.LastInsertId(); err == nil && lastID > 0 {
-		// Atualizar o campo ID usando reflexão
-		idField := v.FieldByName("ID")
-		if idField.IsValid() && idField.CanSet() {
-			idField.SetInt(lastID)
+	lastID, err := result.LastInsertId()
+	if err != nil || lastID <= 0 {
+		return nil, nil
+	}
+
+	// Atualizar o campo da chave primária usando reflexão
+	if idField, ok := fieldByColumn(v, s.primaryKey); ok {
+		if err := setIDValue(idField, lastID); err != nil {
+			return nil, fmt.Errorf("erro ao converter coluna %s: %w", s.primaryKey, err)
 		}
 	}
 
-	return e, nil
+	return lastID, nil
 }
 
-// SaveMany insere múltiplos registros
-func (s *SQLStore[T]) SaveMany(ctx context.Context, entities []T) (*InsertManyResult, error) {
-	if len(entities) == 0 {
-		return nil, nil
+// table retorna o nome da tabela, substituindo o TenantPlaceholder pelo tenant do contexto
+func (s *SQLStore[T]) table(ctx context.Context) (string, error) {
+	return s.opts.resolveTenantName(ctx, s.tableName)
+}
+
+// resolvedName retorna a tabela do contexto, usada pelo NewCachedStore para separar os tenants
+func (s *SQLStore[T]) resolvedName(ctx context.Context) (string, error) {
+	return s.table(ctx)
+}
+
+// entityID retorna o valor do campo mapeado para a chave primária e se ele está preenchido
+func (s *SQLStore[T]) entityID(v reflect.Value) (any, bool) {
+	field, ok := fieldByColumn(v, s.primaryKey)
+	if !ok || field.IsZero() {
+		return nil, false
+	}
+
+	return field.Interface(), true
+}
+
+// hasColumn verifica se a entidade possui a coluna mapeada pela tag `db`
+func (s *SQLStore[T]) hasColumn(name string) bool {
+	_, ok := s.columns[name]
+	return ok
+}
+
+// validateColumns garante que os campos usados para compor a query (projeção, busca) são
+// colunas mapeadas na entidade, falhando antes de executar a consulta
+func (s *SQLStore[T]) validateColumns(usage string, names []string) error {
+	for _, name := range names {
+		if !s.hasColumn(name) {
+			return fmt.Errorf("%w na %s: %s", ErrUnknownColumn, usage, name)
+		}
+	}
+
+	return nil
+}
+
+// WithTransaction para SQL usa uma simples transação
+func (s *SQLStore[T]) WithTransaction(ctx context.Context, fn Transaction) (_ any, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	// Com WithTx a função participa da transação externa
+	if s.tx != nil {
+		return fn(s.tx)
 	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -257,136 +427,1287 @@ func (s *SQLStore[T]) SaveMany(ctx context.Context, entities []T) (*InsertManyRe
 		}
 	}()
 
-	ids := make([]any, len(entities))
+	result, err := fn(tx)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, fmt.Errorf("transaction error: %w, rollback error: %v", err, rollbackErr)
+		}
+		return nil, fmt.Errorf("transaction error: %w", err)
+	}
 
-	for i, entity := range entities {
-		v := reflect.ValueOf(&entity).Elem()
-		fields := make([]string, 0)
-		placeholders := make([]string, 0)
-		values := make([]any, 0)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao fazer commit: %w", err)
+	}
 
-		for j := range v.NumField() {
-			field := v.Type().Field(j)
-			fieldName := field.Tag.Get("db")
+	return result, nil
+}
 
-			if fieldName == "-" {
-				continue
-			}
+func (s *SQLStore[T]) Has(ctx context.Context, id any) bool {
+	table, err := s.table(ctx)
+	if err != nil {
+		return false
+	}
 
-			if fieldName == s.primaryKey && s.autoincrement {
-				continue
-			}
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s = ?)", table, s.primaryKey)
 
-			fields = append(fields, fieldName)
-			placeholders = append(placeholders, "?")
-			values = append(values, v.Field(j).Interface())
-		}
+	var exists bool
+	err = s.readConn().QueryRowContext(ctx, query, id).Scan(&exists)
 
-		query := fmt.Sprintf(
-			"INSERT INTO %s (%s) VALUES (%s)",
-			s.tableName,
-			strings.Join(fields, ", "),
-			strings.Join(placeholders, ", "),
-		)
+	return err == nil && exists
+}
 
-		// Usa tx.ExecContext em vez de s.db.ExecContext
-		result, err := tx.ExecContext(ctx, query, values...)
-		if err != nil {
-			tx.Rollback()
+// HasWhere verifica se existe algum registro que corresponde ao filtro, retornando o erro
+// da consulta em vez de reportá-lo como inexistente
+func (s *SQLStore[T]) HasWhere(ctx context.Context, f map[string]any) (_ bool, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return false, err
+	}
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s%s)", table, whereClause)
+
+	var exists bool
+	if err := s.readConn().QueryRowContext(ctx, query, values...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("erro ao verificar registros: %w", err)
+	}
+
+	return exists, nil
+}
+
+// HasAll verifica em uma única consulta quais IDs existem. O mapa retornado contém todos
+// os IDs informados, com true para os existentes
+func (s *SQLStore[T]) HasAll(ctx context.Context, ids []any) (_ map[any]bool, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[any]bool, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	whereClause, values, err := s.buildWhereClause(map[string]any{s.primaryKey + "__in": ids})
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s%s", s.primaryKey, table, whereClause)
+
+	rows, err := s.readConn().QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar registros: %w", err)
+	}
+	defer rows.Close()
+
+	// Os drivers podem retornar o ID em outro tipo (ex.: int64 para int), então a comparação é textual
+	found := make(map[string]bool)
+	for rows.Next() {
+		var id any
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-
-		if lastID, err := result.LastInsertId(); err == nil {
-			ids[i] = lastID
-			idField := v.FieldByName("ID")
-			if idField.IsValid() && idField.CanSet() {
-				idField.SetInt(lastID)
-			}
+		if b, ok := id.([]byte); ok {
+			id = string(b)
 		}
+		found[fmt.Sprint(id)] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
+	for _, id := range ids {
+		result[id] = found[fmt.Sprint(id)]
+	}
+
+	return result, nil
+}
+
+// MissingIds retorna os ids informados que não existem, em uma única consulta
+func (s *SQLStore[T]) MissingIds(ctx context.Context, ids []any) (_ []any, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return missingIds[T](ctx, s, ids)
+}
+
+// Count retorna o número de registros baseado em uma consulta
+func (s *SQLStore[T]) Count(ctx context.Context, q map[string]any) (_ *int64, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dentro de uma transação o total pode incluir escritas ainda não confirmadas
+	counts := s.counts
+	if s.tx != nil {
+		counts = nil
+	}
+
+	key := countCacheKey(table, q)
+	if count, ok := counts.get(key); ok {
+		return &count, nil
+	}
+
+	whereClause, values, err := s.buildWhereClause(q)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", table)
+	query += whereClause
+
+	var count int64
+	err = s.readConn().QueryRowContext(ctx, query, values...).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+
+	counts.set(key, count)
+
+	return &count, nil
+}
+
+// InvalidateCount descarta os totais memorizados pelo WithCountCache
+func (s *SQLStore[T]) InvalidateCount() {
+	s.counts.invalidate()
+}
+
+// FindById busca um registro por ID
+func (s *SQLStore[T]) FindById(ctx context.Context, id any) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	var result T
+	if err := s.FindByIdInto(ctx, id, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FindByIdInto busca um registro por ID preenchendo dst, que é zerado antes do mapeamento
+// (e mantido quando o registro não existe). Permite reutilizar a mesma entidade em buscas
+// repetidas, como o Scan do database/sql
+func (s *SQLStore[T]) FindByIdInto(ctx context.Context, id any, dst *T) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", table, s.primaryKey)
+
+	stmt, err := prepare(ctx, s.readConn(), query)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar query: %v", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar registro: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return fmt.Errorf("nenhum registro encontrado com id %v: %w", id, ErrNotFound)
+	}
+
+	columns, values, err := s.scanRow(rows)
+	if err != nil {
+		return err
+	}
+
+	var zero T
+	*dst = zero
+
+	return s.mapRowInto(dst, columns, values)
+}
+
+// FindAllWithCount recupera os registros paginados e o total do filtro em uma única
+// consulta, usando COUNT(*) OVER()
+func (s *SQLStore[T]) FindAllWithCount(ctx context.Context, f map[string]any, opts FindOptions) (_ []T, _ int64, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return findAllWithCount[T](ctx, s, f, opts)
+}
+
+// FindPage retorna a página informada com os metadados de paginação
+func (s *SQLStore[T]) FindPage(ctx context.Context, f map[string]any, p, limit int64) (_ *page.Page[T], err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return findPage[T](ctx, s, f, p, limit)
+}
+
+func (s *SQLStore[T]) FindOne(ctx context.Context, f map[string]interface{}, opts ...FindOptions) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sortOpts FindOptions
+	if len(opts) > 0 {
+		sortOpts = opts[0]
+	}
+	if err := s.validateSortField(&sortOpts); err != nil {
+		return nil, err
+	}
+
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT * FROM %s", table)
+	query += whereClause + s.orderBy(sortOpts)
+
+	// Oracle não suporta LIMIT, usa FETCH FIRST
+	if s.driver == enum.DatabaseDriverOracle {
+		query += " FETCH FIRST 1 ROWS ONLY"
+	} else {
+		query += " LIMIT 1"
+	}
+
+	stmt, err := prepare(ctx, s.readConn(), query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar query: %v", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, values...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar documento: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		result, err := s.parseRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao decodificar documento: %w", err)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("documento não encontrado com filtro %v: %w", f, ErrNotFound)
+}
+
+// totalCountColumn é a coluna calculada com COUNT(*) OVER() quando FindOptions.IncludeTotalCount é usado.
+// Não começa com "_", que o Oracle rejeita em identificadores sem aspas (ORA-00911)
+const totalCountColumn = "total_count__"
+
+// FindAll busca registros com paginação
+func (s *SQLStore[T]) FindAll(ctx context.Context, f map[string]any, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.opts.applyFindDefaults(&opts)
+	if err := s.opts.checkLimit(opts.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.validateSortField(&opts); err != nil {
+		return nil, err
+	}
+
+	opts.Initialize()
+
+	if opts.IncludeTotalCount && opts.TotalCount == nil {
+		return nil, fmt.Errorf("TotalCount é obrigatório quando IncludeTotalCount é informado")
+	}
+
+	columns, err := s.selectColumns(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", columns, table)
+	if opts.IncludeTotalCount {
+		if columns == "*" {
+			columns = table + ".*"
+		}
+		query = fmt.Sprintf("SELECT %s, COUNT(*) OVER() AS %s FROM %s", columns, totalCountColumn, table)
+	}
+	query += whereClause + s.orderBy(opts)
+	query, values = s.paginate(query, values, opts)
+
+	stmt, err := prepare(ctx, s.readConn(), query)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar query: %v", err)
+	}
+	defer stmt.Close()
+
+	// Executa a query
+	rows, err := stmt.QueryContext(ctx, values...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	// Processa os resultados
+	var results []T
+	var total int64
+	for rows.Next() {
+		columns, rowValues, err := s.scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.IncludeTotalCount {
+			for i, column := range columns {
+				// O Oracle retorna os identificadores sem aspas em maiúsculas
+				if strings.EqualFold(column, totalCountColumn) {
+					total = toInt64(rowValues[i])
+				}
+			}
+		}
+
+		entity, err := s.mapRow(columns, rowValues)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, *entity)
+	}
+
+	if opts.IncludeTotalCount {
+		// Página além do último registro não retorna linhas, então o total precisa ser consultado
+		if len(results) == 0 && opts.Page > 1 {
+			count, err := s.Count(ctx, f)
+			if err != nil {
+				return nil, err
+			}
+			total = *count
+		}
+		*opts.TotalCount = total
+	}
+
+	return results, nil
+}
+
+// FindAllRaw busca registros como mapas coluna→valor, sem mapeamento para T, útil para views
+// e relatórios dinâmicos cujas colunas não correspondem a nenhuma struct. Os valores são
+// retornados como lidos pelo driver (ex.: []byte para texto no MySQL). Filtros e paginação
+// seguem o FindAll; ordenação e projeção aceitam apenas as colunas mapeadas em T, e os filtros,
+// quando T não mapeia colunas, qualquer identificador simples
+func (s *SQLStore[T]) FindAllRaw(ctx context.Context, f map[string]any, opts FindOptions) (_ []map[string]any, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.opts.applyFindDefaults(&opts)
+	if err := s.opts.checkLimit(opts.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.validateSortField(&opts); err != nil {
+		return nil, err
+	}
+
+	opts.Initialize()
+
+	columns, err := s.selectColumns(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", columns, table) + whereClause + s.orderBy(opts)
+	query, values = s.paginate(query, values, opts)
+
+	rows, err := s.readConn().QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var results []map[string]any
+	for rows.Next() {
+		columns, rowValues, err := s.scanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			row[column] = rowValues[i]
+		}
+		results = append(results, row)
+	}
+
+	return results, rows.Err()
+}
+
+// FindLast retorna os últimos n registros ordenados por sortField, em ordem crescente
+//
+//	store.FindLast(ctx, 10, nil, "created_at")
+//	// Gera: SELECT * FROM t ORDER BY created_at DESC LIMIT 10 (resultado invertido)
+func (s *SQLStore[T]) FindLast(ctx context.Context, n int64, f map[string]any, sortField string) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, fmt.Errorf("quantidade de registros deve ser maior que zero")
+	}
+
+	if err := s.opts.checkLimit(n); err != nil {
+		return nil, err
+	}
+
+	if sortField == "id" {
+		sortField = s.primaryKey
+	}
+
+	// O campo compõe a query, então apenas colunas mapeadas na entidade são aceitas
+	if !s.hasColumn(sortField) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSortField, sortField)
+	}
+
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("SELECT * FROM %s%s ORDER BY %s DESC", table, whereClause, sortField)
+
+	if s.driver == enum.DatabaseDriverOracle {
+		// Oracle usa binds posicionais: o limite vem depois dos valores do filtro
+		query += fmt.Sprintf(" FETCH FIRST :%d ROWS ONLY", len(values)+1)
+	} else {
+		query += " LIMIT ?"
+	}
+	values = append(values, n)
+
+	rows, err := s.readConn().QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var results []T
+	for rows.Next() {
+		entity, err := s.parseRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, *entity)
+	}
+
+	slices.Reverse(results)
+
+	return results, nil
+}
+
+// likeEscapeReplacer escapa os curingas do LIKE e o próprio caractere de escape
+var likeEscapeReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapa term para ser buscado literalmente em um LIKE com ESCAPE '\'
+func escapeLike(term string) string {
+	return likeEscapeReplacer.Replace(term)
+}
+
+// likeEscape retorna o literal do caractere de escape do LIKE. MySQL e MariaDB tratam a barra
+// invertida como escape dentro de strings, então ela precisa ser duplicada
+func (s *SQLStore[T]) likeEscape() string {
+	if s.driver == enum.DatabaseDriverMysql || s.driver == enum.DatabaseDriverMariaDB {
+		return `'\\'`
+	}
+
+	return `'\'`
+}
+
+// Search busca registros cujo texto contém term em qualquer um dos campos informados,
+// sem diferenciar maiúsculas e minúsculas. Com term vazio retorna todos os registros
+//
+// %, _ e \ em term são buscados literalmente, não como curingas do LIKE.
+//
+//	store.Search(ctx, "joão", []string{"name", "email"}, opts)
+//	// Gera: WHERE (UPPER(name) LIKE UPPER(?) ESCAPE '\' OR UPPER(email) LIKE UPPER(?) ESCAPE '\')
+func (s *SQLStore[T]) Search(ctx context.Context, term string, fields []string, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("campos de busca são obrigatórios")
+	}
+
+	// Os campos compõem a query, então apenas colunas mapeadas na entidade são aceitas
+	if err := s.validateColumns("busca", fields); err != nil {
+		return nil, err
+	}
+
+	if term == "" {
+		return s.FindAll(ctx, nil, opts)
+	}
+
+	s.opts.applyFindDefaults(&opts)
+	if err := s.opts.checkLimit(opts.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.validateSortField(&opts); err != nil {
+		return nil, err
+	}
+
+	opts.Initialize()
+
+	conditions := make([]string, 0, len(fields))
+	values := make([]any, 0, len(fields))
+	pattern := "%" + escapeLike(term) + "%"
+	for _, field := range fields {
+		conditions = append(conditions, fmt.Sprintf("UPPER(%s) LIKE UPPER(?) ESCAPE %s", field, s.likeEscape()))
+		values = append(values, pattern)
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE (%s)", table, strings.Join(conditions, " OR "))
+	query += s.orderBy(opts)
+	query, values = s.paginate(query, values, opts)
+
+	rows, err := s.readConn().QueryContext(ctx, query, values...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var results []T
+	for rows.Next() {
+		entity, err := s.parseRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, *entity)
+	}
+
+	return results, nil
+}
+
+// selectColumns monta a lista de colunas do SELECT conforme Fields e ExcludeFields.
+// Sem projeção retorna "*"
+func (s *SQLStore[T]) selectColumns(ctx context.Context, opts FindOptions) (string, error) {
+	if err := opts.validateProjection(); err != nil {
+		return "", err
+	}
+
+	if err := s.validateColumns("projeção", opts.Fields); err != nil {
+		return "", err
+	}
+	if err := s.validateColumns("projeção", opts.ExcludeFields); err != nil {
+		return "", err
+	}
+
+	if len(opts.Fields) > 0 {
+		return strings.Join(opts.Fields, ", "), nil
+	}
+
+	if len(opts.ExcludeFields) > 0 {
+		columns := make([]string, 0, len(s.columns))
+		for _, column := range slices.Sorted(maps.Keys(s.columns)) {
+			if !slices.Contains(opts.ExcludeFields, column) {
+				columns = append(columns, column)
+			}
+		}
+
+		if len(columns) == 0 {
+			return "", fmt.Errorf("ExcludeFields não pode excluir todas as colunas")
+		}
+
+		return strings.Join(columns, ", "), nil
+	}
+
+	return "*", nil
+}
+
+// validateSortField garante que o SortBy informado é uma coluna mapeada da entidade, pois o
+// campo compõe o ORDER BY. "id" é aceito como alias da chave primária e "createdAt" como
+// alias da coluna do CreatedAt
+func (s *SQLStore[T]) validateSortField(opts *FindOptions) error {
+	opts.SortBy = s.sortColumn(opts.SortBy)
+
+	if opts.SortBy != "" && !s.hasColumn(opts.SortBy) {
+		return fmt.Errorf("%w: %s", ErrInvalidSortField, opts.SortBy)
+	}
+
+	return nil
+}
+
+// datePartExpression retorna a expressão do driver que extrai a parte da data da coluna.
+// O dia da semana (dow) é normalizado para domingo = 0 em todos os bancos
+func (s *SQLStore[T]) datePartExpression(field, part string) string {
+	switch s.driver {
+	case enum.DatabaseDriverSqlite:
+		format := map[string]string{"year": "%Y", "month": "%m", "day": "%d", "dow": "%w"}[part]
+		return fmt.Sprintf("CAST(strftime('%s', %s) AS INTEGER)", format, field)
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		if part == "dow" {
+			return fmt.Sprintf("(DAYOFWEEK(%s) - 1)", field)
+		}
+		return fmt.Sprintf("%s(%s)", strings.ToUpper(part), field)
+	case enum.DatabaseDriverOracle:
+		if part == "dow" {
+			// TRUNC(..., 'IW') é a segunda-feira da semana, independente do NLS_TERRITORY
+			return fmt.Sprintf("MOD(TRUNC(%[1]s) - TRUNC(%[1]s, 'IW') + 1, 7)", field)
+		}
+		return fmt.Sprintf("EXTRACT(%s FROM %s)", strings.ToUpper(part), field)
+	default:
+		return fmt.Sprintf("EXTRACT(%s FROM %s)", strings.ToUpper(part), field)
+	}
+}
+
+// jsonPathSegment restringe as chaves dos caminhos JSON, que compõem a query como literais
+var jsonPathSegment = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// jsonPathExpression retorna a expressão do driver que extrai como texto o valor do caminho
+// (chaves separadas por ponto) da coluna JSON. Retorna false para caminhos vazios ou com
+// caracteres fora de [A-Za-z0-9_-]
+func (s *SQLStore[T]) jsonPathExpression(column, path string) (string, bool) {
+	if column == "" || path == "" {
+		return "", false
+	}
+
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if !jsonPathSegment.MatchString(key) {
+			return "", false
+		}
+	}
+
+	if s.driver == enum.DatabaseDriverPostgres {
+		if len(keys) == 1 {
+			return fmt.Sprintf("%s->>'%s'", column, keys[0]), true
+		}
+		return fmt.Sprintf("%s#>>'{%s}'", column, strings.Join(keys, ",")), true
+	}
+
+	// Chaves com hífen precisam de aspas no caminho SQL/JSON ($."status-code")
+	jsonPath := "$"
+	for _, key := range keys {
+		if strings.Contains(key, "-") {
+			key = `"` + key + `"`
+		}
+		jsonPath += "." + key
+	}
+
+	switch s.driver {
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, '%s'))", column, jsonPath), true
+	case enum.DatabaseDriverOracle:
+		return fmt.Sprintf("JSON_VALUE(%s, '%s')", column, jsonPath), true
+	default:
+		return fmt.Sprintf("json_extract(%s, '%s')", column, jsonPath), true
+	}
+}
+
+// sortColumn resolve o campo de ordenação para a coluna: "id" vira a chave primária e o padrão
+// "createdAt" de Initialize vira a coluna do CreatedAt de T quando a entidade não tem essa coluna
+func (s *SQLStore[T]) sortColumn(field string) string {
+	switch {
+	case field == "id":
+		return s.primaryKey
+	case field == defaultSortField && !s.hasColumn(field):
+		return s.timestamps.created
+	}
+
+	return field
+}
+
+// orderBy monta o ORDER BY de SortBy com a chave primária como critério de desempate,
+// garantindo uma ordem total e estável entre páginas. O padrão "createdAt" de Initialize é
+// resolvido para a coluna do CreatedAt; SortBy que não corresponde a uma coluna mapeada é
+// ignorado, pois o campo compõe a query
+func (s *SQLStore[T]) orderBy(opts FindOptions) string {
+	opts.SortBy = s.sortColumn(opts.SortBy)
+	if !s.hasColumn(opts.SortBy) {
+		return ""
+	}
+
+	direction := "ASC"
+	if opts.OrderBy == "DESC" {
+		direction = "DESC"
+	}
+
+	clause := fmt.Sprintf(" ORDER BY %s %s", opts.SortBy, direction)
+	if opts.SortBy != s.primaryKey {
+		clause += fmt.Sprintf(", %s %s", s.primaryKey, direction)
+	}
+
+	return clause
+}
+
+// paginate adiciona o LIMIT/OFFSET da página à query quando há limite
+func (s *SQLStore[T]) paginate(query string, values []any, opts FindOptions) (string, []any) {
+	if opts.Limit <= 0 {
+		return query, values
+	}
+
+	skip := page.Skip(opts.Page, opts.Limit)
+
+	if s.driver == enum.DatabaseDriverOracle {
+		query = fmt.Sprintf("%s OFFSET :1 ROWS FETCH FIRST :2 ROWS ONLY", query)
+		return query, append(values, skip, opts.Limit)
+	}
+
+	query = fmt.Sprintf("%s LIMIT ? OFFSET ?", query)
+	return query, append(values, opts.Limit, skip)
+}
+
+// FindAllJoined [NOT IMPLEMENTED] busca registros com joins de outras coleções
+func (s *SQLStore[T]) FindAllJoined(ctx context.Context, lookups []LookupSpec, f map[string]any, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return nil, fmt.Errorf("not implemented by SQL module")
+}
+
+// SearchText [NOT IMPLEMENTED] busca registros por texto usando índice de texto
+func (s *SQLStore[T]) SearchText(ctx context.Context, query string, opts FindOptions) (_ []T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return nil, fmt.Errorf("not implemented by SQL module")
+}
+
+// Save insere um novo registro. Como no SaveMany e no SaveIgnoreDuplicates, CreatedAt é preenchido
+// quando está zerado e UpdatedAt recebe o instante da inserção (veja WithManagedTimestamps)
+func (s *SQLStore[T]) Save(ctx context.Context, e *T) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Implementação genérica requer reflexão
+	v := reflect.ValueOf(e).Elem()
+	s.opts.setSaveTimestamps(v, time.Now())
+	fields, values := s.insertColumns(v)
+	if err := s.checkInsertColumns(fields); err != nil {
+		return nil, err
+	}
+	query := s.insertQuery(table, fields)
+
+	generatedID, err := s.insertRow(ctx, s.conn(), query, values, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.opts.reloadAfterSave {
+		id := generatedID
+		if pkField, ok := fieldByColumn(v, s.primaryKey); ok && !pkField.IsZero() {
+			id = pkField.Interface()
+		}
+
+		reloaded, err := s.primary().FindById(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao recarregar registro: %w", err)
+		}
+		*e = *reloaded
+	}
+
+	return e, nil
+}
+
+// SaveMany insere múltiplos registros em uma única transação. Com WithSaveCheckpoint a
+// transação é confirmada a cada lote, e uma falha descarta apenas o lote corrente
+func (s *SQLStore[T]) SaveMany(ctx context.Context, entities []T) (_ *InsertManyResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	// Um único instante para o lote inteiro, para que as linhas tenham timestamps consistentes
+	now := time.Now()
+
+	if s.opts.saveCheckpoint > 0 && s.tx == nil {
+		return s.saveManyCheckpointed(ctx, entities, now)
+	}
+
+	ids, _, err := s.saveChunk(ctx, entities, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InsertManyResult{InsertedIDs: ids}, nil
+}
+
+// saveManyCheckpointed insere os registros em transações de até saveCheckpoint linhas. Em caso
+// de falha, o resultado traz os registros já confirmados e o índice da linha que interrompeu o lote
+func (s *SQLStore[T]) saveManyCheckpointed(ctx context.Context, entities []T, now time.Time) (*InsertManyResult, error) {
+	result := &InsertManyResult{InsertedIDs: make([]any, 0, len(entities))}
+
+	offset := 0
+	for chunk := range slices.Chunk(entities, s.opts.saveCheckpoint) {
+		ids, failed, err := s.saveChunk(ctx, chunk, now)
+		if err != nil {
+			result.StoppedAt = offset + failed
+			return result, fmt.Errorf("erro ao salvar registro %d (%d registros confirmados): %w", result.StoppedAt, result.CommittedCount, err)
+		}
+
+		result.InsertedIDs = append(result.InsertedIDs, ids...)
+		result.CommittedCount += int64(len(chunk))
+		offset += len(chunk)
+	}
+
+	result.StoppedAt = len(entities)
+
+	return result, nil
+}
+
+// saveChunk insere os registros em uma transação e retorna os ids gerados. Em caso de falha a
+// transação é desfeita e o índice retornado aponta o registro que falhou
+func (s *SQLStore[T]) saveChunk(ctx context.Context, entities []T, now time.Time) ([]any, int, error) {
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// As colunas dependem apenas das tags de T, então o INSERT é preparado uma única vez
+	fields, _ := s.insertColumns(reflect.ValueOf(&entities[0]).Elem())
+	if err := s.checkInsertColumns(fields); err != nil {
+		return nil, 0, err
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	stmt, err := prepare(ctx, tx, s.insertQuery(table, fields))
+	if err != nil {
+		tx.Rollback()
+		return nil, 0, fmt.Errorf("erro ao preparar query: %v", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]any, len(entities))
+
+	for i, entity := range entities {
+		v := reflect.ValueOf(&entity).Elem()
+		s.opts.setSaveTimestamps(v, now)
+		_, values := s.insertColumns(v)
+
+		generatedID, err := s.insertRow(ctx, stmtExecutor{stmt: stmt}, "", values, v)
+		if err != nil {
+			tx.Rollback()
+			return nil, i, err
+		}
+
+		ids[i] = generatedID
+		if generatedID == nil {
+			if pkField, ok := fieldByColumn(v, s.primaryKey); ok {
+				ids[i] = pkField.Interface()
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+
+	return ids, 0, nil
+}
+
+// SaveIgnoreDuplicates insere os registros ignorando os que violam uma chave única ou primária
+// (INSERT IGNORE no MySQL/MariaDB e ON CONFLICT DO NOTHING no Postgres/SQLite). Ao contrário
+// do SaveMany, os duplicados não desfazem o lote; retorna apenas as chaves dos registros inseridos
+func (s *SQLStore[T]) SaveIgnoreDuplicates(ctx context.Context, entities []T) (_ *InsertManyResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	fields, _ := s.insertColumns(reflect.ValueOf(&entities[0]).Elem())
+	if err := s.checkInsertColumns(fields); err != nil {
+		return nil, err
+	}
+	query, err := s.insertIgnoreQuery(table, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	stmt, err := prepare(ctx, tx, query)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("erro ao preparar query: %v", err)
+	}
+	defer stmt.Close()
+
+	ids := make([]any, 0, len(entities))
+	now := time.Now()
+
+	for _, entity := range entities {
+		v := reflect.ValueOf(&entity).Elem()
+		s.opts.setSaveTimestamps(v, now)
+		_, values := s.insertColumns(v)
+
+		id, inserted, err := s.insertIgnoreRow(ctx, stmt, values, v)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		if inserted {
+			ids = append(ids, id)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &InsertManyResult{InsertedIDs: ids}, nil
+}
+
+// insertIgnoreQuery monta o INSERT que ignora conflitos de chave conforme o driver
+func (s *SQLStore[T]) insertIgnoreQuery(table string, fields []string) (string, error) {
+	query := s.insertQuery(table, fields)
+
+	switch s.driver {
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		return strings.Replace(query, "INSERT INTO", "INSERT IGNORE INTO", 1), nil
+	case enum.DatabaseDriverPostgres, enum.DatabaseDriverSqlite:
+		// O ON CONFLICT precede o RETURNING
+		insert, returning, found := strings.Cut(query, " RETURNING ")
+		query = insert + " ON CONFLICT DO NOTHING"
+		if found {
+			query += " RETURNING " + returning
+		}
+		return query, nil
+	default:
+		return "", fmt.Errorf("SaveIgnoreDuplicates não suportado para o driver %s", s.driver)
+	}
+}
+
+// insertIgnoreRow executa o INSERT de um registro e informa se ele foi inserido ou ignorado,
+// retornando a chave gerada ou informada dos registros inseridos
+func (s *SQLStore[T]) insertIgnoreRow(ctx context.Context, stmt sqlStmt, values []any, v reflect.Value) (any, bool, error) {
+	pkField, hasPK := fieldByColumn(v, s.primaryKey)
+
+	if s.returnsPK() {
+		var generated any
+		err := stmt.QueryRowContext(ctx, values...).Scan(&generated)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		if hasPK {
+			if err := setIDValue(pkField, generated); err != nil {
+				return nil, false, fmt.Errorf("erro ao converter coluna %s: %w", s.primaryKey, err)
+			}
+		}
+
+		return generated, true, nil
+	}
+
+	result, err := stmt.ExecContext(ctx, values...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+	if affected == 0 {
+		return nil, false, nil
+	}
+
+	if s.pkMode == PKModeAutoIncrement {
+		if lastID, err := result.LastInsertId(); err == nil && lastID > 0 {
+			return lastID, true, nil
+		}
+	}
+
+	if hasPK {
+		return pkField.Interface(), true, nil
+	}
+
+	return nil, true, nil
+}
+
+// SaveManyDetailed insere múltiplos registros como SaveMany e retorna um InsertOneResult por
+// registro, na ordem de entrada, com a chave gerada (LastInsertId) ou informada
+func (s *SQLStore[T]) SaveManyDetailed(ctx context.Context, entities []T) (_ []InsertOneResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	result, err := s.SaveMany(ctx, entities)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.detailed(), nil
+}
+
+// SaveManyNotOrdered [NOT IMPLEMENTED] salva vários registros de forma desordenada
+func (s *SQLStore[T]) SaveManyNotOrdered(ctx context.Context, e []T) (_ *InsertManyResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return nil, fmt.Errorf("not implemented by SQL module")
+}
+
+// Update atualiza um registro existente substituindo todas as colunas,
+// inclusive as que possuem o valor zero do Go. Para atualizações parciais use UpdateFields.
+func (s *SQLStore[T]) Update(ctx context.Context, e *T) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return s.update(ctx, e, nil)
+}
+
+// UpdateWhere atualiza o registro como Update, mas apenas se ele também atender ao filtro
+// extra (compare-and-set). Retorna ErrNotFound quando o registro não existe e ErrConflict
+// quando ele existe, mas não atende ao filtro
+//
+//	store.UpdateWhere(ctx, order, map[string]any{"status": "pendente"})
+//	// Gera: UPDATE orders SET ... WHERE id = ? AND status = ?
+func (s *SQLStore[T]) UpdateWhere(ctx context.Context, e *T, extra map[string]any) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return s.update(ctx, e, extra)
+}
+
+// update Função auxiliar do Update e UpdateWhere
+func (s *SQLStore[T]) update(ctx context.Context, e *T, extra map[string]any) (*T, error) {
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(e).Elem()
+
+	// Verifica se existe campo updated_at gerenciado pelo store
+	hasUpdatedAt := v.FieldByName("UpdatedAt").IsValid() && s.opts.managesUpdatedAt()
+
+	// Preparar campos para atualização
+	updates := make([]string, 0)
+	values := make([]any, 0)
+	var id any
+
+	for _, column := range columnFields(v.Type()) {
+		fieldName := column.name
+
+		if fieldName == s.primaryKey {
+			id = v.FieldByIndex(column.index).Interface()
+		} else if !column.generated {
+			updates = append(updates, fmt.Sprintf("%s = ?", fieldName))
+			values = append(values, columnValue(v.FieldByIndex(column.index)))
+		}
+	}
+
+	// Se updated_at existe mas não foi definido pelo cliente, adiciona automaticamente
+	if hasUpdatedAt {
+		updates = append(updates, fmt.Sprintf("%s = ?", s.timestamps.updated))
+		values = append(values, time.Now())
+
+		// Atualiza o valor no struct também
+		if field, ok := fieldByColumn(v, s.timestamps.updated); ok {
+			field.Set(reflect.ValueOf(time.Now()))
+		}
+	}
+
+	// Adicionar ID ao final dos valores
+	values = append(values, id)
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s = ?",
+		table,
+		strings.Join(updates, ", "),
+		s.primaryKey,
+	)
+
+	// Condições extras do UpdateWhere
+	whereClause, whereValues, err := s.buildWhereClause(extra)
+	if err != nil {
+		return nil, err
+	}
+	if whereClause != "" {
+		query += " AND " + strings.TrimPrefix(whereClause, " WHERE ")
+		values = append(values, whereValues...)
+	}
+
+	result, err := s.conn().ExecContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected, err := result.RowsAffected(); err == nil {
+		if rowsAffected == 0 {
+			if len(extra) > 0 && s.primary().Has(ctx, id) {
+				return nil, fmt.Errorf("registro com id %v: %w", id, ErrConflict)
+			}
+			return nil, fmt.Errorf("nenhum registro encontrado com id %v: %w", id, ErrNotFound)
+		}
+	}
+
+	return e, nil
+}
+
+// UpdateFields atualiza apenas as colunas informadas (tags `db`), preservando os demais valores do registro
+func (s *SQLStore[T]) UpdateFields(ctx context.Context, e *T, fields ...string) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	return &InsertManyResult{InsertedIDs: ids}, nil
-}
-
-// SaveManyNotOrdered [NOT IMPLEMENTED] salva vários registros de forma desordenada
-func (s *SQLStore[T]) SaveManyNotOrdered(ctx context.Context, e []T) (*InsertManyResult, error) {
-	return nil, fmt.Errorf("not implemented by SQL module")
-}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("nenhum campo informado para atualização")
+	}
 
-// Update atualiza um registro existente
-func (s *SQLStore[T]) Update(ctx context.Context, e *T) (*T, error) {
 	v := reflect.ValueOf(e).Elem()
 
-	// Verifica se existe campo updated_at
-	hasUpdatedAt := v.FieldByName("UpdatedAt").IsValid()
-
-	// Preparar campos para atualização
-	updates := make([]string, 0)
-	values := make([]any, 0)
 	var id any
-
-	for i := range v.NumField() {
-		field := v.Type().Field(i)
-		fieldName := field.Tag.Get("db")
+	columns := make(map[string]reflect.Value)
+	for _, column := range columnFields(v.Type()) {
+		fieldName := column.name
 
 		if fieldName == s.primaryKey {
-			id = v.Field(i).Interface()
-		} else if field.Tag.Get("db") != "-" {
-			updates = append(updates, fmt.Sprintf("%s = ?", fieldName))
-			values = append(values, v.Field(i).Interface())
+			id = v.FieldByIndex(column.index).Interface()
+			continue
+		}
+
+		if column.generated {
+			continue
 		}
+
+		columns[fieldName] = v.FieldByIndex(column.index)
 	}
 
-	// Se updated_at existe mas não foi definido pelo cliente, adiciona automaticamente
-	if hasUpdatedAt {
-		updates = append(updates, fmt.Sprintf("%s = ?", "updated_at"))
-		values = append(values, time.Now())
+	updates := make([]string, 0, len(fields)+1)
+	values := make([]any, 0, len(fields)+2)
 
-		// Atualiza o valor no struct também
-		for i := range v.NumField() {
-			field := v.Type().Field(i)
-			if field.Tag.Get("db") == "updated_at" {
-				v.Field(i).Set(reflect.ValueOf(time.Now()))
-				break
-			}
+	for _, fieldName := range fields {
+		field, ok := columns[fieldName]
+		if !ok {
+			return nil, fmt.Errorf("campo %s não encontrado na entidade", fieldName)
 		}
+
+		updates = append(updates, fmt.Sprintf("%s = ?", fieldName))
+		values = append(values, columnValue(field))
+	}
+
+	// Atualiza updated_at automaticamente quando não informado pelo cliente
+	if _, ok := columns[s.timestamps.updated]; ok && !slices.Contains(fields, s.timestamps.updated) && s.opts.managesUpdatedAt() {
+		updates = append(updates, s.timestamps.updated+" = ?")
+		values = append(values, time.Now())
 	}
 
-	// Adicionar ID ao final dos valores
 	values = append(values, id)
 
 	query := fmt.Sprintf(
 		"UPDATE %s SET %s WHERE %s = ?",
-		s.tableName,
+		table,
 		strings.Join(updates, ", "),
 		s.primaryKey,
 	)
 
-	result, err := s.db.ExecContext(ctx, query, values...)
+	result, err := s.conn().ExecContext(ctx, query, values...)
 	if err != nil {
 		return nil, err
 	}
 
 	if rowsAffected, err := result.RowsAffected(); err == nil {
 		if rowsAffected == 0 {
-			return nil, fmt.Errorf("registro não encontrado")
+			return nil, fmt.Errorf("nenhum registro encontrado com id %v: %w", id, ErrNotFound)
 		}
 	}
 
-	return e, nil
+	return s.primary().FindById(ctx, id)
+}
+
+// protectedColumn retorna a primeira coluna de fields que não pode ser alterada pelo
+// UpdateMany: a chave primária e a coluna do CreatedAt
+func (s *SQLStore[T]) protectedColumn(fields map[string]any) (string, bool) {
+	for _, column := range []string{s.primaryKey, s.timestamps.created} {
+		if _, ok := fields[column]; ok {
+			return column, true
+		}
+	}
+
+	return "", false
 }
 
-// UpdateMany atualiza atributos de múltiplos registros baseado em um filtro
-func (s *SQLStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate) (*BulkWriteResult, error) {
+// UpdateMany atualiza atributos de múltiplos registros baseado em um filtro. MatchedCount
+// conta os registros encontrados pelo filtro e ModifiedCount os alterados, como no Mongo
+func (s *SQLStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(fd) == 0 {
 		return nil, fmt.Errorf("nenhum update fornecido")
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
 	}
@@ -412,6 +1733,11 @@ func (s *SQLStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate)
 			return nil, fmt.Errorf("campos para atualização são obrigatórios para update %d", i)
 		}
 
+		if column, ok := s.protectedColumn(fb.Fields); ok {
+			tx.Rollback()
+			return nil, fmt.Errorf("update %d, coluna %s: %w", i, column, ErrProtectedColumn)
+		}
+
 		// Constrói SET clause
 		setClauses := make([]string, 0, len(fb.Fields)+1)
 		setValues := make([]any, 0, len(fb.Fields)+1)
@@ -424,25 +1750,46 @@ func (s *SQLStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate)
 		sort.Strings(fieldKeys)
 
 		for _, key := range fieldKeys {
+			// updated_at é gerenciado pelo store, o valor informado é ignorado
+			if key == s.timestamps.updated && s.hasColumn(key) && s.opts.managesUpdatedAt() {
+				continue
+			}
 			setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 			setValues = append(setValues, fb.Fields[key])
 		}
 
-		// Adiciona updated_at automaticamente
-		setClauses = append(setClauses, "updated_at = ?")
-		setValues = append(setValues, now)
+		// Adiciona updated_at automaticamente quando a entidade possui a coluna
+		if s.hasColumn(s.timestamps.updated) && s.opts.managesUpdatedAt() {
+			setClauses = append(setClauses, s.timestamps.updated+" = ?")
+			setValues = append(setValues, now)
+		}
 
 		// Constrói WHERE clause
-		whereClause, whereValues := s.buildWhereClause(fb.Filter)
+		whereClause, whereValues, err := s.buildWhereClause(fb.Filter)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("update %d: %w", i, err)
+		}
 
 		// Monta a query completa
 		query := fmt.Sprintf(
 			"UPDATE %s SET %s%s",
-			s.tableName,
+			table,
 			strings.Join(setClauses, ", "),
 			whereClause,
 		)
 
+		// MySQL e MariaDB contam em RowsAffected apenas as linhas alteradas, então as
+		// encontradas pelo filtro são contadas antes do UPDATE, na mesma transação
+		var matched int64
+		if s.countsChangedRowsOnly() {
+			countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s%s", table, whereClause)
+			if err := tx.QueryRowContext(ctx, countQuery, whereValues...).Scan(&matched); err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("erro ao contar registros do update %d: %w", i, err)
+			}
+		}
+
 		// Combina valores: SET values + WHERE values
 		allValues := append(setValues, whereValues...)
 
@@ -453,7 +1800,10 @@ func (s *SQLStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate)
 		}
 
 		rowsAffected, _ := result.RowsAffected()
-		totalMatched += rowsAffected
+		if !s.countsChangedRowsOnly() {
+			matched = rowsAffected
+		}
+		totalMatched += matched
 		totalModified += rowsAffected
 	}
 
@@ -467,12 +1817,42 @@ func (s *SQLStore[T]) UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate)
 	}, nil
 }
 
-// Upsert cria ou atualiza um registro
-func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (*UpdateResult, error) {
+// countsChangedRowsOnly indica se o RowsAffected do UPDATE conta apenas as linhas cujo valor
+// mudou (MySQL e MariaDB sem CLIENT_FOUND_ROWS), e não todas as encontradas pelo filtro
+func (s *SQLStore[T]) countsChangedRowsOnly() bool {
+	return s.driver == enum.DatabaseDriverMysql || s.driver == enum.DatabaseDriverMariaDB
+}
+
+// checkUpsertFilters rejeita os filtros com Or, que o ON CONFLICT/MERGE não consegue expressar
+func checkUpsertFilters(f []StoreUpsertFilter) error {
+	for _, filter := range f {
+		if filter.Or {
+			return fmt.Errorf("%w: StoreUpsertFilter.Or (%s) só é suportado no Mongo", ErrUnsupported, filter.UpsertFieldKey)
+		}
+	}
+
+	return nil
+}
+
+// Upsert cria ou atualiza um registro. Filtros com Or retornam ErrUnsupported
+func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (_ *UpdateResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if err := checkUpsertFilters(f); err != nil {
+		return nil, err
+	}
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	v := reflect.ValueOf(e).Elem()
 
-	// Verifica se existe campo updated_at
-	hasUpdatedAt := v.FieldByName("UpdatedAt").IsValid()
+	// Verifica se existe campo updated_at gerenciado pelo store
+	hasUpdatedAt := v.FieldByName("UpdatedAt").IsValid() && s.opts.managesUpdatedAt()
 
 	// Preparar campos
 	fields := make([]string, 0)
@@ -490,25 +1870,18 @@ func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (
 	}
 
 	// Construir lista de campos de conflito (upsert) a partir dos filtros
-	conflictFields := make([]string, 0, len(f))
-	conflictFieldsMap := make(map[string]bool)
-	for _, filter := range f {
-		fieldKey := filter.UpsertFieldKey
-		if fieldKey == "" {
-			fieldKey = s.primaryKey
-		}
-		conflictFields = append(conflictFields, fieldKey)
-		conflictFieldsMap[fieldKey] = true
+	conflictFields := s.upsertConflictColumns(f)
+	conflictFieldsMap := make(map[string]bool, len(conflictFields))
+	for _, field := range conflictFields {
+		conflictFieldsMap[field] = true
 	}
 
-	for i := range v.NumField() {
-		field := v.Type().Field(i)
-		fieldName := field.Tag.Get("db")
-
-		if field.Tag.Get("db") != "-" {
+	for _, column := range columnFields(v.Type()) {
+		if !column.generated {
+			fieldName := column.name
 			fields = append(fields, fieldName)
 			placeholders = append(placeholders, "?")
-			values = append(values, v.Field(i).Interface())
+			values = append(values, columnValue(v.FieldByIndex(column.index)))
 
 			// Campos para atualização (exceto os campos de conflito)
 			if !conflictFieldsMap[fieldName] {
@@ -518,7 +1891,7 @@ func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (
 	}
 
 	if hasUpdatedAt {
-		updates = append(updates, fmt.Sprintf("%s = ?", "updated_at"))
+		updates = append(updates, fmt.Sprintf("%s = ?", s.timestamps.updated))
 		values = append(values, time.Now())
 	}
 
@@ -528,7 +1901,7 @@ func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (
 	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
 		query = fmt.Sprintf(
 			"INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
-			s.tableName,
+			table,
 			strings.Join(fields, ", "),
 			strings.Join(placeholders, ", "),
 			strings.Join(updates, ", "),
@@ -536,7 +1909,7 @@ func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (
 	case enum.DatabaseDriverSqlite:
 		query = fmt.Sprintf(
 			"INSERT OR REPLACE INTO %s (%s) VALUES (%s)",
-			s.tableName,
+			table,
 			strings.Join(fields, ", "),
 			strings.Join(placeholders, ", "),
 		)
@@ -544,99 +1917,173 @@ func (s *SQLStore[T]) Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (
 		// PostgreSQL suporta múltiplos campos de conflito
 		query = fmt.Sprintf(
 			"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
-			s.tableName,
+			table,
 			strings.Join(fields, ", "),
 			strings.Join(placeholders, ", "),
 			strings.Join(conflictFields, ", "),
 			strings.Join(updates, ", "),
 		)
 	case enum.DatabaseDriverOracle:
-		// Oracle usa MERGE para upsert com múltiplos campos de conflito
-		// Construir condições ON para o MERGE
-		onConditions := make([]string, 0, len(conflictFields))
-		for _, field := range conflictFields {
-			onConditions = append(onConditions, fmt.Sprintf("t.%s = ?", field))
-		}
-
-		// Construir UPDATE SET (excluindo campos de conflito)
-		updateSets := make([]string, 0)
-		for _, field := range fields {
-			if !conflictFieldsMap[field] {
-				updateSets = append(updateSets, fmt.Sprintf("t.%s = ?", field))
-			}
+		var err error
+		query, values, err = s.oracleMergeQuery(table, v, fields, conflictFields, values, hasUpdatedAt)
+		if err != nil {
+			return nil, err
 		}
+	default:
+		return nil, fmt.Errorf("unsupported database driver to execute Upsert: %s", driverName.GetValue())
+	}
 
-		if hasUpdatedAt {
-			updateSets = append(updateSets, "t.updated_at = ?")
-		}
+	result, err := s.conn().ExecContext(ctx, query, values...)
+	if err != nil {
+		return nil, err
+	}
 
-		query = fmt.Sprintf(
-			"MERGE INTO %s t USING dual ON (%s) "+
-				"WHEN MATCHED THEN UPDATE SET %s "+
-				"WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
-			s.tableName,
-			strings.Join(onConditions, " AND "),
-			strings.Join(updateSets, ", "),
-			strings.Join(fields, ", "),
-			strings.Join(placeholders, ", "),
-		)
+	rowsAffected, _ := result.RowsAffected()
+	return &UpdateResult{UpsertedCount: rowsAffected}, nil
+}
 
-		// Para Oracle, precisamos duplicar os valores:
-		// 1. Para a cláusula ON (conflictFields)
-		// 2. Para o UPDATE SET (campos não-conflito)
-		// 3. Para o INSERT VALUES (todos os campos)
-		oracleValues := make([]any, 0)
-
-		// Valores para ON condition (conflictFields)
-		for _, field := range conflictFields {
-			for i := range v.NumField() {
-				if v.Type().Field(i).Tag.Get("db") == field {
-					oracleValues = append(oracleValues, v.Field(i).Interface())
-					break
-				}
-			}
+// UpsertReturning cria ou atualiza o registro como Upsert e retorna o registro gravado,
+// lido novamente pelas colunas de conflito (ou pela chave primária, sem filtros). Um registro
+// novo sem valor na chave primária gerada pelo banco é inserido como no Save, que atribui a
+// chave gerada à entidade antes da releitura
+func (s *SQLStore[T]) UpsertReturning(ctx context.Context, e *T, f []StoreUpsertFilter) (_ *T, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	v := reflect.ValueOf(e).Elem()
+	conflictColumns := s.upsertConflictColumns(f)
+	for _, column := range conflictColumns {
+		if _, ok := fieldByColumn(v, column); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, column)
 		}
+	}
 
-		// Valores para UPDATE SET (campos não-conflito)
-		for _, field := range fields {
-			if !conflictFieldsMap[field] {
-				for i := range v.NumField() {
-					if v.Type().Field(i).Tag.Get("db") == field {
-						oracleValues = append(oracleValues, v.Field(i).Interface())
-						break
-					}
-				}
+	if _, hasID := s.entityID(v); !hasID && s.generatesPK() && slices.Contains(conflictColumns, s.primaryKey) {
+		if _, err := s.Save(ctx, e); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.Upsert(ctx, e, f); err != nil {
+		return nil, err
+	}
+
+	// O filtro é montado após a gravação, já com a chave gerada
+	filter := make(map[string]any, len(conflictColumns))
+	for _, column := range conflictColumns {
+		field, _ := fieldByColumn(v, column)
+		filter[column] = columnValue(field)
+	}
+
+	return s.primary().FindOne(ctx, filter)
+}
+
+// oracleMergeQuery monta o upsert do Oracle como MERGE com binds posicionais (:1, :2...).
+// Cada valor é vinculado uma única vez na subconsulta de origem e referenciado por nome nas
+// cláusulas ON, UPDATE e INSERT, então os binds seguem a ordem de fields, seguidos das colunas
+// de conflito ausentes de fields (ex.: a chave gerada de um novo registro) e do updated_at.
+// values contém os valores de fields e, com hasUpdatedAt, o updated_at por último
+//
+//	MERGE INTO t USING (SELECT :1 AS email, :2 AS tenant, :3 AS name FROM dual) s
+//	ON (t.email = s.email AND t.tenant = s.tenant)
+//	WHEN MATCHED THEN UPDATE SET t.name = s.name, t.updated_at = :4
+//	WHEN NOT MATCHED THEN INSERT (email, tenant, name) VALUES (s.email, s.tenant, s.name)
+func (s *SQLStore[T]) oracleMergeQuery(table string, v reflect.Value, fields, conflictFields []string, values []any, hasUpdatedAt bool) (string, []any, error) {
+	binds := slices.Clone(values[:len(fields)])
+	source := make([]string, 0, len(fields)+len(conflictFields))
+	for i, field := range fields {
+		source = append(source, fmt.Sprintf(":%d AS %s", i+1, field))
+	}
+
+	onConditions := make([]string, 0, len(conflictFields))
+	for _, field := range conflictFields {
+		if !slices.Contains(fields, field) {
+			fieldValue, ok := fieldByColumn(v, field)
+			if !ok {
+				return "", nil, fmt.Errorf("%w: %s", ErrUnknownColumn, field)
 			}
+			binds = append(binds, columnValue(fieldValue))
+			source = append(source, fmt.Sprintf(":%d AS %s", len(binds), field))
 		}
+		onConditions = append(onConditions, fmt.Sprintf("t.%s = s.%s", field, field))
+	}
 
-		if hasUpdatedAt {
-			oracleValues = append(oracleValues, time.Now())
+	// Colunas do ON não podem ser atualizadas no Oracle (ORA-38104) e a chave primária do registro
+	// encontrado é mantida; o updated_at recebe o instante da operação em vez do valor da entidade
+	updateSets := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if slices.Contains(conflictFields, field) || field == s.primaryKey || (hasUpdatedAt && field == s.timestamps.updated) {
+			continue
 		}
+		updateSets = append(updateSets, fmt.Sprintf("t.%s = s.%s", field, field))
+	}
 
-		// Valores para INSERT (todos os campos)
-		oracleValues = append(oracleValues, values...)
+	if hasUpdatedAt {
+		binds = append(binds, values[len(fields)])
+		updateSets = append(updateSets, fmt.Sprintf("t.%s = :%d", s.timestamps.updated, len(binds)))
+	}
 
-		values = oracleValues
-	default:
-		return nil, fmt.Errorf("unsupported database driver to execute Upsert: %s", driverName.GetValue())
+	insertValues := make([]string, len(fields))
+	for i, field := range fields {
+		insertValues[i] = "s." + field
 	}
 
-	result, err := s.db.ExecContext(ctx, query, values...)
-	if err != nil {
-		return nil, err
+	query := fmt.Sprintf("MERGE INTO %s t USING (SELECT %s FROM dual) s ON (%s)",
+		table, strings.Join(source, ", "), strings.Join(onConditions, " AND "))
+	if len(updateSets) > 0 {
+		query += " WHEN MATCHED THEN UPDATE SET " + strings.Join(updateSets, ", ")
 	}
+	query += fmt.Sprintf(" WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		strings.Join(fields, ", "), strings.Join(insertValues, ", "))
 
-	rowsAffected, _ := result.RowsAffected()
-	return &UpdateResult{UpsertedCount: rowsAffected}, nil
+	return query, binds, nil
 }
 
-// UpsertMany cria ou atualiza múltiplos registros
-func (s *SQLStore[T]) UpsertMany(ctx context.Context, entities []T, f []StoreUpsertFilter) (*BulkWriteResult, error) {
-	if len(entities) == 0 {
+// upsertConflictColumns retorna as colunas que identificam o registro no upsert
+func (s *SQLStore[T]) upsertConflictColumns(f []StoreUpsertFilter) []string {
+	if len(f) == 0 {
+		return []string{s.primaryKey}
+	}
+
+	columns := make([]string, 0, len(f))
+	for _, filter := range f {
+		column := filter.UpsertFieldKey
+		if column == "" {
+			column = s.primaryKey
+		}
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// UpsertMany cria ou atualiza múltiplos registros. Filtros com Or retornam ErrUnsupported
+func (s *SQLStore[T]) UpsertMany(ctx context.Context, entities []T, f []StoreUpsertFilter) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	return s.UpsertManyWith(ctx, upsertItems(entities, f))
+}
+
+// UpsertManyWith executa o upsert de cada item com os seus próprios filtros de conflito, em
+// uma única transação. Itens sem filtros usam a chave primária; filtros com Or retornam ErrUnsupported
+func (s *SQLStore[T]) UpsertManyWith(ctx context.Context, items []UpsertItem[T]) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
 		return nil, nil
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	for _, item := range items {
+		if err := checkUpsertFilters(item.Filters); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -648,20 +2095,19 @@ func (s *SQLStore[T]) UpsertMany(ctx context.Context, entities []T, f []StoreUps
 		}
 	}()
 
-	for _, entity := range entities {
-		v := reflect.ValueOf(&entity).Elem()
+	result := &BulkWriteResult{
+		UpsertedCount: int64(len(items)),
+		UpsertedIDs:   make(map[int64]any),
+	}
+	ordered := s.opts.ordered(true)
+
+	for index, item := range items {
+		v := reflect.ValueOf(&item.Entity).Elem()
+		f := item.Filters
 
 		// Verifica se é um novo registro
-		idField := v.FieldByName("ID")
-		isNewRecord := false
-		if idField.IsValid() {
-			switch idField.Kind() {
-			case reflect.Int, reflect.Int64, reflect.Int32:
-				isNewRecord = idField.Int() == 0
-			case reflect.String:
-				isNewRecord = idField.String() == ""
-			}
-		}
+		_, hasID := s.entityID(v)
+		isNewRecord := !hasID
 
 		// Preparar campos
 		fields := make([]string, 0)
@@ -690,22 +2136,20 @@ func (s *SQLStore[T]) UpsertMany(ctx context.Context, entities []T, f []StoreUps
 			conflictFieldsMap[fieldKey] = true
 		}
 
-		for i := range v.NumField() {
-			field := v.Type().Field(i)
-			fieldName := field.Tag.Get("db")
-
-			if fieldName == "-" {
+		for _, column := range columnFields(v.Type()) {
+			if column.generated {
 				continue
 			}
+			fieldName := column.name
 
-			// Para novos registros com autoincrement, pula o campo ID
-			if isNewRecord && s.autoincrement && fieldName == s.primaryKey {
+			// Para novos registros com chave gerada pelo banco, pula o campo ID
+			if isNewRecord && s.generatesPK() && fieldName == s.primaryKey {
 				continue
 			}
 
 			fields = append(fields, fieldName)
 			placeholders = append(placeholders, "?")
-			values = append(values, v.Field(i).Interface())
+			values = append(values, columnValue(v.FieldByIndex(column.index)))
 
 			// Campos para atualização (exceto os campos de conflito)
 			if !conflictFieldsMap[fieldName] {
@@ -713,10 +2157,10 @@ func (s *SQLStore[T]) UpsertMany(ctx context.Context, entities []T, f []StoreUps
 			}
 		}
 
-		// Verifica se existe campo updated_at
-		hasUpdatedAt := v.FieldByName("UpdatedAt").IsValid()
+		// Verifica se existe campo updated_at gerenciado pelo store
+		hasUpdatedAt := v.FieldByName("UpdatedAt").IsValid() && s.opts.managesUpdatedAt()
 		if hasUpdatedAt {
-			updates = append(updates, fmt.Sprintf("%s = ?", "updated_at"))
+			updates = append(updates, fmt.Sprintf("%s = ?", s.timestamps.updated))
 			values = append(values, time.Now())
 		}
 
@@ -725,157 +2169,259 @@ func (s *SQLStore[T]) UpsertMany(ctx context.Context, entities []T, f []StoreUps
 		case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
 			query = fmt.Sprintf(
 				"INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
-				s.tableName,
+				table,
 				strings.Join(fields, ", "),
 				strings.Join(placeholders, ", "),
 				strings.Join(updates, ", "),
 			)
 		case enum.DatabaseDriverSqlite:
-			if isNewRecord && s.autoincrement {
+			if isNewRecord && s.generatesPK() {
 				// Para novos registros, usa INSERT simples
 				query = fmt.Sprintf(
 					"INSERT INTO %s (%s) VALUES (%s)",
-					s.tableName,
+					table,
 					strings.Join(fields, ", "),
 					strings.Join(placeholders, ", "),
 				)
 			} else {
 				query = fmt.Sprintf(
 					"INSERT OR REPLACE INTO %s (%s) VALUES (%s)",
-					s.tableName,
+					table,
 					strings.Join(fields, ", "),
 					strings.Join(placeholders, ", "),
 				)
 			}
 		case enum.DatabaseDriverPostgres:
-			// PostgreSQL suporta múltiplos campos de conflito
+			// PostgreSQL suporta múltiplos campos de conflito; xmax = 0 indica que a linha foi inserida
 			query = fmt.Sprintf(
-				"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
-				s.tableName,
+				"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s RETURNING %s, (xmax = 0)",
+				table,
 				strings.Join(fields, ", "),
 				strings.Join(placeholders, ", "),
 				strings.Join(conflictFields, ", "),
 				strings.Join(updates, ", "),
+				s.primaryKey,
 			)
 		case enum.DatabaseDriverOracle:
-			// Oracle usa MERGE para upsert com múltiplos campos de conflito
-			// Construir condições ON para o MERGE
-			onConditions := make([]string, 0, len(conflictFields))
-			for _, field := range conflictFields {
-				onConditions = append(onConditions, fmt.Sprintf("t.%s = ?", field))
+			query, values, err = s.oracleMergeQuery(table, v, fields, conflictFields, values, hasUpdatedAt)
+			if err != nil && !ordered {
+				result.UpsertedCount--
+				result.addError(int64(index), err)
+				continue
 			}
-
-			// Construir UPDATE SET (excluindo campos de conflito)
-			updateSets := make([]string, 0)
-			for _, field := range fields {
-				if !conflictFieldsMap[field] {
-					updateSets = append(updateSets, fmt.Sprintf("t.%s = ?", field))
-				}
+			if err != nil {
+				tx.Rollback()
+				return nil, err
 			}
+		default:
+			tx.Rollback()
+			return nil, fmt.Errorf("unsupported database driver to execute Upsert: %s", s.driver.GetValue())
+		}
 
-			if hasUpdatedAt {
-				updateSets = append(updateSets, "t.updated_at = ?")
-			}
+		if err := s.upsertItem(ctx, tx, ordered, result, index, func() error {
+			return s.execUpsertItem(ctx, tx, result, index, v, isNewRecord, query, values)
+		}); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
 
-			query = fmt.Sprintf(
-				"MERGE INTO %s t USING dual ON (%s) "+
-					"WHEN MATCHED THEN UPDATE SET %s "+
-					"WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
-				s.tableName,
-				strings.Join(onConditions, " AND "),
-				strings.Join(updateSets, ", "),
-				strings.Join(fields, ", "),
-				strings.Join(placeholders, ", "),
-			)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao fazer commit: %w", err)
+	}
 
-			// Para Oracle, precisamos duplicar os valores:
-			// 1. Para a cláusula ON (conflictFields)
-			// 2. Para o UPDATE SET (campos não-conflito)
-			// 3. Para o INSERT VALUES (todos os campos)
-			oracleValues := make([]any, 0)
-
-			// Valores para ON condition (conflictFields)
-			for _, field := range conflictFields {
-				for i := range v.NumField() {
-					if v.Type().Field(i).Tag.Get("db") == field {
-						oracleValues = append(oracleValues, v.Field(i).Interface())
-						break
-					}
-				}
-			}
+	return result, result.itemsError()
+}
 
-			// Valores para UPDATE SET (campos não-conflito)
-			for _, field := range fields {
-				if !conflictFieldsMap[field] {
-					for i := range v.NumField() {
-						if v.Type().Field(i).Tag.Get("db") == field {
-							oracleValues = append(oracleValues, v.Field(i).Interface())
-							break
-						}
-					}
-				}
-			}
+// upsertItem executa o upsert de um item do UpsertManyWith. No modo desordenado o item roda
+// sob um savepoint: uma falha desfaz apenas as escritas dele, é registrada em result.Errors e
+// o lote segue. Retorna erro quando o lote inteiro deve ser desfeito
+func (s *SQLStore[T]) upsertItem(ctx context.Context, tx sqlTx, ordered bool, result *BulkWriteResult, index int, exec func() error) error {
+	if ordered {
+		return exec()
+	}
 
-			if hasUpdatedAt {
-				oracleValues = append(oracleValues, time.Now())
-			}
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT upsert_item"); err != nil {
+		return fmt.Errorf("erro ao criar savepoint: %w", err)
+	}
 
-			// Valores para INSERT (todos os campos)
-			oracleValues = append(oracleValues, values...)
+	if err := exec(); err != nil {
+		// No Postgres a transação fica inutilizável após um erro até o ROLLBACK TO SAVEPOINT
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT upsert_item"); rollbackErr != nil {
+			return fmt.Errorf("erro ao desfazer upsert %d: %w", index, rollbackErr)
+		}
 
-			values = oracleValues
-		default:
-			tx.Rollback()
-			return nil, fmt.Errorf("unsupported database driver to execute Upsert: %s", s.driver.GetValue())
+		result.UpsertedCount--
+		result.addError(int64(index), err)
+		return nil
+	}
+
+	// O Oracle não tem RELEASE SAVEPOINT; o próximo SAVEPOINT de mesmo nome substitui o anterior
+	if s.driver != enum.DatabaseDriverOracle {
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT upsert_item"); err != nil {
+			return fmt.Errorf("erro ao liberar savepoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// execUpsertItem executa a query de upsert de um item e contabiliza o resultado por driver
+func (s *SQLStore[T]) execUpsertItem(ctx context.Context, tx sqlTx, result *BulkWriteResult, index int, v reflect.Value, isNewRecord bool, query string, values []any) error {
+	if s.driver == enum.DatabaseDriverPostgres {
+		var id any
+		var inserted bool
+		if err := tx.QueryRowContext(ctx, query, values...).Scan(&id, &inserted); err != nil {
+			return err
 		}
 
-		_, err := tx.ExecContext(ctx, query, values...)
+		result.addUpsertOutcome(index, inserted, !inserted, id)
+		return nil
+	}
+
+	execResult, err := tx.ExecContext(ctx, query, values...)
+	if err != nil {
+		return err
+	}
+
+	switch s.driver {
+	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB:
+		// ON DUPLICATE KEY UPDATE retorna 1 linha afetada para inserção e 2 para atualização
+		rowsAffected, err := execResult.RowsAffected()
 		if err != nil {
-			tx.Rollback()
-			return nil, err
+			return fmt.Errorf("erro ao obter linhas afetadas: %w", err)
+		}
+
+		var id any
+		if lastID, err := execResult.LastInsertId(); err == nil && lastID > 0 {
+			id = lastID
+		} else if pkField, ok := fieldByColumn(v, s.primaryKey); ok {
+			id = pkField.Interface()
+		}
+		result.addUpsertOutcome(index, rowsAffected == 1, rowsAffected == 2, id)
+	case enum.DatabaseDriverSqlite:
+		// Apenas o INSERT simples garante que a linha é nova; o INSERT OR REPLACE não distingue
+		if isNewRecord && s.generatesPK() {
+			var id any
+			if lastID, err := execResult.LastInsertId(); err == nil {
+				id = lastID
+			}
+			result.addUpsertOutcome(index, true, false, id)
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("erro ao fazer commit: %w", err)
+	return nil
+}
+
+// addUpsertOutcome contabiliza o resultado de uma linha do UpsertMany, registrando o id
+// das linhas inseridas pelo índice da entidade
+func (r *BulkWriteResult) addUpsertOutcome(index int, inserted bool, modified bool, id any) {
+	if inserted {
+		r.InsertedCount++
+		r.UpsertedIDs[int64(index)] = id
+		return
+	}
+
+	r.MatchedCount++
+	if modified {
+		r.ModifiedCount++
+	}
+}
+
+// Delete remove um registro pelo ID, retornando ErrNotFound quando ele não existe
+//
+// Para ignorar registros inexistentes use DeleteIfExists.
+func (s *SQLStore[T]) Delete(ctx context.Context, id any) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	deleted, err := s.DeleteIfExists(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return fmt.Errorf("nenhum registro encontrado com id %v: %w", id, ErrNotFound)
+	}
+
+	return nil
+}
+
+// DeleteIfExists remove um registro pelo ID e informa se ele existia
+func (s *SQLStore[T]) DeleteIfExists(ctx context.Context, id any) (_ bool, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	result, err := s.DeleteReturning(ctx, id)
+	if err != nil {
+		return false, err
 	}
 
-	return &BulkWriteResult{UpsertedCount: int64(len(entities))}, nil
+	return result.DeletedCount > 0, nil
 }
 
-// Delete remove um registro pelo ID
-func (s *SQLStore[T]) Delete(ctx context.Context, id any) error {
-	query := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", s.tableName, s.primaryKey)
-	_, err := s.db.ExecContext(ctx, query, id)
-	return err
+// DeleteReturning remove um registro pelo ID e retorna a quantidade removida, distinguindo
+// a exclusão (1) do registro inexistente (0) sem uma consulta extra
+func (s *SQLStore[T]) DeleteReturning(ctx context.Context, id any) (_ *DeleteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table, s.primaryKey)
+	result, err := s.conn().ExecContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao deletar registro: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao verificar registros deletados: %w", err)
+	}
+
+	return &DeleteResult{DeletedCount: rowsAffected}, nil
 }
 
-// DeleteOne remove um registro baseado em um filtro
-func (s *SQLStore[T]) DeleteOne(ctx context.Context, f map[string]interface{}) error {
+// DeleteOne remove um registro baseado em um filtro
+func (s *SQLStore[T]) DeleteOne(ctx context.Context, f map[string]interface{}) (err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return err
+	}
+
 	if f == nil || len(f) == 0 {
 		return fmt.Errorf("filtro não pode ser nulo ou vazio")
 	}
 
-	whereClause, values := s.buildWhereClause(f)
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return err
+	}
 	var query string
 
 	switch s.driver {
 	case enum.DatabaseDriverSqlite:
 		// SQLite não suporta LIMIT em DELETE, usa subquery com ROWID
 		query = fmt.Sprintf("DELETE FROM %s WHERE rowid IN (SELECT rowid FROM %s%s LIMIT 1)",
-			s.tableName, s.tableName, whereClause)
+			table, table, whereClause)
 	case enum.DatabaseDriverOracle:
 		// Oracle não suporta LIMIT, usa ROWNUM em subquery
 		query = fmt.Sprintf("DELETE FROM %s WHERE ROWID IN (SELECT ROWID FROM %s%s AND ROWNUM = 1)",
-			s.tableName, s.tableName, whereClause)
+			table, table, whereClause)
 	case enum.DatabaseDriverMysql, enum.DatabaseDriverMariaDB, enum.DatabaseDriverPostgres:
 		// MySQL, MariaDB e PostgreSQL suportam LIMIT em DELETE
-		query = fmt.Sprintf("DELETE FROM %s%s LIMIT 1", s.tableName, whereClause)
+		query = fmt.Sprintf("DELETE FROM %s%s LIMIT 1", table, whereClause)
 	default:
 		return fmt.Errorf("unsupported database driver for DeleteOne: %s", s.driver.GetValue())
 	}
 
-	result, err := s.db.ExecContext(ctx, query, values...)
+	result, err := s.conn().ExecContext(ctx, query, values...)
 	if err != nil {
 		return fmt.Errorf("erro ao deletar documento: %w", err)
 	}
@@ -886,19 +2432,31 @@ func (s *SQLStore[T]) DeleteOne(ctx context.Context, f map[string]interface{}) e
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("nenhum documento encontrado com filtro %v", f)
+		return fmt.Errorf("nenhum documento encontrado com filtro %v: %w", f, ErrNotFound)
 	}
 
 	return nil
 }
 
 // DeleteMany remove múltiplos registros
-func (s *SQLStore[T]) DeleteMany(ctx context.Context, f map[string]any) (*DeleteResult, error) {
-	whereClause, values := s.buildWhereClause(f)
-	query := fmt.Sprintf("DELETE FROM %s", s.tableName)
+func (s *SQLStore[T]) DeleteMany(ctx context.Context, f map[string]any) (_ *DeleteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	whereClause, values, err := s.buildWhereClause(f)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("DELETE FROM %s", table)
 	query += whereClause
 
-	result, err := s.db.ExecContext(ctx, query, values...)
+	result, err := s.conn().ExecContext(ctx, query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -907,6 +2465,80 @@ func (s *SQLStore[T]) DeleteMany(ctx context.Context, f map[string]any) (*Delete
 	return &DeleteResult{DeletedCount: rowsAffected}, nil
 }
 
+// DeleteManyBatch remove os registros de cada filtro em uma única transação e retorna o
+// DeletedCount agregado. Uma falha em qualquer DELETE desfaz toda a operação
+func (s *SQLStore[T]) DeleteManyBatch(ctx context.Context, filters []map[string]any) (_ *BulkWriteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	table, err := s.table(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("nenhum filtro fornecido")
+	}
+
+	for i, f := range filters {
+		if f == nil {
+			return nil, fmt.Errorf("filtro não pode ser nulo no delete %d", i)
+		}
+	}
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var totalDeleted int64
+	for i, f := range filters {
+		whereClause, values, err := s.buildWhereClause(f)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("delete %d: %w", i, err)
+		}
+		query := fmt.Sprintf("DELETE FROM %s%s", table, whereClause)
+
+		result, err := tx.ExecContext(ctx, query, values...)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("erro ao executar delete %d: %w", i, err)
+		}
+
+		rowsAffected, _ := result.RowsAffected()
+		totalDeleted += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("erro ao fazer commit: %w", err)
+	}
+
+	return &BulkWriteResult{DeletedCount: totalDeleted}, nil
+}
+
+// DeleteByIds remove os registros cujas chaves primárias estão em ids, em uma única query.
+// Uma lista vazia não executa nenhuma operação
+func (s *SQLStore[T]) DeleteByIds(ctx context.Context, ids []any) (_ *DeleteResult, err error) {
+	defer s.opts.tagRequestID(ctx, &err)
+
+	defer s.InvalidateCount()
+
+	if len(ids) == 0 {
+		return &DeleteResult{DeletedCount: 0}, nil
+	}
+
+	return s.DeleteMany(ctx, map[string]any{s.primaryKey + "__in": ids})
+}
+
 // func (s *SQLStore[T]) isOracleDriver() bool {
 // 	// Para Oracle
 // 	var version string
@@ -946,14 +2578,30 @@ func (s *SQLStore[T]) DeleteMany(ctx context.Context, f map[string]any) (*Delete
 //		var filter = map[string]any{"name__in": []string{"John", "Jane"}}
 //		// Gera: name IN (?, ?)
 //
+//	Not In:
+//		var filter = map[string]any{"name__not_in": []string{"John", "Jane"}}
+//		// Gera: name NOT IN (?, ?)
+//
 //	Is Null:
 //		var filter = map[string]any{"name__is_null": true}
-//		// Gera: name IS NULL
+//		// Gera: name IS NULL (com false, name IS NOT NULL)
 //
 //	Is Not Null:
 //		var filter = map[string]any{"name__is_not_null": true}
+//		// Gera: name IS NOT NULL (com false, name IS NULL)
+//
+//	Valor nil (equivalente ao {name: null} do Mongo, já que "= NULL" nunca é verdadeiro):
+//		var filter = map[string]any{"name": nil}
+//		// Gera: name IS NULL
+//		var filter = map[string]any{"name__not": nil}
 //		// Gera: name IS NOT NULL
 //
+//	Exists (equivalente ao $exists do Mongo):
+//		var filter = map[string]any{"name__exists": true}
+//		// Gera: name IS NOT NULL
+//		var filter = map[string]any{"name__exists": false}
+//		// Gera: name IS NULL
+//
 //	Comparações numéricas:
 //		var filter = map[string]any{
 //			"age__gt": 30,      // age > 30
@@ -961,11 +2609,94 @@ func (s *SQLStore[T]) DeleteMany(ctx context.Context, f map[string]any) (*Delete
 //			"age__gte": 18,     // age >= 18
 //			"age__lte": 65,     // age <= 65
 //		}
-func (s *SQLStore[T]) buildWhereClause(filters map[string]any) (string, []any) {
+//
+//	Caminho em coluna JSON (coluna.chave[.subchave]__json_eq; caminhos com caracteres fora de
+//	[A-Za-z0-9_-] retornam ErrUnknownColumn):
+//		var filter = map[string]any{"meta.status__json_eq": "ativo"}
+//		// Gera no Postgres: meta->>'status' = ?
+//		// Gera no MySQL e MariaDB: JSON_UNQUOTE(JSON_EXTRACT(meta, '$.status')) = ?
+//		// Gera no SQLite: json_extract(meta, '$.status') = ?
+//		// Gera no Oracle: JSON_VALUE(meta, '$.status') = ?
+//
+//	Partes de data (year, month, day e dow, com domingo = 0):
+//		var filter = map[string]any{"created_at__month": 1}
+//		// Gera no SQLite: CAST(strftime('%m', created_at) AS INTEGER) = ?
+//		// Gera no Postgres e Oracle: EXTRACT(MONTH FROM created_at) = ?
+//		// Gera no MySQL e MariaDB: MONTH(created_at) = ?
+//
+//	Expressões com AND, OR e NOT aninhados (ver Filter):
+//		var filter = Where(And(Cond(map[string]any{"active": true}), Not(Cond(map[string]any{"age__lt": 18}))))
+//		// Gera: ((active = ?) AND NOT (age < ?))
+//
+// Tipos de valores suportados nos filtros:
+//
+//   - tipos básicos (string, inteiros, float64, time.Time): enviados sem alteração
+//   - bool: enviado como 0/1 no SQLite e Oracle, que armazenam booleanos como inteiros, e sem alteração nos demais
+//   - float32: convertido para float64
+//   - *big.Rat e *big.Float: enviados como texto decimal exato (nil como NULL), para colunas DECIMAL
+//   - driver.Valuer (ex.: decimal.Decimal, uuid.UUID): enviados sem alteração, o driver resolve o valor
+//   - fmt.Stringer: tipos escalares (ex.: enums sobre int) enviados pelo valor base; os demais
+//     convertidos para string através do método String
+func (s *SQLStore[T]) buildWhereClause(filters map[string]any) (string, []any, error) {
 	if len(filters) == 0 {
-		return "", make([]any, 0)
+		return "", make([]any, 0), nil
+	}
+
+	whereConditions, values, err := s.buildConditions(filters)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return " WHERE " + strings.Join(whereConditions, " AND "), values, nil
+}
+
+// buildFilterExpression converte a expressão Filter em uma condição entre parênteses, com os
+// valores na ordem dos placeholders. AND e OR vazios geram "1 = 1" e "1 = 0", respectivamente
+func (s *SQLStore[T]) buildFilterExpression(filter Filter) (string, []any, error) {
+	switch filter.op {
+	case filterNot:
+		clause, values, err := s.buildFilterExpression(filter.children[0])
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + clause, values, nil
+	case filterAnd, filterOr:
+		separator, empty := " AND ", "1 = 1"
+		if filter.op == filterOr {
+			separator, empty = " OR ", "1 = 0"
+		}
+
+		if len(filter.children) == 0 {
+			return "(" + empty + ")", nil, nil
+		}
+
+		clauses := make([]string, len(filter.children))
+		var values []any
+		for i, child := range filter.children {
+			clause, childValues, err := s.buildFilterExpression(child)
+			if err != nil {
+				return "", nil, err
+			}
+			clauses[i] = clause
+			values = append(values, childValues...)
+		}
+
+		return "(" + strings.Join(clauses, separator) + ")", values, nil
+	default:
+		if len(filter.cond) == 0 {
+			return "(1 = 1)", nil, nil
+		}
+
+		conditions, values, err := s.buildConditions(filter.cond)
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + strings.Join(conditions, " AND ") + ")", values, nil
 	}
+}
 
+// buildConditions converte cada filtro em uma condição, com as chaves ordenadas
+func (s *SQLStore[T]) buildConditions(filters map[string]any) ([]string, []any, error) {
 	// Ordena as chaves
 	keys := make([]string, 0, len(filters))
 	for key := range filters {
@@ -981,6 +2712,20 @@ func (s *SQLStore[T]) buildWhereClause(filters map[string]any) (string, []any) {
 		field := key
 		value := filters[key]
 
+		if expression, ok := filterExpression(value); ok && key == filterKey {
+			clause, expressionValues, err := s.buildFilterExpression(expression)
+			if err != nil {
+				return nil, nil, err
+			}
+			whereConditions = append(whereConditions, clause)
+			values = append(values, expressionValues...)
+			continue
+		}
+
+		if err := s.validateFilterColumn(filterColumn(key)); err != nil {
+			return nil, nil, err
+		}
+
 		if strings.Contains(key, "__") {
 			parts := strings.Split(key, "__")
 			field = parts[0]
@@ -1002,12 +2747,44 @@ func (s *SQLStore[T]) buildWhereClause(filters map[string]any) (string, []any) {
 				operator = "<="
 			case "in":
 				operator = "IN"
+			case "not_in":
+				operator = "NOT IN"
 			case "not":
 				operator = "!="
 			case "is_null":
 				operator = "IS NULL"
+				if isNull, ok := value.(bool); ok && !isNull {
+					operator = "IS NOT NULL"
+				}
 			case "is_not_null":
 				operator = "IS NOT NULL"
+				if notNull, ok := value.(bool); ok && !notNull {
+					operator = "IS NULL"
+				}
+			case "exists":
+				operator = "IS NULL"
+				if exists, ok := value.(bool); ok && exists {
+					operator = "IS NOT NULL"
+				}
+			case "year", "month", "day", "dow":
+				field = s.datePartExpression(field, parts[1])
+			case "json_eq":
+				column, path, _ := strings.Cut(field, ".")
+				expression, ok := s.jsonPathExpression(column, path)
+				if !ok {
+					// O caminho compõe a query como literal, então caminhos inválidos são rejeitados
+					return nil, nil, fmt.Errorf("%w: caminho JSON inválido no filtro %s", ErrUnknownColumn, key)
+				}
+				field = expression
+			}
+		}
+
+		if isNilValue(value) {
+			switch operator {
+			case "=":
+				operator = "IS NULL"
+			case "!=":
+				operator = "IS NOT NULL"
 			}
 		}
 
@@ -1023,7 +2800,7 @@ func (s *SQLStore[T]) buildWhereClause(filters map[string]any) (string, []any) {
 			continue
 		}
 
-		if operator == "IN" {
+		if operator == "IN" || operator == "NOT IN" {
 			// Obter o slice de valores
 			valuesSlice, ok := value.([]any)
 			if !ok {
@@ -1068,22 +2845,175 @@ func (s *SQLStore[T]) buildWhereClause(filters map[string]any) (string, []any) {
 				field, operator, strings.Join(placeholders, ", ")))
 
 			// Adicionar cada valor individualmente ao slice de valores
-			values = append(values, valuesSlice...)
+			for _, val := range valuesSlice {
+				values = append(values, s.filterValue(val))
+			}
 
 			continue
 		}
 
 		whereConditions = append(whereConditions, fmt.Sprintf("%s %s ?", field, operator))
-		values = append(values, value)
+		values = append(values, s.filterValue(value))
+	}
+
+	return whereConditions, values, nil
+}
+
+// filterIdentifier formato aceito para colunas de filtro quando T não mapeia colunas (ex.: views no FindAllRaw)
+var filterIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateFilterColumn garante que a coluna do filtro, que compõe o texto da query, é uma coluna
+// mapeada na entidade, como na projeção. Entidades sem colunas mapeadas aceitam apenas
+// identificadores simples
+func (s *SQLStore[T]) validateFilterColumn(column string) error {
+	if len(s.columns) == 0 {
+		if !filterIdentifier.MatchString(column) {
+			return fmt.Errorf("%w no filtro: %s", ErrUnknownColumn, column)
+		}
+		return nil
+	}
+
+	return s.validateColumns("filtro", []string{column})
+}
+
+// filterColumn retorna a coluna da chave do filtro: a parte antes do operador (campo__op) e,
+// no json_eq, antes do caminho JSON (coluna.caminho__json_eq)
+func filterColumn(key string) string {
+	parts := strings.Split(key, "__")
+	if len(parts) > 1 && parts[1] == "json_eq" {
+		column, _, _ := strings.Cut(parts[0], ".")
+		return column
+	}
+
+	return parts[0]
+}
+
+// isNilValue indica se o valor do filtro é nil ou um ponteiro nil
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// filterValue normaliza o valor do filtro para o driver. SQLite e Oracle não têm tipo booleano
+// e armazenam 0/1, então o bool é enviado como inteiro para que active = false case com 0
+func (s *SQLStore[T]) filterValue(value any) any {
+	value = normalizeFilterValue(value)
+
+	if b, ok := value.(bool); ok && (s.driver == enum.DatabaseDriverSqlite || s.driver == enum.DatabaseDriverOracle) {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	return value
+}
+
+// normalizeFilterValue converte tipos numéricos e wrappers conhecidos para
+// formatos aceitos pelos drivers SQL. *big.Rat e *big.Float são enviados como texto decimal
+// exato, e o ponteiro nil como NULL. Tipos escalares com String() (ex.: enums sobre int)
+// são comparados pelo valor base, não pelo texto
+func normalizeFilterValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case driver.Valuer:
+		return v
+	case time.Time:
+		return v
+	case float32:
+		return float64(v)
+	case *big.Rat:
+		if v == nil {
+			return nil
+		}
+		return ratFilterString(v)
+	case *big.Float:
+		if v == nil {
+			return nil
+		}
+		return v.Text('f', -1)
+	case fmt.Stringer:
+		if scalar, ok := scalarValue(reflect.ValueOf(v)); ok {
+			return scalar
+		}
+		return v.String()
+	}
+
+	return value
+}
+
+// ratFilterScale casas decimais usadas para frações sem representação decimal finita (ex.: 1/3),
+// igual à escala máxima do DECIMAL no MySQL
+const ratFilterScale = 30
+
+// ratFilterString formata o *big.Rat em texto decimal, sem passar por float64, para que colunas
+// DECIMAL sejam comparadas com o valor exato
+func ratFilterString(v *big.Rat) string {
+	scale, exact := v.FloatPrec()
+	if !exact {
+		scale = ratFilterScale
+	}
+
+	return v.FloatString(scale)
+}
+
+// scalarValue converte valores de tipos nomeados sobre int, uint, float, bool ou string para o
+// tipo base. Retorna false para os demais kinds
+func scalarValue(v reflect.Value) (any, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Bool:
+		return v.Bool(), true
+	case reflect.String:
+		return v.String(), true
+	default:
+		return nil, false
+	}
+}
+
+// scanInto delega a conversão ao sql.Scanner do campo (ou do elemento, em campos ponteiro).
+// Em ponteiros, NULL mantém o campo nil. Retorna false quando o tipo não implementa sql.Scanner
+func scanInto(field reflect.Value, value any) (bool, error) {
+	if field.Kind() == reflect.Ptr && reflect.PointerTo(field.Type().Elem()).Implements(scannerType) {
+		if value == nil {
+			field.Set(reflect.Zero(field.Type()))
+			return true, nil
+		}
+
+		elem := reflect.New(field.Type().Elem())
+		if err := elem.Interface().(sql.Scanner).Scan(value); err != nil {
+			return true, err
+		}
+		field.Set(elem)
+		return true, nil
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(scannerType) {
+		return true, field.Addr().Interface().(sql.Scanner).Scan(value)
 	}
 
-	return " WHERE " + strings.Join(whereConditions, " AND "), values
+	return false, nil
 }
 
 // setValue Função auxiliar para definir valores com conversão de tipo
-func (s *SQLStore[T]) setValue(field reflect.Value, value any) {
+func (s *SQLStore[T]) setValue(field reflect.Value, value any) error {
 	if !field.CanSet() {
-		return
+		return nil
+	}
+
+	// Tipos que implementam sql.Scanner (ex.: UUID, tipos geográficos) convertem o valor bruto do driver
+	if handled, err := scanInto(field, value); handled {
+		return err
 	}
 
 	switch field.Kind() {
@@ -1093,6 +3023,16 @@ func (s *SQLStore[T]) setValue(field reflect.Value, value any) {
 			// Obtém o tipo do elemento do ponteiro
 			elemType := field.Type().Elem()
 
+			// Ponteiros para time.Time usam a mesma conversão dos campos time.Time
+			if elemType == reflect.TypeFor[time.Time]() {
+				t, err := toTime(value, s.opts.timeLayouts...)
+				if err != nil {
+					return err
+				}
+				field.Set(reflect.ValueOf(&t))
+				return nil
+			}
+
 			// Cria uma nova instância do tipo do enum
 			newInstance := reflect.New(elemType).Interface()
 
@@ -1108,13 +3048,13 @@ func (s *SQLStore[T]) setValue(field reflect.Value, value any) {
 
 				// Converte a string para o tipo específico
 				converted, err := converter.FromString(strValue)
-				if err == nil {
-					// Define o valor no campo
-					field.Set(reflect.ValueOf(converted))
-				} else {
-					fmt.Printf("Erro ao converter para %s: %v\n", elemType.String(), err)
+				if err != nil {
+					return fmt.Errorf("erro ao converter para %s: %w", elemType.String(), err)
 				}
-				return
+
+				// Define o valor no campo
+				field.Set(reflect.ValueOf(converted))
+				return nil
 			}
 
 			// Cria um novo valor do tipo correto
@@ -1123,9 +3063,7 @@ func (s *SQLStore[T]) setValue(field reflect.Value, value any) {
 			// Converte o valor para o tipo correto
 			convertedValue, err := s.convertToType(reflect.ValueOf(value), elemType)
 			if err != nil {
-				// Lida com erro de conversão
-				fmt.Printf("Erro ao converter valor: %v\n", err)
-				return
+				return fmt.Errorf("erro ao converter valor: %w", err)
 			}
 
 			// Define o valor no elemento do ponteiro
@@ -1165,34 +3103,202 @@ func (s *SQLStore[T]) setValue(field reflect.Value, value any) {
 		} else {
 			field.SetString(fmt.Sprintf("%v", value))
 		}
-	case reflect.Int, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var intVal int64
 		switch v := value.(type) {
-		case int:
-			field.SetInt(int64(v))
-		case int64:
-			field.SetInt(v)
+		case float64:
+			// SQLite (tipagem dinâmica) e expressões calculadas podem retornar inteiros como REAL; a parte decimal é descartada
+			intVal = int64(v)
+		case float32:
+			intVal = int64(v)
 		case []byte:
-			intVal, _ := strconv.ParseInt(string(v), 10, 64)
-			field.SetInt(intVal)
+			parsed, err := strconv.ParseInt(string(v), 10, 64)
+			if err != nil {
+				return err
+			}
+			intVal = parsed
+		case string:
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return err
+			}
+			intVal = parsed
+		default:
+			// Drivers podem retornar qualquer tamanho de inteiro, com ou sem sinal
+			rv := reflect.ValueOf(value)
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				intVal = rv.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				if rv.Uint() > math.MaxInt64 {
+					return fmt.Errorf("valor %d excede o campo %s", rv.Uint(), field.Type())
+				}
+				intVal = int64(rv.Uint())
+			case reflect.Invalid:
+				// NULL mantém o valor zero do campo
+				return nil
+			default:
+				return fmt.Errorf("não é possível converter %T para o campo %s", value, field.Type())
+			}
 		}
-	case reflect.Float64:
+		if field.OverflowInt(intVal) {
+			return fmt.Errorf("valor %d excede o campo %s", intVal, field.Type())
+		}
+		field.SetInt(intVal)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var uintVal uint64
 		switch v := value.(type) {
 		case float64:
-			field.SetFloat(v)
+			if v < 0 {
+				return fmt.Errorf("valor %v negativo para o campo %s", v, field.Type())
+			}
+			uintVal = uint64(v)
+		case float32:
+			if v < 0 {
+				return fmt.Errorf("valor %v negativo para o campo %s", v, field.Type())
+			}
+			uintVal = uint64(v)
+		case []byte:
+			parsed, err := strconv.ParseUint(string(v), 10, 64)
+			if err != nil {
+				return err
+			}
+			uintVal = parsed
+		case string:
+			parsed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return err
+			}
+			uintVal = parsed
+		default:
+			rv := reflect.ValueOf(value)
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				if rv.Int() < 0 {
+					return fmt.Errorf("valor %d negativo para o campo %s", rv.Int(), field.Type())
+				}
+				uintVal = uint64(rv.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				uintVal = rv.Uint()
+			case reflect.Invalid:
+				// NULL mantém o valor zero do campo
+				return nil
+			default:
+				return fmt.Errorf("não é possível converter %T para o campo %s", value, field.Type())
+			}
+		}
+		if field.OverflowUint(uintVal) {
+			return fmt.Errorf("valor %d excede o campo %s", uintVal, field.Type())
+		}
+		field.SetUint(uintVal)
+	case reflect.Float32, reflect.Float64:
+		var floatVal float64
+		switch v := value.(type) {
 		case []byte:
-			floatVal, _ := strconv.ParseFloat(string(v), 64)
-			field.SetFloat(floatVal)
+			parsed, err := strconv.ParseFloat(string(v), 64)
+			if err != nil {
+				return err
+			}
+			floatVal = parsed
+		case string:
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return err
+			}
+			floatVal = parsed
+		default:
+			rv := reflect.ValueOf(value)
+			switch rv.Kind() {
+			case reflect.Float32, reflect.Float64:
+				floatVal = rv.Float()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				floatVal = float64(rv.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				floatVal = float64(rv.Uint())
+			case reflect.Invalid:
+				// NULL mantém o valor zero do campo
+				return nil
+			default:
+				return fmt.Errorf("não é possível converter %T para o campo %s", value, field.Type())
+			}
+		}
+		if field.OverflowFloat(floatVal) {
+			return fmt.Errorf("valor %v excede o campo %s", floatVal, field.Type())
 		}
+		field.SetFloat(floatVal)
 	case reflect.Struct:
 		// Para tipos Time, conversão específica
 		if field.Type().String() == "time.Time" {
-			if v, ok := value.(time.Time); ok {
-				field.Set(reflect.ValueOf(v))
-			} else if v, ok := value.([]byte); ok {
-				t, _ := time.Parse("2006-01-02 15:04:05", string(v))
-				field.Set(reflect.ValueOf(t))
+			t, err := toTime(value, s.opts.timeLayouts...)
+			if err != nil {
+				return err
 			}
+			field.Set(reflect.ValueOf(t))
+		}
+	}
+
+	return nil
+}
+
+// timeLayouts Formatos aceitos ao converter textos retornados pelos drivers para time.Time
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+// toTime Função auxiliar que converte os valores de data retornados pelos drivers para time.Time.
+// Os layouts informados são tentados antes dos formatos conhecidos
+func toTime(value any, layouts ...string) (time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		// NULL mantém o valor zero, como nos campos numéricos
+		return time.Time{}, nil
+	case time.Time:
+		return v, nil
+	case []byte:
+		return parseTime(string(v), layouts...)
+	case string:
+		return parseTime(v, layouts...)
+	default:
+		return time.Time{}, fmt.Errorf("tipo %T não pode ser convertido para time.Time", value)
+	}
+}
+
+// parseTime Função auxiliar que converte um texto para time.Time usando os layouts informados
+// e, em seguida, os formatos conhecidos
+func parseTime(raw string, layouts ...string) (time.Time, error) {
+	var err error
+	for _, layout := range slices.Concat(layouts, timeLayouts) {
+		var t time.Time
+		if t, err = time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// toInt64 Função auxiliar que converte valores numéricos retornados pelos drivers para int64
+func toInt64(value any) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case []byte:
+		intVal, _ := strconv.ParseInt(string(v), 10, 64)
+		return intVal
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return int64(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			return int64(rv.Float())
 		}
+		intVal, _ := strconv.ParseInt(fmt.Sprintf("%v", value), 10, 64)
+		return intVal
 	}
 }
 
@@ -1251,7 +3357,7 @@ func (s *SQLStore[T]) convertToType(value reflect.Value, targetType reflect.Type
 			case time.Time:
 				return reflect.ValueOf(v), nil
 			case []uint8:
-				t, err := time.Parse("2006-01-02 15:04:05", string(v))
+				t, err := toTime(v, s.opts.timeLayouts...)
 				if err != nil {
 					return reflect.Value{}, err
 				}
@@ -1266,10 +3372,20 @@ func (s *SQLStore[T]) convertToType(value reflect.Value, targetType reflect.Type
 
 // parseRow Função auxiliar de parse de linha do banco
 func (s *SQLStore[T]) parseRow(rows *sql.Rows) (*T, error) {
+	columns, values, err := s.scanRow(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapRow(columns, values)
+}
+
+// scanRow Função auxiliar que lê os nomes das colunas e os valores da linha atual
+func (s *SQLStore[T]) scanRow(rows *sql.Rows) ([]string, []any, error) {
 	// Obtém os nomes das colunas
 	columns, err := rows.Columns()
 	if err != nil {
-		return nil, fmt.Errorf("erro ao obter colunas: %v", err)
+		return nil, nil, fmt.Errorf("erro ao obter colunas: %v", err)
 	}
 
 	// Cria um slice de valores para scan
@@ -1281,33 +3397,44 @@ func (s *SQLStore[T]) parseRow(rows *sql.Rows) (*T, error) {
 
 	// Faz o scan
 	if err := rows.Scan(valuePtrs...); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	return columns, values, nil
+}
+
+// mapRow Função auxiliar que mapeia os valores de uma linha para a entidade usando as tags 'db'.
+// Retorna erro com o nome da coluna quando algum valor não pode ser convertido
+func (s *SQLStore[T]) mapRow(columns []string, values []any) (*T, error) {
 	// Cria a estrutura de retorno
-	entity := reflect.New(reflect.TypeOf((*T)(nil)).Elem()).Interface().(*T)
+	entity := new(T)
+	if err := s.mapRowInto(entity, columns, values); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// mapRowInto Função auxiliar que mapeia os valores de uma linha para a entidade informada
+func (s *SQLStore[T]) mapRowInto(entity *T, columns []string, values []any) error {
 	v := reflect.ValueOf(entity).Elem()
-	t := v.Type()
 
-	// Criar um mapa de tags 'db' para campos
+	// Criar um mapa de tags 'db' para campos, incluindo os de structs aninhadas
 	dbTagToField := make(map[string]reflect.Value)
-	for i := range v.NumField() {
-		field := v.Field(i)
-		typeField := t.Field(i)
-		tag := typeField.Tag.Get("db")
-		if tag != "" && tag != "-" {
-			dbTagToField[tag] = field
-		}
-	}
+	nestedColumnFields(v, "", dbTagToField)
 
 	// Mapeia os valores para os campos usando as tags 'db'
 	for i, column := range columns {
 		// Procura pelo campo com a tag 'db' correspondente
 		if field, ok := dbTagToField[column]; ok && field.IsValid() {
 			// Converte e atribui o valor
-			s.setValue(field, values[i])
+			if err := s.setValue(field, values[i]); err != nil {
+				return fmt.Errorf("erro ao converter coluna %s: %w", column, err)
+			}
 		}
 	}
 
-	return entity, nil
+	transformEntity(s.opts, entity)
+
+	return nil
 }
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/luma-sys/go-db-store/enum"
+)
+
+// SQLConfig parâmetros do NewSQLStoreWithConfig
+type SQLConfig struct {
+	DB            *sql.DB
+	Driver        enum.DatabaseDriver
+	TableName     string
+	PrimaryKey    string
+	Autoincrement bool
+	// Pool configura o pool de conexões do DB antes de criar o store
+	Pool PoolConfig
+}
+
+// PoolConfig configura o pool de conexões do *sql.DB. Valores zero mantêm a configuração atual
+// do DB; para remover um limite já definido use SetMaxOpenConns etc. diretamente
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// apply aplica ao db os valores informados
+func (p PoolConfig) apply(db *sql.DB) {
+	if p.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if p.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	}
+	if p.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+	}
+}
+
+// NewSQLStoreWithConfig cria o store como NewSQLStore, aplicando antes o cfg.Pool ao cfg.DB.
+// O pool pertence ao *sql.DB, então a configuração vale para todos os stores que o compartilham.
+// O DB de leitura do WithReadDB não é alterado. Retorna erro quando cfg.DB não é informado
+func NewSQLStoreWithConfig[T any](cfg SQLConfig, opts ...Option) (Store[T], error) {
+	if cfg.DB == nil {
+		return nil, errors.New("NewSQLStoreWithConfig: SQLConfig.DB não informado")
+	}
+
+	cfg.Pool.apply(cfg.DB)
+
+	return NewSQLStore[T](cfg.DB, cfg.Driver, cfg.TableName, cfg.PrimaryKey, cfg.Autoincrement, opts...), nil
+}
@@ -3,8 +3,14 @@ package store
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +27,7 @@ type TestSQLEntity struct {
 	Score     float64   `db:"score" json:"score"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	Internal  string    `db:"-" json:"-"`
 }
 
 type TestSQLEntityWithoutTimestamps struct {
@@ -271,340 +278,544 @@ func TestSQLSave_IgnoredFields(t *testing.T) {
 	assert.NotZero(t, result.ID)
 }
 
-// ==================== TESTES SAVE MANY ====================
+type TestSQLEntityWithDefaults struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Status    string    `db:"status,generated"`
+	CreatedAt time.Time `db:"created_at,generated"`
+}
 
-func TestSQLSaveMany(t *testing.T) {
-	db, err := setupSQLDB()
+func setupSQLDBWithDefaults() (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
-		t.Fatal(err)
+		return nil, errors.New("erro ao abrir conexão com SQLite: " + err.Error())
 	}
-	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
-	ctx := context.Background()
+	_, err = db.Exec(`
+		CREATE TABLE default_entities (
+			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pendente',
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return nil, errors.New("erro ao criar tabela: " + err.Error())
+	}
 
-	tests := []struct {
-		name    string
-		input   []TestSQLEntity
-		check   func(*testing.T, *InsertManyResult)
-		wantErr bool
-	}{
-		{
-			name: "deve salvar múltiplos registros",
-			input: []TestSQLEntity{
-				{Name: "João", Age: 25},
-				{Name: "Maria", Age: 30},
-				{Name: "Pedro", Age: 35},
-			},
-			check: func(t *testing.T, result *InsertManyResult) {
-				assert.Equal(t, 3, len(result.InsertedIDs))
-			},
-		},
-		{
-			name: "deve salvar um único registro",
-			input: []TestSQLEntity{
-				{Name: "Único", Age: 40},
-			},
-			check: func(t *testing.T, result *InsertManyResult) {
-				assert.Equal(t, 1, len(result.InsertedIDs))
-			},
-		},
-		{
-			name:  "deve retornar nil para slice vazio",
-			input: []TestSQLEntity{},
-			check: func(t *testing.T, result *InsertManyResult) {
-				assert.Nil(t, result)
-			},
-		},
+	return db, nil
+}
+
+func TestSQLSave_ReloadAfterSave(t *testing.T) {
+	db, err := setupSQLDBWithDefaults()
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer db.Close()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Limpa a tabela
-			db.Exec("DELETE FROM test_entities")
+	ctx := context.Background()
 
-			result, err := store.SaveMany(ctx, tt.input)
+	t.Run("deve refletir valores DEFAULT do banco na entidade", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntityWithDefaults](db, enum.DatabaseDriverSqlite, "default_entities", "id", true, WithReloadAfterSave())
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
+		result, err := store.Save(ctx, &TestSQLEntityWithDefaults{Name: "Com Reload"})
+		assert.NoError(t, err)
+		assert.NotZero(t, result.ID)
+		assert.Equal(t, "Com Reload", result.Name)
+		assert.Equal(t, "pendente", result.Status)
+		assert.False(t, result.CreatedAt.IsZero())
+	})
 
-			assert.NoError(t, err)
+	t.Run("não deve recarregar o registro sem a opção", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntityWithDefaults](db, enum.DatabaseDriverSqlite, "default_entities", "id", true)
 
-			if tt.check != nil {
-				tt.check(t, result)
-			}
+		result, err := store.Save(ctx, &TestSQLEntityWithDefaults{Name: "Sem Reload"})
+		assert.NoError(t, err)
+		assert.NotZero(t, result.ID)
+		assert.Empty(t, result.Status)
 
-			// Verifica contagem
-			if len(tt.input) > 0 {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(len(tt.input)), *count)
-			}
-		})
-	}
+		found, err := store.FindById(ctx, result.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "pendente", found.Status)
+	})
 }
 
-// ==================== TESTES SAVE MANY NOT ORDERED ====================
+type TestSQLEntityWithCustomKey struct {
+	UUID int64  `db:"uuid"`
+	Name string `db:"name"`
+}
 
-func TestSQLSaveManyNotOrdered(t *testing.T) {
-	db, err := setupSQLDB()
+func TestSQLSave_CustomPrimaryKey(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	_, err = db.Exec(`
+		CREATE TABLE custom_key_entities (
+			uuid INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithCustomKey](db, enum.DatabaseDriverSqlite, "custom_key_entities", "uuid", true)
 	ctx := context.Background()
 
-	entities := []TestSQLEntity{
-		{Name: "Doc 1"},
-		{Name: "Doc 2"},
-	}
+	result, err := store.Save(ctx, &TestSQLEntityWithCustomKey{Name: "Chave Customizada"})
+	assert.NoError(t, err)
+	assert.NotZero(t, result.UUID)
 
-	result, err := store.SaveManyNotOrdered(ctx, entities)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "not implemented")
+	found, err := store.FindById(ctx, result.UUID)
+	assert.NoError(t, err)
+	assert.Equal(t, result.UUID, found.UUID)
+	assert.Equal(t, "Chave Customizada", found.Name)
 }
 
-// ==================== TESTES FIND BY ID ====================
-
-func TestSQLFindById(t *testing.T) {
-	db, err := setupSQLDB()
+func TestSQLUpsertMany_CustomPrimaryKey(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	_, err = db.Exec(`
+		CREATE TABLE custom_key_entities (
+			uuid INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL
+		);
+		INSERT INTO custom_key_entities (uuid, name) VALUES (1, 'Existente');
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithCustomKey](db, enum.DatabaseDriverSqlite, "custom_key_entities", "uuid", true)
 	ctx := context.Background()
 
-	// Setup: salva registros de teste
-	testDoc := &TestSQLEntity{
-		Name:   "Documento Teste",
-		Age:    25,
-		Active: true,
-		Score:  88.5,
+	_, err = store.UpsertMany(ctx, []TestSQLEntityWithCustomKey{{UUID: 1, Name: "Atualizado"}}, nil)
+	assert.NoError(t, err)
+
+	count, err := store.Count(ctx, map[string]any{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *count)
+
+	found, err := store.FindById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Atualizado", found.Name)
+}
+
+func TestSQLFindById_InvalidColumnValue(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
 	}
-	saved, _ := store.Save(ctx, testDoc)
+	defer db.Close()
 
-	tests := []struct {
-		name    string
-		id      any
-		check   func(*testing.T, *TestSQLEntity)
-		wantErr bool
-	}{
-		{
-			name: "deve encontrar registro existente",
-			id:   saved.ID,
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, saved.ID, result.ID)
-				assert.Equal(t, "Documento Teste", result.Name)
-				assert.Equal(t, 25, result.Age)
-				assert.True(t, result.Active)
-				assert.Equal(t, 88.5, result.Score)
-			},
-		},
-		{
-			name:    "deve retornar erro para ID inexistente",
-			id:      99999,
-			wantErr: true,
-		},
-		{
-			name:    "deve retornar erro para ID zero",
-			id:      0,
-			wantErr: true,
-		},
+	_, err = db.Exec(`
+		CREATE TABLE invalid_entities (
+			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			created_at TEXT NOT NULL
+		);
+		INSERT INTO invalid_entities (name, created_at) VALUES ('Data Inválida', 'não é uma data');
+	`)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := store.FindById(ctx, tt.id)
+	type InvalidEntity struct {
+		ID        int       `db:"id"`
+		Name      string    `db:"name"`
+		CreatedAt time.Time `db:"created_at"`
+	}
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Nil(t, result)
-				return
-			}
+	store := NewSQLStore[InvalidEntity](db, enum.DatabaseDriverSqlite, "invalid_entities", "id", true)
+	ctx := context.Background()
 
-			assert.NoError(t, err)
-			assert.NotNil(t, result)
+	t.Run("deve retornar erro com o nome da coluna no FindById", func(t *testing.T) {
+		result, err := store.FindById(ctx, 1)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "erro ao converter coluna created_at")
+	})
 
-			if tt.check != nil {
-				tt.check(t, result)
-			}
-		})
-	}
+	t.Run("deve retornar erro com o nome da coluna numérica inválida", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE invalid_numbers (id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, age TEXT NOT NULL);
+			INSERT INTO invalid_numbers (age) VALUES ('trinta')`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type InvalidNumber struct {
+			ID  int `db:"id"`
+			Age int `db:"age"`
+		}
+
+		numbers := NewSQLStore[InvalidNumber](db, enum.DatabaseDriverSqlite, "invalid_numbers", "id", true)
+		result, err := numbers.FindById(ctx, 1)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "erro ao converter coluna age")
+	})
+
+	t.Run("deve retornar erro com o nome da coluna no FindAll", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.Error(t, err)
+		assert.Nil(t, results)
+		assert.Contains(t, err.Error(), "erro ao converter coluna created_at")
+	})
 }
 
-// ==================== TESTES FIND ONE ====================
+type TestSQLEntityWithServerKey struct {
+	UUID string `db:"uuid"`
+	Name string `db:"name"`
+}
 
-func TestSQLFindOne(t *testing.T) {
-	db, err := setupSQLDB()
+func TestSQLSave_ServerDefaultPKMode(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	_, err = db.Exec(`
+		CREATE TABLE server_key_entities (
+			uuid TEXT NOT NULL PRIMARY KEY DEFAULT (lower(hex(randomblob(16)))),
+			name TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithServerKey](db, enum.DatabaseDriverSqlite, "server_key_entities", "uuid", false, WithPKMode(PKModeServerDefault))
 	ctx := context.Background()
 
-	// Setup: salva registros de teste
-	testDocs := []TestSQLEntity{
-		{Name: "João Silva", Age: 25, Active: true, Score: 80},
-		{Name: "Maria Santos", Age: 30, Active: true, Score: 90},
-		{Name: "Pedro Costa", Age: 35, Active: false, Score: 70},
+	t.Run("deve omitir a chave no Save e ler o valor gerado pelo banco", func(t *testing.T) {
+		result, err := store.Save(ctx, &TestSQLEntityWithServerKey{Name: "Chave do Servidor"})
+		assert.NoError(t, err)
+		assert.Len(t, result.UUID, 32)
+
+		found, err := store.FindById(ctx, result.UUID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Chave do Servidor", found.Name)
+	})
+
+	t.Run("deve retornar as chaves geradas no SaveMany", func(t *testing.T) {
+		result, err := store.SaveMany(ctx, []TestSQLEntityWithServerKey{{Name: "Primeiro"}, {Name: "Segundo"}})
+		assert.NoError(t, err)
+		assert.Len(t, result.InsertedIDs, 2)
+		for _, id := range result.InsertedIDs {
+			assert.NotEmpty(t, id)
+		}
+	})
+
+	t.Run("deve sobrescrever o parâmetro autoincrement", func(t *testing.T) {
+		autoStore := NewSQLStore[TestSQLEntityWithCustomKey](db, enum.DatabaseDriverSqlite, "custom_key_entities", "uuid", false, WithPKMode(PKModeAutoIncrement))
+		_, err := db.Exec(`CREATE TABLE custom_key_entities (uuid INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`)
+		assert.NoError(t, err)
+
+		result, err := autoStore.Save(ctx, &TestSQLEntityWithCustomKey{Name: "Auto"})
+		assert.NoError(t, err)
+		assert.NotZero(t, result.UUID)
+	})
+}
+
+func TestSQLSave_TenantRouting(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
 	}
-	for _, doc := range testDocs {
-		_, _ = store.Save(ctx, &doc)
+	defer db.Close()
+
+	for _, tenant := range []string{"acme", "globex"} {
+		_, err = db.Exec(fmt.Sprintf(`
+			CREATE TABLE events_%s (
+				id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL
+			);
+		`, tenant))
+		if err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	tests := []struct {
-		name    string
-		filter  map[string]interface{}
-		check   func(*testing.T, *TestSQLEntity)
-		wantErr bool
-	}{
-		{
-			name:   "deve encontrar registro com filtro simples",
-			filter: map[string]interface{}{"name": "João Silva"},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "João Silva", result.Name)
-				assert.Equal(t, 25, result.Age)
-				assert.True(t, result.Active)
-				assert.Equal(t, 80.0, result.Score)
-			},
-		},
-		{
-			name:   "deve encontrar registro com filtro booleano",
-			filter: map[string]interface{}{"active": false},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Pedro Costa", result.Name)
-				assert.False(t, result.Active)
-			},
-		},
-		{
-			name:   "deve encontrar registro com múltiplos filtros",
-			filter: map[string]interface{}{"active": true, "age": 30},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Maria Santos", result.Name)
-				assert.Equal(t, 30, result.Age)
-				assert.True(t, result.Active)
-			},
-		},
-		{
-			name:   "deve encontrar registro com operador __gt",
-			filter: map[string]interface{}{"age__gt": 30},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Pedro Costa", result.Name)
-				assert.Greater(t, result.Age, 30)
-			},
-		},
-		{
-			name:   "deve encontrar registro com operador __gte",
-			filter: map[string]interface{}{"age__gte": 30},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.GreaterOrEqual(t, result.Age, 30)
-			},
-		},
-		{
-			name:   "deve encontrar registro com operador __lt",
-			filter: map[string]interface{}{"score__lt": 75},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Pedro Costa", result.Name)
-				assert.Less(t, result.Score, 75.0)
-			},
-		},
-		{
-			name:   "deve encontrar registro com operador __lte",
-			filter: map[string]interface{}{"age__lte": 25},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "João Silva", result.Name)
-				assert.LessOrEqual(t, result.Age, 25)
-			},
-		},
-		{
-			name:   "deve encontrar registro com operador __like",
-			filter: map[string]interface{}{"name__like": "%Silva%"},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "João Silva", result.Name)
-				assert.Contains(t, result.Name, "Silva")
-			},
-		},
+	store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "events_"+TenantPlaceholder, "id", true, WithTenantContextKey(testTenantKey{}))
+	acmeCtx := context.WithValue(context.Background(), testTenantKey{}, "acme")
+	globexCtx := context.WithValue(context.Background(), testTenantKey{}, "globex")
+
+	t.Run("deve salvar cada tenant na própria tabela", func(t *testing.T) {
+		_, err := store.Save(acmeCtx, &TestSQLEntityWithoutTimestamps{Name: "Acme"})
+		assert.NoError(t, err)
+		_, err = store.Save(globexCtx, &TestSQLEntityWithoutTimestamps{Name: "Globex"})
+		assert.NoError(t, err)
+
+		var name string
+		assert.NoError(t, db.QueryRow("SELECT name FROM events_acme").Scan(&name))
+		assert.Equal(t, "Acme", name)
+		assert.NoError(t, db.QueryRow("SELECT name FROM events_globex").Scan(&name))
+		assert.Equal(t, "Globex", name)
+
+		count, err := store.Count(acmeCtx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+	})
+
+	t.Run("deve falhar sem tenant no contexto", func(t *testing.T) {
+		_, err := store.Save(context.Background(), &TestSQLEntityWithoutTimestamps{Name: "Sem Tenant"})
+		assert.ErrorIs(t, err, ErrMissingTenant)
+	})
+
+	t.Run("deve falhar com tenant inválido", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), testTenantKey{}, "acme; DROP TABLE events_acme")
+		_, err := store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Inválido"})
+		assert.ErrorIs(t, err, ErrInvalidTenant)
+	})
+}
+
+func TestSQLScoped_Tenant(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, tenant := range []string{"acme", "globex"} {
+		_, err = db.Exec(fmt.Sprintf(`
+			CREATE TABLE events_%s (
+				id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL
+			);
+		`, tenant))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	base := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "events_"+TenantPlaceholder, "id", true, WithTenantContextKey(testTenantKey{}))
+	acme := base.Scoped(WithTenant("acme"))
+	ctx := context.Background()
+
+	t.Run("deve aplicar o tenant em todas as operações do store", func(t *testing.T) {
+		saved, err := acme.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Acme"})
+		assert.NoError(t, err)
+
+		found, err := acme.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Acme", found.Name)
+
+		count, err := acme.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+
+		assert.NoError(t, acme.Delete(ctx, saved.ID))
+		assert.False(t, acme.Has(ctx, saved.ID))
+	})
+
+	t.Run("deve ter prioridade sobre o tenant do contexto", func(t *testing.T) {
+		globexCtx := context.WithValue(ctx, testTenantKey{}, "globex")
+		_, err := acme.Save(globexCtx, &TestSQLEntityWithoutTimestamps{Name: "Acme"})
+		assert.NoError(t, err)
+
+		var total int
+		assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM events_globex").Scan(&total))
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("deve manter o store original inalterado", func(t *testing.T) {
+		_, err := base.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Sem Tenant"})
+		assert.ErrorIs(t, err, ErrMissingTenant)
+		assert.Equal(t, "sql:sqlite/events_{tenant}", acme.Describe())
+	})
+}
+
+type TestSQLEntityWithDeletedAt struct {
+	ID        int        `db:"id"`
+	Name      string     `db:"name"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func TestSQLSave_TimePointer(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE soft_delete_entities (
+			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			deleted_at TIMESTAMP NULL
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithDeletedAt](db, enum.DatabaseDriverSqlite, "soft_delete_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve gravar NULL e ler nil para ponteiro nil", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithDeletedAt{Name: "Ativo"})
+		assert.NoError(t, err)
+
+		var deletedAt sql.NullTime
+		assert.NoError(t, db.QueryRow("SELECT deleted_at FROM soft_delete_entities WHERE id = ?", saved.ID).Scan(&deletedAt))
+		assert.False(t, deletedAt.Valid)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, found.DeletedAt)
+	})
+
+	t.Run("deve gravar e ler o valor de ponteiro preenchido", func(t *testing.T) {
+		now := time.Date(2024, 5, 10, 14, 30, 0, 0, time.UTC)
+		saved, err := store.Save(ctx, &TestSQLEntityWithDeletedAt{Name: "Removido", DeletedAt: &now})
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		if assert.NotNil(t, found.DeletedAt) {
+			assert.True(t, now.Equal(*found.DeletedAt))
+		}
+	})
+
+	t.Run("deve limpar o valor ao atualizar para nil", func(t *testing.T) {
+		now := time.Now()
+		saved, _ := store.Save(ctx, &TestSQLEntityWithDeletedAt{Name: "Restaurado", DeletedAt: &now})
+
+		saved.DeletedAt = nil
+		_, err := store.Update(ctx, saved)
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, found.DeletedAt)
+	})
+
+	t.Run("deve ler NULL como valor zero em campo time.Time", func(t *testing.T) {
+		type softDeleteValue struct {
+			ID        int       `db:"id"`
+			Name      string    `db:"name"`
+			DeletedAt time.Time `db:"deleted_at"`
+		}
+
+		_, err := db.Exec(`INSERT INTO soft_delete_entities (name) VALUES ('Sem Data')`)
+		assert.NoError(t, err)
+
+		values := NewSQLStore[softDeleteValue](db, enum.DatabaseDriverSqlite, "soft_delete_entities", "id", true)
+		found, err := values.FindOne(ctx, map[string]any{"name": "Sem Data"})
+		assert.NoError(t, err)
+		assert.True(t, found.DeletedAt.IsZero())
+	})
+}
+
+func TestSQLSave_NoMappedColumns(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type untagged struct {
+		ID   int
+		Name string
+	}
+
+	store := NewSQLStore[untagged](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	_, err = store.Save(ctx, &untagged{Name: "Sem tags"})
+	assert.ErrorIs(t, err, ErrNoMappedColumns)
+	assert.ErrorContains(t, err, "tags `db`")
+
+	_, err = store.SaveMany(ctx, []untagged{{Name: "Sem tags"}})
+	assert.ErrorIs(t, err, ErrNoMappedColumns)
+
+	_, err = store.SaveIgnoreDuplicates(ctx, []untagged{{Name: "Sem tags"}})
+	assert.ErrorIs(t, err, ErrNoMappedColumns)
+}
+
+// ==================== TESTES SAVE MANY ====================
+
+func TestSQLSaveMany(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		input   []TestSQLEntity
+		check   func(*testing.T, *InsertManyResult)
+		wantErr bool
+	}{
 		{
-			name:   "deve encontrar registro com operador __like no início",
-			filter: map[string]interface{}{"name__like": "Maria%"},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Maria Santos", result.Name)
+			name: "deve salvar múltiplos registros",
+			input: []TestSQLEntity{
+				{Name: "João", Age: 25},
+				{Name: "Maria", Age: 30},
+				{Name: "Pedro", Age: 35},
 			},
-		},
-		{
-			name:   "deve encontrar registro com operador __in com []string",
-			filter: map[string]interface{}{"name__in": []string{"Maria Santos", "Não Existe"}},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Maria Santos", result.Name)
+			check: func(t *testing.T, result *InsertManyResult) {
+				assert.Equal(t, 3, len(result.InsertedIDs))
 			},
 		},
 		{
-			name:   "deve encontrar registro com operador __in com []int",
-			filter: map[string]interface{}{"age__in": []int{25, 99}},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, 25, result.Age)
+			name: "deve salvar um único registro",
+			input: []TestSQLEntity{
+				{Name: "Único", Age: 40},
 			},
-		},
-		{
-			name:    "deve retornar erro quando não encontra registro",
-			filter:  map[string]interface{}{"name": "Não Existe"},
-			wantErr: true,
-		},
-		{
-			name:    "deve retornar erro quando filtro não corresponde",
-			filter:  map[string]interface{}{"age": 999},
-			wantErr: true,
-		},
-		{
-			name:   "deve encontrar registro com filtro vazio (retorna primeiro)",
-			filter: map[string]interface{}{},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.NotNil(t, result)
-				assert.NotEmpty(t, result.Name)
+			check: func(t *testing.T, result *InsertManyResult) {
+				assert.Equal(t, 1, len(result.InsertedIDs))
 			},
 		},
 		{
-			name:   "deve encontrar por ID numérico",
-			filter: map[string]interface{}{"id": 1},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, 1, result.ID)
-				assert.Equal(t, "João Silva", result.Name)
+			name:  "deve retornar nil para slice vazio",
+			input: []TestSQLEntity{},
+			check: func(t *testing.T, result *InsertManyResult) {
+				assert.Nil(t, result)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := store.FindOne(ctx, tt.filter)
+			// Limpa a tabela
+			db.Exec("DELETE FROM test_entities")
+
+			result, err := store.SaveMany(ctx, tt.input)
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				assert.Nil(t, result)
-				assert.Contains(t, err.Error(), "documento não encontrado")
 				return
 			}
 
 			assert.NoError(t, err)
-			assert.NotNil(t, result)
 
 			if tt.check != nil {
 				tt.check(t, result)
 			}
+
+			// Verifica contagem
+			if len(tt.input) > 0 {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(len(tt.input)), *count)
+			}
 		})
 	}
 }
 
-// ==================== TESTES FIND ALL ====================
-
-func TestSQLFindAll(t *testing.T) {
+func TestSQLSaveMany_PreparedStatement(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -614,270 +825,60 @@ func TestSQLFindAll(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	// Setup: salva registros de teste
-	testDocs := []TestSQLEntity{
-		{Name: "João", Age: 25, Active: true, Score: 80},
-		{Name: "Maria", Age: 30, Active: true, Score: 90},
-		{Name: "Pedro", Age: 35, Active: false, Score: 70},
-		{Name: "Ana", Age: 28, Active: true, Score: 85},
-		{Name: "Carlos", Age: 40, Active: false, Score: 75},
-	}
-	for _, doc := range testDocs {
-		_, _ = store.Save(ctx, &doc)
-	}
-
-	tests := []struct {
-		name    string
-		filter  map[string]any
-		opts    FindOptions
-		wantLen int
-		check   func(*testing.T, []TestSQLEntity)
-		wantErr bool
-	}{
-		{
-			name:    "deve retornar todos os registros sem filtro",
-			filter:  nil,
-			opts:    FindOptions{},
-			wantLen: 5,
-		},
-		{
-			name:    "deve retornar todos com filtro vazio",
-			filter:  map[string]any{},
-			opts:    FindOptions{},
-			wantLen: 5,
-		},
-		{
-			name:    "deve filtrar por campo booleano true",
-			filter:  map[string]any{"active": true},
-			opts:    FindOptions{},
-			wantLen: 3,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.True(t, r.Active)
-				}
-			},
-		},
-		{
-			name:    "deve filtrar por campo booleano false",
-			filter:  map[string]any{"active": false},
-			opts:    FindOptions{},
-			wantLen: 2,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.False(t, r.Active)
-				}
-			},
-		},
-		{
-			name:    "deve filtrar por campo string",
-			filter:  map[string]any{"name": "João"},
-			opts:    FindOptions{},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "João", results[0].Name)
-			},
-		},
-		{
-			name:    "deve usar operador __gt",
-			filter:  map[string]any{"age__gt": 30},
-			opts:    FindOptions{},
-			wantLen: 2,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.Greater(t, r.Age, 30)
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __gte",
-			filter:  map[string]any{"age__gte": 30},
-			opts:    FindOptions{},
-			wantLen: 3,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.GreaterOrEqual(t, r.Age, 30)
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __lt",
-			filter:  map[string]any{"age__lt": 30},
-			opts:    FindOptions{},
-			wantLen: 2,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.Less(t, r.Age, 30)
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __lte",
-			filter:  map[string]any{"age__lte": 30},
-			opts:    FindOptions{},
-			wantLen: 3,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.LessOrEqual(t, r.Age, 30)
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __like",
-			filter:  map[string]any{"name__like": "%a%"},
-			opts:    FindOptions{},
-			wantLen: 3, // Maria, Ana, Carlos
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.Contains(t, r.Name, "a")
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __like no início",
-			filter:  map[string]any{"name__like": "M%"},
-			opts:    FindOptions{},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "Maria", results[0].Name)
-			},
-		},
-		{
-			name:    "deve usar operador __not_like",
-			filter:  map[string]any{"name__not_like": "%a%"},
-			opts:    FindOptions{},
-			wantLen: 2, // João, Pedro
-		},
-		{
-			name:    "deve usar operador __not",
-			filter:  map[string]any{"name__not": "João"},
-			opts:    FindOptions{},
-			wantLen: 4,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.NotEqual(t, "João", r.Name)
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __in com []int",
-			filter:  map[string]any{"age__in": []int{25, 30}},
-			opts:    FindOptions{},
-			wantLen: 2,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.True(t, r.Age == 25 || r.Age == 30)
-				}
-			},
-		},
-		{
-			name:    "deve usar operador __in com []string",
-			filter:  map[string]any{"name__in": []string{"João", "Maria"}},
-			opts:    FindOptions{},
-			wantLen: 2,
-		},
-		{
-			name:    "deve combinar múltiplos filtros",
-			filter:  map[string]any{"active": true, "age__gte": 28},
-			opts:    FindOptions{},
-			wantLen: 2, // Maria (30, active) e Ana (28, active)
-			check: func(t *testing.T, results []TestSQLEntity) {
-				for _, r := range results {
-					assert.True(t, r.Active)
-					assert.GreaterOrEqual(t, r.Age, 28)
-				}
-			},
-		},
-		{
-			name:    "deve aplicar paginação - página 1",
-			filter:  nil,
-			opts:    FindOptions{Page: 1, Limit: 2},
-			wantLen: 2,
-		},
-		{
-			name:    "deve aplicar paginação - página 2",
-			filter:  nil,
-			opts:    FindOptions{Page: 2, Limit: 2},
-			wantLen: 2,
-		},
-		{
-			name:    "deve aplicar paginação - página 3",
-			filter:  nil,
-			opts:    FindOptions{Page: 3, Limit: 2},
-			wantLen: 1,
-		},
-		{
-			name:    "deve retornar vazio quando filtro não encontra",
-			filter:  map[string]any{"name": "NaoExiste"},
-			opts:    FindOptions{},
-			wantLen: 0,
-		},
-		{
-			name:    "deve retornar vazio para página além dos resultados",
-			filter:  nil,
-			opts:    FindOptions{Page: 100, Limit: 10},
-			wantLen: 0,
-		},
+	input := make([]TestSQLEntity, 50)
+	for i := range input {
+		input[i] = TestSQLEntity{Name: fmt.Sprintf("Lote %02d", i), Age: i, Active: i%2 == 0, Score: float64(i) / 2}
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			results, err := store.FindAll(ctx, tt.filter, tt.opts)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
-
-			assert.NoError(t, err)
-			assert.Equal(t, tt.wantLen, len(results))
+	result, err := store.SaveMany(ctx, input)
+	assert.NoError(t, err)
+	assert.Len(t, result.InsertedIDs, len(input))
 
-			if tt.check != nil {
-				tt.check(t, results)
-			}
-		})
+	// Cada linha deve receber os próprios valores ao reutilizar o statement
+	for i, id := range result.InsertedIDs {
+		found, err := store.FindById(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, input[i].Name, found.Name)
+		assert.Equal(t, input[i].Age, found.Age)
+		assert.Equal(t, input[i].Active, found.Active)
+		assert.Equal(t, input[i].Score, found.Score)
 	}
 }
 
-func TestSQLFindAll_IsNullOperators(t *testing.T) {
-	db, err := sql.Open("sqlite3", ":memory:")
+func TestSQLSaveManyDetailed(t *testing.T) {
+	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	_, err = db.Exec(`
-		CREATE TABLE nullable_entities (
-			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
-			name TEXT,
-			age INTEGER
-		);
-		INSERT INTO nullable_entities (name, age) VALUES ('João', 25);
-		INSERT INTO nullable_entities (name, age) VALUES (NULL, 30);
-		INSERT INTO nullable_entities (name, age) VALUES ('Maria', NULL);
-	`)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "nullable_entities", "id", true)
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	t.Run("deve usar operador __is_null", func(t *testing.T) {
-		results, err := store.FindAll(ctx, map[string]any{"name__is_null": true}, FindOptions{})
+	t.Run("deve retornar um resultado com id por registro", func(t *testing.T) {
+		input := []TestSQLEntity{{Name: "Primeiro"}, {Name: "Segundo"}, {Name: "Terceiro"}}
+
+		results, err := store.SaveManyDetailed(ctx, input)
 		assert.NoError(t, err)
-		assert.Equal(t, 1, len(results))
+		assert.Len(t, results, len(input))
+
+		for i, result := range results {
+			assert.NotNil(t, result.InsertedID)
+
+			found, err := store.FindById(ctx, result.InsertedID)
+			assert.NoError(t, err)
+			assert.Equal(t, input[i].Name, found.Name)
+		}
 	})
 
-	t.Run("deve usar operador __is_not_null", func(t *testing.T) {
-		results, err := store.FindAll(ctx, map[string]any{"name__is_not_null": true}, FindOptions{})
+	t.Run("deve retornar nil para slice vazio", func(t *testing.T) {
+		results, err := store.SaveManyDetailed(ctx, []TestSQLEntity{})
 		assert.NoError(t, err)
-		assert.Equal(t, 2, len(results))
+		assert.Nil(t, results)
 	})
 }
 
-// ==================== TESTES ILIKE (CASE INSENSITIVE) ====================
-
-func TestSQLILike(t *testing.T) {
+func TestSQLSaveMany_SharedTimestamp(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -887,191 +888,153 @@ func TestSQLILike(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	// Setup: salva registros de teste com diferentes casos de capitalização
-	testDocs := []TestSQLEntity{
-		{Name: "João Silva", Age: 25, Active: true},
-		{Name: "MARIA SANTOS", Age: 30, Active: true},
-		{Name: "pedro oliveira", Age: 35, Active: false},
-		{Name: "Ana Paula Costa", Age: 28, Active: true},
-		{Name: "CARLOS EDUARDO", Age: 40, Active: false},
-		{Name: "Fernanda Lima", Age: 22, Active: true},
-		{Name: "roberto almeida", Age: 33, Active: true},
-	}
-	for _, doc := range testDocs {
-		_, _ = store.Save(ctx, &doc)
-	}
+	imported := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+	_, err = store.SaveMany(ctx, []TestSQLEntity{
+		{Name: "Ana", Age: 20},
+		{Name: "Bruno", Age: 21},
+		{Name: "Carla", Age: 22},
+		{Name: "Importado", Age: 23, CreatedAt: imported},
+	})
+	assert.NoError(t, err)
 
-	tests := []struct {
-		name    string
-		filter  map[string]any
-		wantLen int
-		check   func(*testing.T, []TestSQLEntity)
-	}{
-		{
-			name:    "deve encontrar com busca case-insensitive minúscula",
-			filter:  map[string]any{"name__ilike": "%joão%"},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "João Silva", results[0].Name)
-			},
-		},
-		{
-			name:    "deve encontrar com busca case-insensitive maiúscula",
-			filter:  map[string]any{"name__ilike": "%MARIA%"},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "MARIA SANTOS", results[0].Name)
-			},
-		},
-		{
-			name:    "deve encontrar com busca case-insensitive mixed case",
-			filter:  map[string]any{"name__ilike": "%PeDrO%"},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "pedro oliveira", results[0].Name)
-			},
-		},
-		{
-			name:    "deve encontrar múltiplos com wildcard no início",
-			filter:  map[string]any{"name__ilike": "%a%"},
-			wantLen: 7, // João, MARIA, Ana Paula Costa, CARLOS, Fernanda, roberto almeida, pedro oliveira não tem 'a'
-		},
-		{
-			name:    "deve encontrar com wildcard no fim",
-			filter:  map[string]any{"name__ilike": "joão%"},
-			wantLen: 1,
-		},
-		{
-			name:    "deve encontrar com wildcard no início",
-			filter:  map[string]any{"name__ilike": "%silva"},
-			wantLen: 1,
-		},
-		{
-			name:    "deve encontrar nome completo case-insensitive",
-			filter:  map[string]any{"name__ilike": "ana paula costa"},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "Ana Paula Costa", results[0].Name)
-			},
-		},
-		{
-			name:    "deve encontrar sobrenome case-insensitive",
-			filter:  map[string]any{"name__ilike": "%EDUARDO%"},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "CARLOS EDUARDO", results[0].Name)
-			},
-		},
-		{
-			name:    "deve retornar vazio quando não encontra",
-			filter:  map[string]any{"name__ilike": "%zzz%"},
-			wantLen: 0,
-		},
-		{
-			name:    "deve combinar ILIKE com outro filtro",
-			filter:  map[string]any{"name__ilike": "%a%", "active": true},
-			wantLen: 5, // João, MARIA, Ana Paula Costa, Fernanda, roberto almeida (todos ativos com 'a')
-		},
-		{
-			name:    "deve combinar ILIKE com operador numérico",
-			filter:  map[string]any{"name__ilike": "%silva%", "age__gte": 20},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "João Silva", results[0].Name)
-				assert.Equal(t, 25, results[0].Age)
-			},
-		},
-		{
-			name:    "deve funcionar com espaços e caracteres especiais",
-			filter:  map[string]any{"name__ilike": "%PAULA COSTA%"},
-			wantLen: 1,
-			check: func(t *testing.T, results []TestSQLEntity) {
-				assert.Equal(t, "Ana Paula Costa", results[0].Name)
-			},
-		},
-	}
+	t.Run("deve usar o mesmo instante para todas as linhas do lote", func(t *testing.T) {
+		found, err := store.FindAll(ctx, map[string]any{"name__in": []string{"Ana", "Bruno", "Carla"}}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, found, 3)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			results, err := store.FindAll(ctx, tt.filter, FindOptions{})
+		first := found[0].CreatedAt
+		assert.False(t, first.IsZero())
+		for _, e := range found {
+			assert.True(t, e.CreatedAt.Equal(first), "created_at divergente para %s", e.Name)
+			assert.True(t, e.UpdatedAt.Equal(first), "updated_at divergente para %s", e.Name)
+		}
+	})
+
+	t.Run("deve preservar created_at informado", func(t *testing.T) {
+		found, err := store.FindOne(ctx, map[string]any{"name": "Importado"})
+		assert.NoError(t, err)
+		assert.True(t, found.CreatedAt.Equal(imported))
+	})
+
+	t.Run("deve preencher os timestamps do Save como no SaveMany", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+
+		saved, err := store.Save(ctx, &TestSQLEntity{Name: "Avulso", Age: 24})
+		assert.NoError(t, err)
+		assert.True(t, saved.CreatedAt.After(before))
+		assert.True(t, saved.UpdatedAt.Equal(saved.CreatedAt))
+
+		_, err = store.SaveIgnoreDuplicates(ctx, []TestSQLEntity{{Name: "Sem Duplicados", Age: 25}})
+		assert.NoError(t, err)
+
+		for _, name := range []string{"Avulso", "Sem Duplicados", "Ana"} {
+			found, err := store.FindOne(ctx, map[string]any{"name": name})
 			assert.NoError(t, err)
-			assert.Equal(t, tt.wantLen, len(results), "Número de resultados não corresponde")
-			if tt.check != nil && len(results) > 0 {
-				tt.check(t, results)
-			}
-		})
-	}
+			assert.True(t, found.CreatedAt.After(before), "created_at não preenchido para %s", name)
+			assert.True(t, found.UpdatedAt.After(before), "updated_at não preenchido para %s", name)
+		}
+	})
+
+	t.Run("deve gravar o updated_at informado quando não gerenciado", func(t *testing.T) {
+		unmanaged := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithManagedTimestamps(true, false))
+
+		_, err := unmanaged.Save(ctx, &TestSQLEntity{Name: "Save Manual", UpdatedAt: imported})
+		assert.NoError(t, err)
+		_, err = unmanaged.SaveMany(ctx, []TestSQLEntity{{Name: "SaveMany Manual", UpdatedAt: imported}})
+		assert.NoError(t, err)
+
+		for _, name := range []string{"Save Manual", "SaveMany Manual"} {
+			found, err := unmanaged.FindOne(ctx, map[string]any{"name": name})
+			assert.NoError(t, err)
+			assert.True(t, found.UpdatedAt.Equal(imported), "updated_at alterado para %s", name)
+			assert.False(t, found.CreatedAt.IsZero())
+		}
+	})
 }
 
-// Teste específico para garantir que ILIKE funciona com FindOne
-func TestSQLFindOneILike(t *testing.T) {
+func TestSQLSaveMany_Checkpoint(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	_, err = db.Exec(`
+		CREATE TRIGGER reject_invalid BEFORE INSERT ON test_entities
+		WHEN NEW.name = 'inválido'
+		BEGIN SELECT RAISE(ABORT, 'registro inválido'); END;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithSaveCheckpoint(500))
 	ctx := context.Background()
 
-	// Setup
-	testDocs := []TestSQLEntity{
-		{Name: "UPPERCASE NAME", Age: 25, Active: true},
-		{Name: "lowercase name", Age: 30, Active: true},
-		{Name: "MiXeD CaSe NaMe", Age: 35, Active: false},
-	}
-	for _, doc := range testDocs {
-		_, _ = store.Save(ctx, &doc)
+	newEntities := func(n int) []TestSQLEntity {
+		entities := make([]TestSQLEntity, n)
+		for i := range entities {
+			entities[i] = TestSQLEntity{Name: fmt.Sprintf("Registro %d", i), Age: i}
+		}
+		return entities
 	}
 
-	tests := []struct {
-		name      string
-		filter    map[string]any
-		wantName  string
-		wantFound bool
-	}{
-		{
-			name:      "deve encontrar UPPERCASE com busca lowercase",
-			filter:    map[string]any{"name__ilike": "%uppercase%"},
-			wantName:  "UPPERCASE NAME",
-			wantFound: true,
-		},
-		{
-			name:      "deve encontrar lowercase com busca UPPERCASE",
-			filter:    map[string]any{"name__ilike": "%LOWERCASE%"},
-			wantName:  "lowercase name",
-			wantFound: true,
-		},
-		{
-			name:      "deve encontrar MiXeD com busca qualquer case",
-			filter:    map[string]any{"name__ilike": "%mixed case%"},
-			wantName:  "MiXeD CaSe NaMe",
-			wantFound: true,
-		},
-		{
-			name:      "deve retornar erro quando não encontra",
-			filter:    map[string]any{"name__ilike": "%inexistente%"},
-			wantFound: false,
-		},
-	}
+	t.Run("deve manter os lotes confirmados antes da falha", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := store.FindOne(ctx, tt.filter)
-			if tt.wantFound {
-				assert.NoError(t, err)
-				assert.NotNil(t, result)
-				assert.Equal(t, tt.wantName, result.Name)
-			} else {
-				assert.Error(t, err)
-				assert.Nil(t, result)
-			}
-		})
-	}
+		// A 1500ª linha falha no terceiro lote, que é descartado inteiro
+		entities := newEntities(2000)
+		entities[1499].Name = "inválido"
+
+		result, err := store.SaveMany(ctx, entities)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "registro inválido")
+		assert.Equal(t, int64(1000), result.CommittedCount)
+		assert.Equal(t, 1499, result.StoppedAt)
+		assert.Len(t, result.InsertedIDs, 1000)
+
+		count, err := store.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), *count)
+
+		has, err := store.HasWhere(ctx, map[string]any{"age": 999})
+		assert.NoError(t, err)
+		assert.True(t, has)
+
+		has, err = store.HasWhere(ctx, map[string]any{"age": 1000})
+		assert.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("deve reportar todos os registros quando não há falha", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		result, err := store.SaveMany(ctx, newEntities(1200))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1200), result.CommittedCount)
+		assert.Equal(t, 1200, result.StoppedAt)
+		assert.Len(t, result.InsertedIDs, 1200)
+	})
+
+	t.Run("deve desfazer tudo sem checkpoint", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		entities := newEntities(2000)
+		entities[1499].Name = "inválido"
+
+		atomic := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		_, err := atomic.SaveMany(ctx, entities)
+		assert.Error(t, err)
+
+		count, err := atomic.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), *count)
+	})
 }
 
-// Teste para verificar que ILIKE funciona com DeleteOne
-func TestSQLDeleteOneILike(t *testing.T) {
+// ==================== TESTES SAVE MANY NOT ORDERED ====================
+
+func TestSQLSaveManyNotOrdered(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1081,79 +1044,117 @@ func TestSQLDeleteOneILike(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	// Setup
-	testDoc := TestSQLEntity{Name: "TeStE CaSe InSeNsItIvE", Age: 25, Active: true}
-	_, _ = store.Save(ctx, &testDoc)
-
-	// Verifica que o registro existe
-	count, _ := store.Count(ctx, map[string]any{"name__ilike": "%teste%"})
-	assert.Equal(t, int64(1), *count)
-
-	// Deleta usando ILIKE
-	err = store.DeleteOne(ctx, map[string]any{"name__ilike": "%TESTE CASE%"})
-	assert.NoError(t, err)
+	entities := []TestSQLEntity{
+		{Name: "Doc 1"},
+		{Name: "Doc 2"},
+	}
 
-	// Verifica que foi deletado
-	count, _ = store.Count(ctx, map[string]any{"name__ilike": "%teste%"})
-	assert.Equal(t, int64(0), *count)
+	result, err := store.SaveManyNotOrdered(ctx, entities)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not implemented")
 }
 
-// Teste para verificar que ILIKE funciona com Count
-func TestSQLCountILike(t *testing.T) {
+// ==================== TESTES SAVE IGNORE DUPLICATES ====================
+
+func TestSQLSaveIgnoreDuplicates(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
+	_, err = db.Exec("CREATE UNIQUE INDEX idx_test_entities_name ON test_entities (name)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	// Setup
-	testDocs := []TestSQLEntity{
-		{Name: "TESTE UM", Age: 25, Active: true},
-		{Name: "teste dois", Age: 30, Active: true},
-		{Name: "TeSte TrÊs", Age: 35, Active: false},
-		{Name: "Outro Nome", Age: 40, Active: true},
-	}
-	for _, doc := range testDocs {
-		_, _ = store.Save(ctx, &doc)
-	}
+	existing, err := store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
+	assert.NoError(t, err)
+
+	t.Run("deve ignorar os duplicados e retornar apenas os ids inseridos", func(t *testing.T) {
+		result, err := store.SaveIgnoreDuplicates(ctx, []TestSQLEntity{
+			{Name: "Maria", Age: 30},
+			{Name: "João", Age: 99},
+			{Name: "Pedro", Age: 35},
+			{Name: "Maria", Age: 31},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.InsertedIDs, 2)
+		assert.NotContains(t, result.InsertedIDs, int64(existing.ID))
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(3), *count)
+
+		found, err := store.FindOne(ctx, map[string]any{"name": "João"})
+		assert.NoError(t, err)
+		assert.Equal(t, 25, found.Age)
+	})
+
+	t.Run("deve ignorar chave primária duplicada informada pelo cliente", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE manual_ignore (id INTEGER NOT NULL PRIMARY KEY, name TEXT NOT NULL)`)
+		assert.NoError(t, err)
+
+		manual := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "manual_ignore", "id", false)
+		_, err = manual.Save(ctx, &TestSQLEntityWithoutTimestamps{ID: 1, Name: "Original"})
+		assert.NoError(t, err)
+
+		result, err := manual.SaveIgnoreDuplicates(ctx, []TestSQLEntityWithoutTimestamps{
+			{ID: 1, Name: "Duplicado"},
+			{ID: 2, Name: "Novo"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []any{2}, result.InsertedIDs)
+
+		found, _ := manual.FindById(ctx, 1)
+		assert.Equal(t, "Original", found.Name)
+	})
+
+	t.Run("deve retornar nil para lista vazia", func(t *testing.T) {
+		result, err := store.SaveIgnoreDuplicates(ctx, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("deve falhar para driver sem suporte", func(t *testing.T) {
+		oracle := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverOracle, "test_entities", "id", true)
+		_, err := oracle.SaveIgnoreDuplicates(ctx, []TestSQLEntity{{Name: "Ana"}})
+		assert.ErrorContains(t, err, "não suportado")
+	})
+}
+
+func TestSQLInsertIgnoreQuery(t *testing.T) {
+	fields := []string{"name", "age"}
 
 	tests := []struct {
-		name      string
-		filter    map[string]any
-		wantCount int64
+		driver   enum.DatabaseDriver
+		opts     []Option
+		expected string
 	}{
-		{
-			name:      "deve contar todos com 'teste' (case insensitive)",
-			filter:    map[string]any{"name__ilike": "%teste%"},
-			wantCount: 3,
-		},
-		{
-			name:      "deve contar com busca UPPERCASE",
-			filter:    map[string]any{"name__ilike": "%TESTE%"},
-			wantCount: 3,
-		},
-		{
-			name:      "deve contar zero quando não encontra",
-			filter:    map[string]any{"name__ilike": "%xyz%"},
-			wantCount: 0,
-		},
+		{enum.DatabaseDriverMysql, nil, "INSERT IGNORE INTO users (name, age) VALUES (?, ?)"},
+		{enum.DatabaseDriverMariaDB, nil, "INSERT IGNORE INTO users (name, age) VALUES (?, ?)"},
+		{enum.DatabaseDriverSqlite, nil, "INSERT INTO users (name, age) VALUES (?, ?) ON CONFLICT DO NOTHING"},
+		{enum.DatabaseDriverPostgres, []Option{WithPKMode(PKModeServerDefault)}, "INSERT INTO users (name, age) VALUES (?, ?) ON CONFLICT DO NOTHING RETURNING id"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			count, err := store.Count(ctx, tt.filter)
+		t.Run(string(tt.driver), func(t *testing.T) {
+			s := NewSQLStore[TestSQLEntity](nil, tt.driver, "users", "id", true, tt.opts...).(*SQLStore[TestSQLEntity])
+			query, err := s.insertIgnoreQuery("users", fields)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.wantCount, *count)
+			assert.Equal(t, tt.expected, query)
 		})
 	}
 }
 
-// ==================== TESTES COUNT ====================
+// ==================== TESTES FIND BY ID ====================
 
-func TestSQLCount(t *testing.T) {
+func TestSQLFindById(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1163,72 +1164,65 @@ func TestSQLCount(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	// Setup
-	testDocs := []TestSQLEntity{
-		{Name: "João", Age: 25, Active: true},
-		{Name: "Maria", Age: 30, Active: true},
-		{Name: "Pedro", Age: 35, Active: false},
-	}
-	for _, doc := range testDocs {
-		_, _ = store.Save(ctx, &doc)
+	// Setup: salva registros de teste
+	testDoc := &TestSQLEntity{
+		Name:   "Documento Teste",
+		Age:    25,
+		Active: true,
+		Score:  88.5,
 	}
+	saved, _ := store.Save(ctx, testDoc)
 
 	tests := []struct {
-		name      string
-		filter    map[string]any
-		wantCount int64
-		wantErr   bool
+		name    string
+		id      any
+		check   func(*testing.T, *TestSQLEntity)
+		wantErr bool
 	}{
 		{
-			name:      "deve contar todos os registros",
-			filter:    map[string]any{},
-			wantCount: 3,
-		},
-		{
-			name:      "deve contar com filtro booleano",
-			filter:    map[string]any{"active": true},
-			wantCount: 2,
-		},
-		{
-			name:      "deve contar com operador __gt",
-			filter:    map[string]any{"age__gt": 25},
-			wantCount: 2,
-		},
-		{
-			name:      "deve retornar zero quando não encontra",
-			filter:    map[string]any{"name": "NaoExiste"},
-			wantCount: 0,
+			name: "deve encontrar registro existente",
+			id:   saved.ID,
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, saved.ID, result.ID)
+				assert.Equal(t, "Documento Teste", result.Name)
+				assert.Equal(t, 25, result.Age)
+				assert.True(t, result.Active)
+				assert.Equal(t, 88.5, result.Score)
+			},
 		},
 		{
-			name:      "deve contar com múltiplos filtros",
-			filter:    map[string]any{"active": true, "age__gte": 30},
-			wantCount: 1,
+			name:    "deve retornar erro para ID inexistente",
+			id:      99999,
+			wantErr: true,
 		},
 		{
-			name:      "deve contar com operador __in",
-			filter:    map[string]any{"name__in": []string{"João", "Maria"}},
-			wantCount: 2,
+			name:    "deve retornar erro para ID zero",
+			id:      0,
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			count, err := store.Count(ctx, tt.filter)
+			result, err := store.FindById(ctx, tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Nil(t, result)
 				return
 			}
 
 			assert.NoError(t, err)
-			assert.Equal(t, tt.wantCount, *count)
+			assert.NotNil(t, result)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
 		})
 	}
 }
 
-// ==================== TESTES HAS ====================
-
-func TestSQLHas(t *testing.T) {
+func TestSQLFindByIdInto(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1238,191 +1232,389 @@ func TestSQLHas(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	// Setup
-	saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Existe"})
+	first, _ := store.Save(ctx, &TestSQLEntity{Name: "João", Age: 30, Active: true, Score: 9.5})
+	second, _ := store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 25})
 
-	tests := []struct {
-		name string
-		id   any
-		want bool
-	}{
-		{
-			name: "deve retornar true para registro existente",
-			id:   saved.ID,
-			want: true,
-		},
-		{
-			name: "deve retornar false para registro inexistente",
-			id:   99999,
-			want: false,
-		},
-		{
-			name: "deve retornar false para ID zero",
-			id:   0,
-			want: false,
-		},
-		{
-			name: "deve retornar false para ID negativo",
-			id:   -1,
-			want: false,
-		},
+	var dst TestSQLEntity
+
+	t.Run("deve preencher a entidade informada", func(t *testing.T) {
+		err := store.FindByIdInto(ctx, first.ID, &dst)
+		assert.NoError(t, err)
+		assert.Equal(t, first.ID, dst.ID)
+		assert.Equal(t, "João", dst.Name)
+		assert.True(t, dst.Active)
+	})
+
+	t.Run("deve reutilizar a entidade sem manter valores da busca anterior", func(t *testing.T) {
+		err := store.FindByIdInto(ctx, second.ID, &dst)
+		assert.NoError(t, err)
+		assert.Equal(t, second.ID, dst.ID)
+		assert.Equal(t, "Maria", dst.Name)
+		assert.Equal(t, 25, dst.Age)
+		assert.False(t, dst.Active)
+		assert.Zero(t, dst.Score)
+	})
+
+	t.Run("deve manter a entidade quando não encontra", func(t *testing.T) {
+		err := store.FindByIdInto(ctx, 999, &dst)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, "Maria", dst.Name)
+
+		_, err = store.FindById(ctx, 999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestSQLWithReadTransform(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer db.Close()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := store.Has(ctx, tt.id)
-			assert.Equal(t, tt.want, result)
-		})
+	redact := func(e *TestSQLEntity) {
+		e.Name = strings.ToUpper(e.Name)
 	}
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithReadTransform(redact))
+	ctx := context.Background()
+
+	saved, err := store.Save(ctx, &TestSQLEntity{Name: "joão", Age: 30})
+	assert.NoError(t, err)
+	_, err = store.Save(ctx, &TestSQLEntity{Name: "maria", Age: 25})
+	assert.NoError(t, err)
+
+	t.Run("deve transformar no FindById", func(t *testing.T) {
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "JOÃO", found.Name)
+	})
+
+	t.Run("deve transformar no FindOne", func(t *testing.T) {
+		found, err := store.FindOne(ctx, map[string]any{"age": 25})
+		assert.NoError(t, err)
+		assert.Equal(t, "MARIA", found.Name)
+	})
+
+	t.Run("deve transformar cada registro do FindAll", func(t *testing.T) {
+		found, err := store.FindAll(ctx, nil, FindOptions{SortBy: "age", OrderBy: "asc"})
+		assert.NoError(t, err)
+		assert.Len(t, found, 2)
+		assert.Equal(t, "MARIA", found[0].Name)
+		assert.Equal(t, "JOÃO", found[1].Name)
+	})
+
+	t.Run("deve entrar em pânico com transformação de outro tipo", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true,
+				WithReadTransform(func(*TestSQLEntityWithDefaults) {}))
+		})
+		assert.Panics(t, func() {
+			store.Scoped(WithReadTransform(func(*TestSQLEntityWithDefaults) {}))
+		})
+	})
 }
 
-// ==================== TESTES UPDATE ====================
+func TestSQLFindAll_FilterExpression(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
 
-func TestSQLUpdate(t *testing.T) {
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for _, e := range nestedTestEntities {
+		_, err := store.Save(ctx, &TestSQLEntity{Name: e.Name, Age: e.Age, Active: e.Active, Score: e.Score})
+		assert.NoError(t, err)
+	}
+
+	t.Run("deve gerar cláusulas entre parênteses", func(t *testing.T) {
+		s := store.(*SQLStore[TestSQLEntity])
+		whereClause, values, err := s.buildWhereClause(Where(nestedTestFilter()))
+		assert.NoError(t, err)
+
+		assert.Equal(t, " WHERE ((active = ?) AND ((age >= ?) OR ((name LIKE ?) AND (score >= ?))) AND NOT (score < ?))", whereClause)
+		assert.Equal(t, []any{1, 30, "A%", 80, 75}, values)
+	})
+
+	t.Run("deve filtrar pela expressão aninhada", func(t *testing.T) {
+		results, err := store.FindAll(ctx, Where(nestedTestFilter()), FindOptions{SortBy: "name", OrderBy: "ASC"})
+		assert.NoError(t, err)
+
+		names := make([]string, len(results))
+		for i, r := range results {
+			names[i] = r.Name
+		}
+		assert.Equal(t, []string{"Ana", "Maria"}, names)
+	})
+
+	t.Run("deve combinar a expressão com as demais chaves do mapa", func(t *testing.T) {
+		filter := Where(nestedTestFilter())
+		filter["name"] = "Ana"
+
+		total, err := store.Count(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *total)
+	})
+
+	t.Run("deve tratar OR vazio como nenhum registro e AND vazio como todos", func(t *testing.T) {
+		total, err := store.Count(ctx, Where(Or()))
+		assert.NoError(t, err)
+		assert.Zero(t, *total)
+
+		total, err = store.Count(ctx, Where(And()))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(nestedTestEntities)), *total)
+	})
+}
+
+func TestSQLFindAllRaw(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
+	_, err = db.Exec(`CREATE VIEW age_report AS SELECT id, name, age * 12 AS age_months FROM test_entities`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	tests := []struct {
-		name    string
-		setup   func() *TestSQLEntity
-		update  func(*TestSQLEntity) *TestSQLEntity
-		check   func(*testing.T, *TestSQLEntity)
-		wantErr bool
-	}{
-		{
-			name: "deve atualizar campo string",
-			setup: func() *TestSQLEntity {
-				doc := &TestSQLEntity{Name: "Original", Age: 25}
-				store.Save(ctx, doc)
-				return doc
-			},
-			update: func(e *TestSQLEntity) *TestSQLEntity {
-				e.Name = "Atualizado"
-				return e
-			},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.Equal(t, "Atualizado", result.Name)
-				assert.Equal(t, 25, result.Age)
-			},
-		},
-		{
-			name: "deve atualizar campo numérico",
-			setup: func() *TestSQLEntity {
-				doc := &TestSQLEntity{Name: "Teste", Age: 25, Score: 80}
-				store.Save(ctx, doc)
-				return doc
-			},
-			update: func(e *TestSQLEntity) *TestSQLEntity {
-				e.Age = 30
-				e.Score = 95.5
-				return e
-			},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				found, _ := store.FindById(ctx, result.ID)
-				assert.Equal(t, 30, found.Age)
-				assert.Equal(t, 95.5, found.Score)
-			},
-		},
-		{
-			name: "deve atualizar campo booleano",
-			setup: func() *TestSQLEntity {
-				doc := &TestSQLEntity{Name: "Teste", Active: false}
-				store.Save(ctx, doc)
-				return doc
-			},
-			update: func(e *TestSQLEntity) *TestSQLEntity {
-				e.Active = true
-				return e
-			},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				found, _ := store.FindById(ctx, result.ID)
-				assert.True(t, found.Active)
-			},
-		},
-		{
-			name: "deve atualizar UpdatedAt automaticamente",
-			setup: func() *TestSQLEntity {
-				doc := &TestSQLEntity{Name: "Teste"}
-				store.Save(ctx, doc)
-				time.Sleep(10 * time.Millisecond)
-				return doc
-			},
-			update: func(e *TestSQLEntity) *TestSQLEntity {
-				e.Name = "Atualizado"
-				return e
-			},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				assert.True(t, time.Since(result.UpdatedAt) < time.Minute)
-			},
-		},
-		{
-			name: "deve atualizar múltiplos campos",
-			setup: func() *TestSQLEntity {
-				doc := &TestSQLEntity{Name: "Original", Age: 20, Score: 50, Active: false}
-				store.Save(ctx, doc)
-				return doc
-			},
-			update: func(e *TestSQLEntity) *TestSQLEntity {
-				e.Name = "Atualizado"
-				e.Age = 30
-				e.Score = 100
-				e.Active = true
-				return e
-			},
-			check: func(t *testing.T, result *TestSQLEntity) {
-				found, _ := store.FindById(ctx, result.ID)
-				assert.Equal(t, "Atualizado", found.Name)
-				assert.Equal(t, 30, found.Age)
-				assert.Equal(t, 100.0, found.Score)
-				assert.True(t, found.Active)
-			},
-		},
-		{
-			name: "deve retornar erro para registro inexistente",
-			setup: func() *TestSQLEntity {
-				return &TestSQLEntity{ID: 99999, Name: "Teste"}
-			},
-			update: func(e *TestSQLEntity) *TestSQLEntity {
-				return e
-			},
-			wantErr: true,
-		},
+	for _, e := range []TestSQLEntity{{Name: "João", Age: 25}, {Name: "Maria", Age: 30}, {Name: "Pedro", Age: 35}} {
+		_, err := store.Save(ctx, &e)
+		assert.NoError(t, err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Limpa tabela
-			db.Exec("DELETE FROM test_entities")
+	t.Run("deve retornar as linhas como mapas coluna→valor", func(t *testing.T) {
+		rows, err := store.(*SQLStore[TestSQLEntity]).FindAllRaw(ctx, map[string]any{"age__gte": 30}, FindOptions{SortBy: "age", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "Pedro", rows[0]["name"])
+		assert.Equal(t, int64(35), rows[0]["age"])
+		assert.Contains(t, rows[0], "created_at")
+	})
 
-			entity := tt.setup()
-			toUpdate := tt.update(entity)
+	t.Run("deve ler colunas de uma view sem struct correspondente", func(t *testing.T) {
+		view := NewSQLStore[struct{}](db, enum.DatabaseDriverSqlite, "age_report", "id", true)
 
-			result, err := store.Update(ctx, toUpdate)
+		rows, err := view.(*SQLStore[struct{}]).FindAllRaw(ctx, map[string]any{"name": "Maria"}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]any{{"id": int64(2), "name": "Maria", "age_months": int64(360)}}, rows)
+	})
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
+	t.Run("deve respeitar projeção e paginação", func(t *testing.T) {
+		rows, err := store.(*SQLStore[TestSQLEntity]).FindAllRaw(ctx, nil, FindOptions{Fields: []string{"name"}, SortBy: "name", OrderBy: "ASC", Limit: 1, Page: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]any{{"name": "Maria"}}, rows)
+	})
+}
 
-			assert.NoError(t, err)
-			assert.NotNil(t, result)
+func TestNewSQLStoreWithConfig(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
 
-			if tt.check != nil {
-				tt.check(t, result)
-			}
+	store, err := NewSQLStoreWithConfig[TestSQLEntity](SQLConfig{
+		DB:            db,
+		Driver:        enum.DatabaseDriverSqlite,
+		TableName:     "test_entities",
+		PrimaryKey:    "id",
+		Autoincrement: true,
+		Pool:          PoolConfig{MaxOpenConns: 1, MaxIdleConns: 1, ConnMaxIdleTime: time.Minute},
+	})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Equal(t, 1, db.Stats().MaxOpenConnections)
+
+	saved, err := store.Save(ctx, &TestSQLEntity{Name: "João"})
+	assert.NoError(t, err)
+	assert.True(t, store.Has(ctx, saved.ID))
+
+	t.Run("deve manter a configuração do DB para valores zero", func(t *testing.T) {
+		_, err := NewSQLStoreWithConfig[TestSQLEntity](SQLConfig{DB: db, Driver: enum.DatabaseDriverSqlite, TableName: "test_entities", PrimaryKey: "id"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, db.Stats().MaxOpenConnections)
+	})
+
+	t.Run("deve retornar erro sem DB", func(t *testing.T) {
+		store, err := NewSQLStoreWithConfig[TestSQLEntity](SQLConfig{
+			Driver:     enum.DatabaseDriverSqlite,
+			TableName:  "test_entities",
+			PrimaryKey: "id",
+			Pool:       PoolConfig{MaxOpenConns: 1},
 		})
+		assert.Error(t, err)
+		assert.Nil(t, store)
+	})
+}
+
+func TestSQLFindAll_MaxLimit(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for i := range 3 {
+		_, err := store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Registro %d", i)})
+		assert.NoError(t, err)
 	}
+
+	t.Run("deve rejeitar limite acima do padrão", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{Limit: math.MaxInt64})
+		assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+		_, err = store.Search(ctx, "Registro", []string{"name"}, FindOptions{Limit: DefaultMaxLimit + 1})
+		assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+		_, err = store.FindLast(ctx, math.MaxInt64, nil, "id")
+		assert.ErrorIs(t, err, ErrLimitTooLarge)
+	})
+
+	t.Run("deve aceitar limite razoável e sem paginação", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		results, err = store.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	t.Run("deve usar o limite configurado", func(t *testing.T) {
+		limited := store.Scoped(WithMaxLimit(2))
+
+		_, err := limited.FindAll(ctx, nil, FindOptions{Limit: 3})
+		assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+		results, err := limited.FindAll(ctx, nil, FindOptions{Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("deve permitir desativar a verificação", func(t *testing.T) {
+		results, err := store.Scoped(WithMaxLimit(0)).FindAll(ctx, nil, FindOptions{Limit: math.MaxInt32})
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
 }
 
-// ==================== TESTES UPDATE MANY ====================
+// recordingLogger guarda as mensagens registradas, com os campos do logger da requisição
+type recordingLogger struct {
+	fields  []any
+	entries *[]string
+}
 
-func TestSQLUpdateMany(t *testing.T) {
+func (l recordingLogger) record(level, msg string, args ...any) {
+	*l.entries = append(*l.entries, fmt.Sprint(append([]any{level, msg}, append(l.fields, args...)...)...))
+}
+
+func (l recordingLogger) Debug(msg string, args ...any) { l.record("DEBUG", msg, args...) }
+func (l recordingLogger) Error(msg string, args ...any) { l.record("ERROR", msg, args...) }
+
+func TestSQLWithLoggerFromContext(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type loggerKey struct{}
+	var entries []string
+
+	fromContext := func(ctx context.Context) Logger {
+		logger, _ := ctx.Value(loggerKey{}).(Logger)
+		return logger
+	}
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithLoggerFromContext(fromContext))
+	ctx := context.WithValue(context.Background(), loggerKey{}, recordingLogger{fields: []any{"request_id", "req-42"}, entries: &entries})
+
+	t.Run("deve registrar os comandos com os campos da requisição", func(t *testing.T) {
+		_, err := store.Save(ctx, &TestSQLEntity{Name: "João"})
+		assert.NoError(t, err)
+
+		_, err = store.FindAll(ctx, map[string]any{"name": "João"}, FindOptions{})
+		assert.NoError(t, err)
+
+		assert.Len(t, entries, 2)
+		assert.Contains(t, entries[0], "INSERT INTO test_entities")
+		assert.Contains(t, entries[1], "SELECT")
+		for _, entry := range entries {
+			assert.True(t, strings.HasPrefix(entry, "DEBUG"), entry)
+			assert.Contains(t, entry, "req-42")
+			assert.Contains(t, entry, "duration")
+		}
+	})
+
+	t.Run("deve registrar falhas e comandos em transação", func(t *testing.T) {
+		entries = nil
+
+		_, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"name": "João"}, Fields: map[string]any{"coluna_inexistente": 1}}})
+		assert.Error(t, err)
+
+		assert.Len(t, entries, 1)
+		assert.True(t, strings.HasPrefix(entries[0], "ERROR"), entries[0])
+		assert.Contains(t, entries[0], "UPDATE test_entities")
+	})
+
+	t.Run("deve registrar as execuções dos statements preparados", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TRIGGER rejeita_falha BEFORE INSERT ON test_entities
+			WHEN NEW.name = 'Falha' BEGIN SELECT RAISE(ABORT, 'nome rejeitado'); END`)
+		assert.NoError(t, err)
+		entries = nil
+
+		_, err = store.FindOne(ctx, map[string]any{"name": "João"})
+		assert.NoError(t, err)
+
+		_, err = store.SaveMany(ctx, []TestSQLEntity{{Name: "Ana"}, {Name: "Falha"}})
+		assert.Error(t, err)
+
+		assert.Len(t, entries, 3)
+		assert.True(t, strings.HasPrefix(entries[0], "DEBUG"), entries[0])
+		assert.Contains(t, entries[0], "SELECT")
+		assert.True(t, strings.HasPrefix(entries[1], "DEBUG"), entries[1])
+		assert.Contains(t, entries[1], "INSERT INTO test_entities")
+		assert.True(t, strings.HasPrefix(entries[2], "ERROR"), entries[2])
+		assert.Contains(t, entries[2], "INSERT INTO test_entities")
+		assert.Contains(t, entries[2], "nome rejeitado")
+	})
+
+	t.Run("deve registrar o erro da consulta no QueryRowContext", func(t *testing.T) {
+		entries = nil
+		conn := loggingConn{sqlConn: db, logger: fromContext}
+
+		var count int
+		assert.NoError(t, conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM test_entities").Scan(&count))
+		assert.Error(t, conn.QueryRowContext(ctx, "SELECT * FROM tabela_inexistente").Scan(&count))
+
+		assert.Len(t, entries, 2)
+		assert.True(t, strings.HasPrefix(entries[0], "DEBUG"), entries[0])
+		assert.True(t, strings.HasPrefix(entries[1], "ERROR"), entries[1])
+		assert.Contains(t, entries[1], "tabela_inexistente")
+	})
+
+	t.Run("não deve registrar sem logger no contexto", func(t *testing.T) {
+		entries = nil
+
+		_, err := store.FindAll(context.Background(), nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+// ==================== TESTES FIND ONE ====================
+
+func TestSQLFindOne(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1432,226 +1624,145 @@ func TestSQLUpdateMany(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
+	// Setup: salva registros de teste
+	testDocs := []TestSQLEntity{
+		{Name: "João Silva", Age: 25, Active: true, Score: 80},
+		{Name: "Maria Santos", Age: 30, Active: true, Score: 90},
+		{Name: "Pedro Costa", Age: 35, Active: false, Score: 70},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
 	tests := []struct {
 		name    string
-		setup   func()
-		input   []EntityFieldsToUpdate
-		check   func(*testing.T, *BulkWriteResult)
+		filter  map[string]interface{}
+		check   func(*testing.T, *TestSQLEntity)
 		wantErr bool
-		errMsg  string
 	}{
 		{
-			name: "deve atualizar um único registro",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 25})
+			name:   "deve encontrar registro com filtro simples",
+			filter: map[string]interface{}{"name": "João Silva"},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "João Silva", result.Name)
+				assert.Equal(t, 25, result.Age)
+				assert.True(t, result.Active)
+				assert.Equal(t, 80.0, result.Score)
 			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name": "Original"},
-					Fields: map[string]any{"name": "Atualizado"},
-				},
+		},
+		{
+			name:   "deve encontrar registro com filtro booleano",
+			filter: map[string]interface{}{"active": false},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Pedro Costa", result.Name)
+				assert.False(t, result.Active)
 			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(1), result.ModifiedCount)
-
-				records, _ := store.FindAll(ctx, map[string]any{"name": "Atualizado"}, FindOptions{})
-				assert.Equal(t, 1, len(records))
+		},
+		{
+			name:   "deve encontrar registro com múltiplos filtros",
+			filter: map[string]interface{}{"active": true, "age": 30},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Maria Santos", result.Name)
+				assert.Equal(t, 30, result.Age)
+				assert.True(t, result.Active)
 			},
 		},
 		{
-			name: "deve atualizar múltiplos registros com filtros diferentes",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Pedro", Age: 35})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name": "João"},
-					Fields: map[string]any{"name": "João Atualizado"},
-				},
-				{
-					Filter: map[string]any{"name": "Maria"},
-					Fields: map[string]any{"name": "Maria Atualizada"},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(2), result.ModifiedCount)
-
-				records, _ := store.FindAll(ctx, map[string]any{"name": "Pedro"}, FindOptions{})
-				assert.Equal(t, 1, len(records))
+			name:   "deve encontrar registro com operador __gt",
+			filter: map[string]interface{}{"age__gt": 30},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Pedro Costa", result.Name)
+				assert.Greater(t, result.Age, 30)
 			},
 		},
 		{
-			name: "deve atualizar vários registros com mesmo filtro",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Grupo A", Active: true})
-				store.Save(ctx, &TestSQLEntity{Name: "Grupo A", Active: true})
-				store.Save(ctx, &TestSQLEntity{Name: "Grupo B", Active: false})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"active": true},
-					Fields: map[string]any{"name": "Grupo A Atualizado"},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(2), result.ModifiedCount)
-
-				records, _ := store.FindAll(ctx, map[string]any{"name": "Grupo A Atualizado"}, FindOptions{})
-				assert.Equal(t, 2, len(records))
+			name:   "deve encontrar registro com operador __gte",
+			filter: map[string]interface{}{"age__gte": 30},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.GreaterOrEqual(t, result.Age, 30)
 			},
 		},
 		{
-			name: "deve atualizar updated_at automaticamente",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original"})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name": "Original"},
-					Fields: map[string]any{"name": "Atualizado"},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				records, _ := store.FindAll(ctx, map[string]any{"name": "Atualizado"}, FindOptions{})
-				assert.True(t, time.Since(records[0].UpdatedAt) < time.Minute)
+			name:   "deve encontrar registro com operador __lt",
+			filter: map[string]interface{}{"score__lt": 75},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Pedro Costa", result.Name)
+				assert.Less(t, result.Score, 75.0)
 			},
 		},
 		{
-			name: "deve usar operador __like no filtro",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João Silva", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "João Santos", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria Silva", Age: 35})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name__like": "João%"},
-					Fields: map[string]any{"active": true},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(2), result.ModifiedCount)
+			name:   "deve encontrar registro com operador __lte",
+			filter: map[string]interface{}{"age__lte": 25},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "João Silva", result.Name)
+				assert.LessOrEqual(t, result.Age, 25)
 			},
 		},
 		{
-			name: "deve usar operador __in no filtro",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Registro 1", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "Registro 2", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Registro 3", Age: 35})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"age__in": []int{25, 35}},
-					Fields: map[string]any{"active": true},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(2), result.ModifiedCount)
+			name:   "deve encontrar registro com operador __like",
+			filter: map[string]interface{}{"name__like": "%Silva%"},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "João Silva", result.Name)
+				assert.Contains(t, result.Name, "Silva")
 			},
 		},
 		{
-			name: "deve usar operador __gte no filtro",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Jovem", Age: 20})
-				store.Save(ctx, &TestSQLEntity{Name: "Adulto", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Senior", Age: 50})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"age__gte": 30},
-					Fields: map[string]any{"active": true},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(2), result.ModifiedCount)
+			name:   "deve encontrar registro com operador __like no início",
+			filter: map[string]interface{}{"name__like": "Maria%"},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Maria Santos", result.Name)
 			},
 		},
 		{
-			name:    "deve retornar erro quando slice vazio",
-			setup:   func() {},
-			input:   []EntityFieldsToUpdate{},
-			wantErr: true,
-			errMsg:  "nenhum update fornecido",
+			name:   "deve encontrar registro com operador __in com []string",
+			filter: map[string]interface{}{"name__in": []string{"Maria Santos", "Não Existe"}},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Maria Santos", result.Name)
+			},
 		},
 		{
-			name: "deve retornar erro quando filtro vazio",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original"})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{},
-					Fields: map[string]any{"name": "Atualizado"},
-				},
+			name:   "deve encontrar registro com operador __in com []int",
+			filter: map[string]interface{}{"age__in": []int{25, 99}},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, 25, result.Age)
 			},
+		},
+		{
+			name:    "deve retornar erro quando não encontra registro",
+			filter:  map[string]interface{}{"name": "Não Existe"},
 			wantErr: true,
-			errMsg:  "filtro é obrigatório para update 0",
 		},
 		{
-			name: "deve retornar erro quando campos vazios",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original"})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name": "Original"},
-					Fields: map[string]any{},
-				},
-			},
+			name:    "deve retornar erro quando filtro não corresponde",
+			filter:  map[string]interface{}{"age": 999},
 			wantErr: true,
-			errMsg:  "campos para atualização são obrigatórios para update 0",
 		},
 		{
-			name: "deve retornar zero quando filtro não encontra",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original"})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name": "NaoExiste"},
-					Fields: map[string]any{"name": "Atualizado"},
-				},
-			},
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(0), result.ModifiedCount)
+			name:   "deve encontrar registro com filtro vazio (retorna primeiro)",
+			filter: map[string]interface{}{},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.NotNil(t, result)
+				assert.NotEmpty(t, result.Name)
 			},
 		},
 		{
-			name: "deve fazer rollback em caso de erro no meio da transação",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original 1"})
-				store.Save(ctx, &TestSQLEntity{Name: "Original 2"})
-			},
-			input: []EntityFieldsToUpdate{
-				{
-					Filter: map[string]any{"name": "Original 1"},
-					Fields: map[string]any{"name": "Atualizado 1"},
-				},
-				{
-					Filter: map[string]any{},
-					Fields: map[string]any{"name": "Atualizado 2"},
-				},
+			name:   "deve encontrar por ID numérico",
+			filter: map[string]interface{}{"id": 1},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, 1, result.ID)
+				assert.Equal(t, "João Silva", result.Name)
 			},
-			wantErr: true,
-			errMsg:  "filtro é obrigatório para update 1",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db.Exec("DELETE FROM test_entities")
-			tt.setup()
-
-			result, err := store.UpdateMany(ctx, tt.input)
+			result, err := store.FindOne(ctx, tt.filter)
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				if tt.errMsg != "" {
-					assert.Contains(t, err.Error(), tt.errMsg)
-				}
+				assert.Nil(t, result)
+				assert.Contains(t, err.Error(), "documento não encontrado")
 				return
 			}
 
@@ -1664,10 +1775,52 @@ func TestSQLUpdateMany(t *testing.T) {
 		})
 	}
 }
+func TestSQLFindOne_Sorted(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
 
-// ==================== TESTES UPSERT ====================
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
 
-func TestSQLUpsert(t *testing.T) {
+	testDocs := []TestSQLEntity{
+		{Name: "João Silva", Age: 25, Active: true, Score: 80},
+		{Name: "Maria Santos", Age: 30, Active: true, Score: 90},
+		{Name: "Pedro Costa", Age: 35, Active: false, Score: 70},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	t.Run("deve retornar o registro de maior score", func(t *testing.T) {
+		result, err := store.FindOne(ctx, nil, FindOptions{SortBy: "score", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Maria Santos", result.Name)
+	})
+
+	t.Run("deve retornar o registro de menor idade", func(t *testing.T) {
+		result, err := store.FindOne(ctx, nil, FindOptions{SortBy: "age", OrderBy: "ASC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "João Silva", result.Name)
+	})
+
+	t.Run("deve combinar filtro e ordenação", func(t *testing.T) {
+		result, err := store.FindOne(ctx, map[string]any{"active": true}, FindOptions{SortBy: "age", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Maria Santos", result.Name)
+	})
+
+	t.Run("deve rejeitar campo de ordenação não mapeado", func(t *testing.T) {
+		_, err := store.FindOne(ctx, nil, FindOptions{SortBy: "age; DROP TABLE test_entities"})
+		assert.ErrorIs(t, err, ErrInvalidSortField)
+	})
+}
+
+// ==================== TESTES FIND ALL ====================
+
+func TestSQLFindAll(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1677,57 +1830,214 @@ func TestSQLUpsert(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
+	// Setup: salva registros de teste
+	testDocs := []TestSQLEntity{
+		{Name: "João", Age: 25, Active: true, Score: 80},
+		{Name: "Maria", Age: 30, Active: true, Score: 90},
+		{Name: "Pedro", Age: 35, Active: false, Score: 70},
+		{Name: "Ana", Age: 28, Active: true, Score: 85},
+		{Name: "Carlos", Age: 40, Active: false, Score: 75},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
 	tests := []struct {
 		name    string
-		setup   func()
-		input   *TestSQLEntity
-		filters []StoreUpsertFilter
-		check   func(*testing.T, *UpdateResult)
+		filter  map[string]any
+		opts    FindOptions
+		wantLen int
+		check   func(*testing.T, []TestSQLEntity)
 		wantErr bool
 	}{
 		{
-			name:  "deve inserir novo registro quando não existe",
-			setup: func() {},
-			input: &TestSQLEntity{
-				Name:   "Novo Registro",
-				Age:    25,
-				Active: true,
+			name:    "deve retornar todos os registros sem filtro",
+			filter:  nil,
+			opts:    FindOptions{},
+			wantLen: 5,
+		},
+		{
+			name:    "deve retornar todos com filtro vazio",
+			filter:  map[string]any{},
+			opts:    FindOptions{},
+			wantLen: 5,
+		},
+		{
+			name:    "deve filtrar por campo booleano true",
+			filter:  map[string]any{"active": true},
+			opts:    FindOptions{},
+			wantLen: 3,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.True(t, r.Active)
+				}
 			},
-			filters: nil,
-			check: func(t *testing.T, result *UpdateResult) {
-				assert.Equal(t, int64(1), result.UpsertedCount)
-
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
+		},
+		{
+			name:    "deve filtrar por campo booleano false",
+			filter:  map[string]any{"active": false},
+			opts:    FindOptions{},
+			wantLen: 2,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.False(t, r.Active)
+				}
 			},
 		},
 		{
-			name: "deve atualizar registro existente (SQLite usa INSERT OR REPLACE)",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 25})
+			name:    "deve filtrar por campo string",
+			filter:  map[string]any{"name": "João"},
+			opts:    FindOptions{},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "João", results[0].Name)
 			},
-			input: &TestSQLEntity{
-				ID:   1,
-				Name: "Atualizado",
-				Age:  30,
+		},
+		{
+			name:    "deve usar operador __gt",
+			filter:  map[string]any{"age__gt": 30},
+			opts:    FindOptions{},
+			wantLen: 2,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.Greater(t, r.Age, 30)
+				}
 			},
-			filters: nil,
-			check: func(t *testing.T, result *UpdateResult) {
-				assert.Equal(t, int64(1), result.UpsertedCount)
-
-				found, _ := store.FindById(ctx, 1)
-				assert.Equal(t, "Atualizado", found.Name)
-				assert.Equal(t, 30, found.Age)
+		},
+		{
+			name:    "deve usar operador __gte",
+			filter:  map[string]any{"age__gte": 30},
+			opts:    FindOptions{},
+			wantLen: 3,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.GreaterOrEqual(t, r.Age, 30)
+				}
+			},
+		},
+		{
+			name:    "deve usar operador __lt",
+			filter:  map[string]any{"age__lt": 30},
+			opts:    FindOptions{},
+			wantLen: 2,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.Less(t, r.Age, 30)
+				}
+			},
+		},
+		{
+			name:    "deve usar operador __lte",
+			filter:  map[string]any{"age__lte": 30},
+			opts:    FindOptions{},
+			wantLen: 3,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.LessOrEqual(t, r.Age, 30)
+				}
+			},
+		},
+		{
+			name:    "deve usar operador __like",
+			filter:  map[string]any{"name__like": "%a%"},
+			opts:    FindOptions{},
+			wantLen: 3, // Maria, Ana, Carlos
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.Contains(t, r.Name, "a")
+				}
+			},
+		},
+		{
+			name:    "deve usar operador __like no início",
+			filter:  map[string]any{"name__like": "M%"},
+			opts:    FindOptions{},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "Maria", results[0].Name)
+			},
+		},
+		{
+			name:    "deve usar operador __not_like",
+			filter:  map[string]any{"name__not_like": "%a%"},
+			opts:    FindOptions{},
+			wantLen: 2, // João, Pedro
+		},
+		{
+			name:    "deve usar operador __not",
+			filter:  map[string]any{"name__not": "João"},
+			opts:    FindOptions{},
+			wantLen: 4,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.NotEqual(t, "João", r.Name)
+				}
+			},
+		},
+		{
+			name:    "deve usar operador __in com []int",
+			filter:  map[string]any{"age__in": []int{25, 30}},
+			opts:    FindOptions{},
+			wantLen: 2,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.True(t, r.Age == 25 || r.Age == 30)
+				}
+			},
+		},
+		{
+			name:    "deve usar operador __in com []string",
+			filter:  map[string]any{"name__in": []string{"João", "Maria"}},
+			opts:    FindOptions{},
+			wantLen: 2,
+		},
+		{
+			name:    "deve combinar múltiplos filtros",
+			filter:  map[string]any{"active": true, "age__gte": 28},
+			opts:    FindOptions{},
+			wantLen: 2, // Maria (30, active) e Ana (28, active)
+			check: func(t *testing.T, results []TestSQLEntity) {
+				for _, r := range results {
+					assert.True(t, r.Active)
+					assert.GreaterOrEqual(t, r.Age, 28)
+				}
 			},
 		},
+		{
+			name:    "deve aplicar paginação - página 1",
+			filter:  nil,
+			opts:    FindOptions{Page: 1, Limit: 2},
+			wantLen: 2,
+		},
+		{
+			name:    "deve aplicar paginação - página 2",
+			filter:  nil,
+			opts:    FindOptions{Page: 2, Limit: 2},
+			wantLen: 2,
+		},
+		{
+			name:    "deve aplicar paginação - página 3",
+			filter:  nil,
+			opts:    FindOptions{Page: 3, Limit: 2},
+			wantLen: 1,
+		},
+		{
+			name:    "deve retornar vazio quando filtro não encontra",
+			filter:  map[string]any{"name": "NaoExiste"},
+			opts:    FindOptions{},
+			wantLen: 0,
+		},
+		{
+			name:    "deve retornar vazio para página além dos resultados",
+			filter:  nil,
+			opts:    FindOptions{Page: 100, Limit: 10},
+			wantLen: 0,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db.Exec("DELETE FROM test_entities")
-			tt.setup()
-
-			result, err := store.Upsert(ctx, tt.input, tt.filters)
+			results, err := store.FindAll(ctx, tt.filter, tt.opts)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -1735,86 +2045,247 @@ func TestSQLUpsert(t *testing.T) {
 			}
 
 			assert.NoError(t, err)
-			assert.NotNil(t, result)
+			assert.Equal(t, tt.wantLen, len(results))
 
 			if tt.check != nil {
-				tt.check(t, result)
+				tt.check(t, results)
 			}
 		})
 	}
 }
 
-// ==================== TESTES UPSERT MANY ====================
-
-func TestSQLUpsertMany(t *testing.T) {
-	db, err := setupSQLDB()
+func TestSQLFindAll_IsNullOperators(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	_, err = db.Exec(`
+		CREATE TABLE nullable_entities (
+			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT,
+			age INTEGER
+		);
+		INSERT INTO nullable_entities (name, age) VALUES ('João', 25);
+		INSERT INTO nullable_entities (name, age) VALUES (NULL, 30);
+		INSERT INTO nullable_entities (name, age) VALUES ('Maria', NULL);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "nullable_entities", "id", true)
 	ctx := context.Background()
 
-	tests := []struct {
-		name    string
-		setup   func()
-		input   []TestSQLEntity
-		filters []StoreUpsertFilter
-		check   func(*testing.T, *BulkWriteResult)
-		wantErr bool
-	}{
-		{
-			name:  "deve inserir múltiplos novos registros",
-			setup: func() {},
-			input: []TestSQLEntity{
-				{Name: "Doc 1", Age: 25},
-				{Name: "Doc 2", Age: 30},
-				{Name: "Doc 3", Age: 35},
-			},
-			filters: nil,
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Equal(t, int64(3), result.UpsertedCount)
+	t.Run("deve usar operador __is_null", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"name__is_null": true}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+	})
 
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(3), *count)
-			},
-		},
-		{
-			name:    "deve retornar nil para slice vazio",
-			setup:   func() {},
-			input:   []TestSQLEntity{},
-			filters: nil,
-			check: func(t *testing.T, result *BulkWriteResult) {
-				assert.Nil(t, result)
-			},
-		},
+	t.Run("deve usar operador __is_not_null", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"name__is_not_null": true}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(results))
+	})
+
+	t.Run("deve usar operador __is_null com false", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"name__is_null": false}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(results))
+	})
+
+	t.Run("deve tratar valor nil como IS NULL", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"name": nil}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, 2, results[0].ID)
+
+		var name *string
+		results, err = store.FindAll(ctx, map[string]any{"name": name}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+	})
+
+	t.Run("deve tratar __not com nil como IS NOT NULL", func(t *testing.T) {
+		results, err := store.FindAll(ctx, map[string]any{"name__not": nil}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(results))
+	})
+}
+
+func TestSQLFindAll_IncludeTotalCount(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer db.Close()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			db.Exec("DELETE FROM test_entities")
-			tt.setup()
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
 
-			result, err := store.UpsertMany(ctx, tt.input, tt.filters)
+	for i := range 7 {
+		store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Registro %d", i), Active: i%2 == 0})
+	}
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
+	t.Run("deve respeitar o limite e retornar o total do filtro", func(t *testing.T) {
+		var total int64
+		results, err := store.FindAll(ctx, map[string]any{"active": true}, FindOptions{
+			Page:              1,
+			Limit:             2,
+			IncludeTotalCount: true,
+			TotalCount:        &total,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, int64(4), total)
+		for _, r := range results {
+			assert.True(t, r.Active)
+		}
+	})
 
-			assert.NoError(t, err)
+	t.Run("deve retornar o total para página além dos registros", func(t *testing.T) {
+		var total int64
+		results, err := store.FindAll(ctx, nil, FindOptions{
+			Page:              10,
+			Limit:             5,
+			IncludeTotalCount: true,
+			TotalCount:        &total,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, int64(7), total)
+	})
 
-			if tt.check != nil {
-				tt.check(t, result)
+	t.Run("deve retornar erro sem TotalCount", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{IncludeTotalCount: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLFindAll_StableSortWithTies(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for i := range 9 {
+		store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Empate %d", i), Age: 20 + i%3})
+	}
+
+	for _, orderBy := range []string{"ASC", "DESC"} {
+		t.Run("deve paginar sem sobreposição ordenando "+orderBy, func(t *testing.T) {
+			seen := make(map[int]bool)
+			for p := int64(1); p <= 3; p++ {
+				results, err := store.FindAll(ctx, nil, FindOptions{Page: p, Limit: 3, SortBy: "age", OrderBy: orderBy})
+				assert.NoError(t, err)
+				assert.Len(t, results, 3)
+
+				for _, r := range results {
+					assert.False(t, seen[r.ID], "registro %d repetido entre páginas", r.ID)
+					seen[r.ID] = true
+				}
 			}
+			assert.Len(t, seen, 9)
 		})
 	}
+
+	t.Run("deve desempatar pela chave primária", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{SortBy: "age", OrderBy: "ASC"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 9)
+
+		for i := 1; i < len(results); i++ {
+			if results[i-1].Age == results[i].Age {
+				assert.Less(t, results[i-1].ID, results[i].ID)
+			}
+		}
+	})
 }
 
-// ==================== TESTES DELETE ====================
+type TestSQLAddress struct {
+	City string `db:"city"`
+	Zip  string `db:"zip"`
+}
 
-func TestSQLDelete(t *testing.T) {
+type TestSQLEntityWithAddress struct {
+	ID      int            `db:"id"`
+	Name    string         `db:"name"`
+	Address TestSQLAddress `db:"address"`
+}
+
+func TestSQLFindAll_NestedStruct(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE customers (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			address_city TEXT,
+			address_zip TEXT
+		)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`INSERT INTO customers (name, address_city, address_zip) VALUES ('João', 'Curitiba', '80000-000')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithAddress](db, enum.DatabaseDriverSqlite, "customers", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve preencher a struct aninhada a partir das colunas planas", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "João", results[0].Name)
+		assert.Equal(t, TestSQLAddress{City: "Curitiba", Zip: "80000-000"}, results[0].Address)
+	})
+
+	t.Run("deve gravar a struct aninhada no INSERT e ler de volta", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithAddress{Name: "Maria", Address: TestSQLAddress{City: "Recife", Zip: "50000-000"}})
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSQLAddress{City: "Recife", Zip: "50000-000"}, found.Address)
+	})
+
+	t.Run("deve gravar a struct aninhada no UPDATE", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithAddress{Name: "Ana", Address: TestSQLAddress{City: "Natal"}})
+		assert.NoError(t, err)
+
+		saved.Address = TestSQLAddress{City: "Fortaleza", Zip: "60000-000"}
+		_, err = store.Update(ctx, saved)
+		assert.NoError(t, err)
+
+		found, err := store.FindOne(ctx, map[string]any{"address_city": "Fortaleza"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Ana", found.Name)
+		assert.Equal(t, "60000-000", found.Address.Zip)
+
+		_, err = store.UpdateFields(ctx, &TestSQLEntityWithAddress{ID: saved.ID, Address: TestSQLAddress{Zip: "60100-000"}}, "address_zip")
+		assert.NoError(t, err)
+
+		found, err = store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSQLAddress{City: "Fortaleza", Zip: "60100-000"}, found.Address)
+	})
+}
+
+func TestSQLFindAll_InvalidSortField(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1824,73 +2295,117 @@ func TestSQLDelete(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
+	store.Save(ctx, &TestSQLEntity{Name: "Ordenado", Age: 30})
+
 	tests := []struct {
 		name    string
-		setup   func() int
-		check   func(*testing.T, int)
+		sortBy  string
 		wantErr bool
 	}{
-		{
-			name: "deve deletar registro existente",
-			setup: func() int {
-				saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar"})
-				return saved.ID
-			},
-			check: func(t *testing.T, id int) {
-				exists := store.Has(ctx, id)
-				assert.False(t, exists)
-			},
-		},
-		{
-			name: "não deve retornar erro para registro inexistente",
-			setup: func() int {
-				return 99999
-			},
-			check: func(t *testing.T, id int) {
-				// SQLite não retorna erro para DELETE de registro inexistente
-			},
-		},
-		{
-			name: "deve manter outros registros intactos",
-			setup: func() int {
-				store.Save(ctx, &TestSQLEntity{Name: "Manter 1"})
-				toDelete, _ := store.Save(ctx, &TestSQLEntity{Name: "Deletar"})
-				store.Save(ctx, &TestSQLEntity{Name: "Manter 2"})
-				return toDelete.ID
-			},
-			check: func(t *testing.T, id int) {
-				assert.False(t, store.Has(ctx, id))
-
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(2), *count)
-			},
-		},
+		{name: "deve aceitar coluna mapeada", sortBy: "age"},
+		{name: "deve aceitar alias id", sortBy: "id"},
+		{name: "deve aceitar SortBy vazio", sortBy: ""},
+		{name: "deve rejeitar campo desconhecido", sortBy: "idade", wantErr: true},
+		{name: "deve rejeitar tentativa de injeção", sortBy: "age; DROP TABLE test_entities", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db.Exec("DELETE FROM test_entities")
-
-			id := tt.setup()
-			err := store.Delete(ctx, id)
+			results, err := store.FindAll(ctx, nil, FindOptions{SortBy: tt.sortBy})
 
 			if tt.wantErr {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidSortField)
 				return
 			}
 
 			assert.NoError(t, err)
-
-			if tt.check != nil {
-				tt.check(t, id)
-			}
+			assert.Len(t, results, 1)
 		})
 	}
+
+	t.Run("deve validar SortBy na busca", func(t *testing.T) {
+		_, err := store.Search(ctx, "Orden", []string{"name"}, FindOptions{SortBy: "idade"})
+		assert.ErrorIs(t, err, ErrInvalidSortField)
+	})
 }
 
-// ==================== TESTES DELETE ONE ====================
+func TestSQLFindPage(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
 
-func TestSQLDeleteOne(t *testing.T) {
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for i := range 25 {
+		store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Página %d", i), Age: 20 + i, Active: i%5 != 0})
+	}
+
+	t.Run("deve montar os metadados da segunda página", func(t *testing.T) {
+		result, err := store.FindPage(ctx, nil, 2, 10)
+		assert.NoError(t, err)
+		assert.Len(t, result.Items, 10)
+		assert.Equal(t, 2, result.Meta.CurrentPage)
+		assert.Equal(t, 3, result.Meta.TotalPages)
+		assert.Equal(t, 10, result.Meta.ItemCount)
+		assert.Equal(t, 25, result.Meta.TotalItems)
+		assert.Equal(t, 10, result.Meta.ItemsPerPage)
+	})
+
+	t.Run("deve aplicar o filtro no total", func(t *testing.T) {
+		result, err := store.FindPage(ctx, map[string]any{"active": true}, 2, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 20, result.Meta.TotalItems)
+		assert.Equal(t, 2, result.Meta.TotalPages)
+		assert.Equal(t, 10, result.Meta.ItemCount)
+	})
+
+	t.Run("deve retornar página vazia além do último registro", func(t *testing.T) {
+		result, err := store.FindPage(ctx, nil, 4, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Items)
+		assert.Equal(t, 25, result.Meta.TotalItems)
+	})
+
+	t.Run("deve rejeitar limite zero", func(t *testing.T) {
+		_, err := store.FindPage(ctx, nil, 1, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLFindAllWithCount(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for i := range 23 {
+		store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Total %d", i), Age: i})
+	}
+
+	t.Run("deve retornar a página e o total do filtro", func(t *testing.T) {
+		results, total, err := store.FindAllWithCount(ctx, map[string]any{"age__gte": 3}, FindOptions{Page: 1, Limit: 10, SortBy: "age"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 10)
+		assert.Equal(t, int64(20), total)
+		assert.Equal(t, 3, results[0].Age)
+	})
+
+	t.Run("deve retornar total zero sem resultados", func(t *testing.T) {
+		results, total, err := store.FindAllWithCount(ctx, map[string]any{"age__gt": 100}, FindOptions{Limit: 10})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, int64(0), total)
+	})
+}
+
+func TestSQLFindAll_DateParts(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -1900,206 +2415,125 @@ func TestSQLDeleteOne(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
+	dates := []string{"2024-01-15 10:00:00", "2024-01-20 10:00:00", "2024-02-05 10:00:00", "2023-01-07 10:00:00"}
+	for i, date := range dates {
+		_, err := db.Exec("INSERT INTO test_entities (name, age, created_at, updated_at) VALUES (?, ?, ?, ?)",
+			fmt.Sprintf("Data %d", i), i, date, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	tests := []struct {
-		name    string
-		setup   func()
-		filter  map[string]interface{}
-		check   func(*testing.T)
-		wantErr bool
+		name     string
+		filters  map[string]any
+		expected int
 	}{
-		{
-			name: "deve deletar registro com filtro simples",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 30})
-			},
-			filter: map[string]interface{}{"name": "João"},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
-
-				result, _ := store.FindOne(ctx, map[string]interface{}{"name": "Maria"})
-				assert.NotNil(t, result)
-			},
-		},
-		{
-			name: "deve deletar com filtro booleano",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc1", Active: true})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc2", Active: false})
-			},
-			filter: map[string]interface{}{"active": true},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
+		{name: "deve filtrar pelo mês", filters: map[string]any{"created_at__month": 1}, expected: 3},
+		{name: "deve filtrar pelo ano", filters: map[string]any{"created_at__year": 2024}, expected: 3},
+		{name: "deve combinar mês e ano", filters: map[string]any{"created_at__month": 1, "created_at__year": 2024}, expected: 2},
+		{name: "deve filtrar pelo dia", filters: map[string]any{"created_at__day": 5}, expected: 1},
+		// 2024-01-15 e 2024-02-05 são segundas-feiras, 2024-01-20 e 2023-01-07 são sábados
+		{name: "deve filtrar pelo dia da semana", filters: map[string]any{"created_at__dow": 1}, expected: 2},
+		{name: "deve filtrar sábado", filters: map[string]any{"created_at__dow": 6}, expected: 2},
+	}
 
-				result, _ := store.FindOne(ctx, map[string]interface{}{"active": false})
-				assert.NotNil(t, result)
-			},
-		},
-		{
-			name: "deve deletar com operador __gt",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 35})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 40})
-			},
-			filter: map[string]interface{}{"age__gt": 30},
-			check: func(t *testing.T) {
-				// Deve deletar apenas um (o primeiro que encontrar > 30)
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(2), *count)
-			},
-		},
-		{
-			name: "deve deletar com operador __gte",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
-			},
-			filter: map[string]interface{}{"age__gte": 30},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
-			},
-		},
-		{
-			name: "deve deletar com operador __lt",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
-			},
-			filter: map[string]interface{}{"age__lt": 25},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
-			},
-		},
-		{
-			name: "deve deletar com operador __lte",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
-			},
-			filter: map[string]interface{}{"age__lte": 25},
-			check: func(t *testing.T) {
-				// Deve deletar apenas um
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(2), *count)
-			},
-		},
-		{
-			name: "deve deletar com operador __like",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João Silva"})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria Santos"})
-			},
-			filter: map[string]interface{}{"name__like": "%Silva%"},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
-
-				result, _ := store.FindOne(ctx, map[string]interface{}{"name": "Maria Santos"})
-				assert.NotNil(t, result)
-			},
-		},
-		{
-			name: "deve deletar com operador __in",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João"})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria"})
-				store.Save(ctx, &TestSQLEntity{Name: "Pedro"})
-			},
-			filter: map[string]interface{}{"name__in": []string{"João", "Maria"}},
-			check: func(t *testing.T) {
-				// Deve deletar apenas um
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(2), *count)
-			},
-		},
-		{
-			name: "deve deletar com múltiplos filtros",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 25})
-			},
-			filter: map[string]interface{}{"name": "João", "age": 25},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(2), *count)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.FindAll(ctx, tt.filters, FindOptions{})
+			assert.NoError(t, err)
+			assert.Len(t, results, tt.expected)
+		})
+	}
 
-				result, _ := store.FindOne(ctx, map[string]interface{}{"name": "João", "age": 30})
-				assert.NotNil(t, result)
-			},
-		},
-		{
-			name: "deve retornar erro quando nenhum registro é encontrado",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João"})
-			},
-			filter:  map[string]interface{}{"name": "NaoExiste"},
-			wantErr: true,
-		},
-		{
-			name:    "deve retornar erro quando filtro é nulo",
-			setup:   func() {},
-			filter:  nil,
-			wantErr: true,
-		},
-		{
-			name:    "deve retornar erro quando filtro é vazio",
-			setup:   func() {},
-			filter:  map[string]interface{}{},
-			wantErr: true,
-		},
-		{
-			name: "deve manter outros registros intactos",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Manter 1"})
-				store.Save(ctx, &TestSQLEntity{Name: "Deletar"})
-				store.Save(ctx, &TestSQLEntity{Name: "Manter 2"})
-			},
-			filter: map[string]interface{}{"name": "Deletar"},
-			check: func(t *testing.T) {
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(2), *count)
+	t.Run("deve extrair a parte da data de registros salvos pelo store", func(t *testing.T) {
+		createdAt := time.Date(2022, 6, 1, 15, 30, 0, 123456789, time.UTC)
+		_, err := store.Save(ctx, &TestSQLEntity{Name: "Salvo", Age: 99, CreatedAt: createdAt, UpdatedAt: createdAt})
+		assert.NoError(t, err)
 
-				results, _ := store.FindAll(ctx, map[string]any{}, FindOptions{})
-				assert.Equal(t, 2, len(results))
+		count, err := store.Count(ctx, map[string]any{"created_at__year": 2022, "created_at__month": 6})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+	})
+}
 
-				for _, r := range results {
-					assert.NotEqual(t, "Deletar", r.Name)
-				}
-			},
-		},
+func TestSQLFindAll_UnknownProjectionColumn(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer db.Close()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			db.Exec("DELETE FROM test_entities")
-			tt.setup()
+	// A tabela não existe: o erro de coluna precisa ocorrer antes de qualquer consulta
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "tabela_inexistente", "id", true)
+	ctx := context.Background()
 
-			err := store.DeleteOne(ctx, tt.filter)
+	t.Run("deve rejeitar campo desconhecido em Fields", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"id", "email"}})
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+		assert.ErrorContains(t, err, "email")
+	})
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				return
-			}
+	t.Run("deve rejeitar campo desconhecido em ExcludeFields", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{ExcludeFields: []string{"senha"}})
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+	})
 
-			assert.NoError(t, err)
+	t.Run("deve rejeitar campo desconhecido na busca", func(t *testing.T) {
+		_, err := store.Search(ctx, "", []string{"name", "apelido"}, FindOptions{})
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+	})
+}
 
-			if tt.check != nil {
-				tt.check(t)
-			}
-		})
+func TestSQLFindAll_DefaultFindOptions(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true,
+		WithDefaultFindOptions(FindOptions{Limit: 50, SortBy: "age", OrderBy: "DESC"}))
+	ctx := context.Background()
+
+	entities := make([]TestSQLEntity, 60)
+	for i := range entities {
+		entities[i] = TestSQLEntity{Name: fmt.Sprintf("Pessoa %d", i), Age: i}
 	}
+	_, err = store.SaveMany(ctx, entities)
+	assert.NoError(t, err)
+
+	t.Run("deve usar o limite e a ordenação padrão do store", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 50)
+		assert.Equal(t, 59, results[0].Age)
+	})
+
+	t.Run("deve manter os valores informados pelo chamador", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{Limit: 5, SortBy: "age", OrderBy: "ASC"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 5)
+		assert.Equal(t, 0, results[0].Age)
+	})
+
+	t.Run("deve aplicar os padrões no Search", func(t *testing.T) {
+		results, err := store.Search(ctx, "Pessoa", []string{"name"}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 50)
+	})
+
+	t.Run("deve manter o comportamento padrão sem configuração", func(t *testing.T) {
+		plain := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		results, err := plain.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 60)
+	})
 }
 
-// ==================== TESTES DELETE MANY ====================
+// ==================== TESTES ILIKE (CASE INSENSITIVE) ====================
 
-func TestSQLDeleteMany(t *testing.T) {
+func TestSQLILike(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -2109,104 +2543,3631 @@ func TestSQLDeleteMany(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
+	// Setup: salva registros de teste com diferentes casos de capitalização
+	testDocs := []TestSQLEntity{
+		{Name: "João Silva", Age: 25, Active: true},
+		{Name: "MARIA SANTOS", Age: 30, Active: true},
+		{Name: "pedro oliveira", Age: 35, Active: false},
+		{Name: "Ana Paula Costa", Age: 28, Active: true},
+		{Name: "CARLOS EDUARDO", Age: 40, Active: false},
+		{Name: "Fernanda Lima", Age: 22, Active: true},
+		{Name: "roberto almeida", Age: 33, Active: true},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
 	tests := []struct {
 		name    string
-		setup   func()
 		filter  map[string]any
-		check   func(*testing.T, *DeleteResult)
-		wantErr bool
+		wantLen int
+		check   func(*testing.T, []TestSQLEntity)
 	}{
 		{
-			name: "deve deletar múltiplos registros",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Active: true})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Active: true})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Active: false})
-			},
-			filter: map[string]any{"active": true},
-			check: func(t *testing.T, result *DeleteResult) {
-				assert.Equal(t, int64(2), result.DeletedCount)
-
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
+			name:    "deve encontrar com busca case-insensitive minúscula",
+			filter:  map[string]any{"name__ilike": "%joão%"},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "João Silva", results[0].Name)
 			},
 		},
 		{
-			name: "deve usar operadores no filtro",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 40})
-			},
-			filter: map[string]any{"age__gte": 30},
-			check: func(t *testing.T, result *DeleteResult) {
-				assert.Equal(t, int64(2), result.DeletedCount)
+			name:    "deve encontrar com busca case-insensitive maiúscula",
+			filter:  map[string]any{"name__ilike": "%MARIA%"},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "MARIA SANTOS", results[0].Name)
 			},
 		},
 		{
-			name: "deve usar operador __in",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc 1", Age: 25})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc 2", Age: 30})
-				store.Save(ctx, &TestSQLEntity{Name: "Doc 3", Age: 35})
-			},
-			filter: map[string]any{"age__in": []int{25, 35}},
-			check: func(t *testing.T, result *DeleteResult) {
-				assert.Equal(t, int64(2), result.DeletedCount)
-
-				count, _ := store.Count(ctx, map[string]any{})
-				assert.Equal(t, int64(1), *count)
+			name:    "deve encontrar com busca case-insensitive mixed case",
+			filter:  map[string]any{"name__ilike": "%PeDrO%"},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "pedro oliveira", results[0].Name)
 			},
 		},
 		{
-			name: "deve usar operador __like",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "João Silva"})
-				store.Save(ctx, &TestSQLEntity{Name: "João Santos"})
-				store.Save(ctx, &TestSQLEntity{Name: "Maria Silva"})
+			name:    "deve encontrar múltiplos com wildcard no início",
+			filter:  map[string]any{"name__ilike": "%a%"},
+			wantLen: 7, // João, MARIA, Ana Paula Costa, CARLOS, Fernanda, roberto almeida, pedro oliveira não tem 'a'
+		},
+		{
+			name:    "deve encontrar com wildcard no fim",
+			filter:  map[string]any{"name__ilike": "joão%"},
+			wantLen: 1,
+		},
+		{
+			name:    "deve encontrar com wildcard no início",
+			filter:  map[string]any{"name__ilike": "%silva"},
+			wantLen: 1,
+		},
+		{
+			name:    "deve encontrar nome completo case-insensitive",
+			filter:  map[string]any{"name__ilike": "ana paula costa"},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "Ana Paula Costa", results[0].Name)
 			},
-			filter: map[string]any{"name__like": "João%"},
-			check: func(t *testing.T, result *DeleteResult) {
-				assert.Equal(t, int64(2), result.DeletedCount)
+		},
+		{
+			name:    "deve encontrar sobrenome case-insensitive",
+			filter:  map[string]any{"name__ilike": "%EDUARDO%"},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "CARLOS EDUARDO", results[0].Name)
 			},
 		},
 		{
-			name: "deve retornar zero quando não encontra",
-			setup: func() {
-				store.Save(ctx, &TestSQLEntity{Name: "Doc"})
+			name:    "deve retornar vazio quando não encontra",
+			filter:  map[string]any{"name__ilike": "%zzz%"},
+			wantLen: 0,
+		},
+		{
+			name:    "deve combinar ILIKE com outro filtro",
+			filter:  map[string]any{"name__ilike": "%a%", "active": true},
+			wantLen: 5, // João, MARIA, Ana Paula Costa, Fernanda, roberto almeida (todos ativos com 'a')
+		},
+		{
+			name:    "deve combinar ILIKE com operador numérico",
+			filter:  map[string]any{"name__ilike": "%silva%", "age__gte": 20},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "João Silva", results[0].Name)
+				assert.Equal(t, 25, results[0].Age)
 			},
-			filter: map[string]any{"name": "NaoExiste"},
-			check: func(t *testing.T, result *DeleteResult) {
-				assert.Equal(t, int64(0), result.DeletedCount)
+		},
+		{
+			name:    "deve funcionar com espaços e caracteres especiais",
+			filter:  map[string]any{"name__ilike": "%PAULA COSTA%"},
+			wantLen: 1,
+			check: func(t *testing.T, results []TestSQLEntity) {
+				assert.Equal(t, "Ana Paula Costa", results[0].Name)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db.Exec("DELETE FROM test_entities")
-			tt.setup()
+			results, err := store.FindAll(ctx, tt.filter, FindOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLen, len(results), "Número de resultados não corresponde")
+			if tt.check != nil && len(results) > 0 {
+				tt.check(t, results)
+			}
+		})
+	}
+}
 
-			result, err := store.DeleteMany(ctx, tt.filter)
+// Teste específico para garantir que ILIKE funciona com FindOne
+func TestSQLFindOneILike(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
 
-			if tt.wantErr {
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	// Setup
+	testDocs := []TestSQLEntity{
+		{Name: "UPPERCASE NAME", Age: 25, Active: true},
+		{Name: "lowercase name", Age: 30, Active: true},
+		{Name: "MiXeD CaSe NaMe", Age: 35, Active: false},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	tests := []struct {
+		name      string
+		filter    map[string]any
+		wantName  string
+		wantFound bool
+	}{
+		{
+			name:      "deve encontrar UPPERCASE com busca lowercase",
+			filter:    map[string]any{"name__ilike": "%uppercase%"},
+			wantName:  "UPPERCASE NAME",
+			wantFound: true,
+		},
+		{
+			name:      "deve encontrar lowercase com busca UPPERCASE",
+			filter:    map[string]any{"name__ilike": "%LOWERCASE%"},
+			wantName:  "lowercase name",
+			wantFound: true,
+		},
+		{
+			name:      "deve encontrar MiXeD com busca qualquer case",
+			filter:    map[string]any{"name__ilike": "%mixed case%"},
+			wantName:  "MiXeD CaSe NaMe",
+			wantFound: true,
+		},
+		{
+			name:      "deve retornar erro quando não encontra",
+			filter:    map[string]any{"name__ilike": "%inexistente%"},
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.FindOne(ctx, tt.filter)
+			if tt.wantFound {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, tt.wantName, result.Name)
+			} else {
 				assert.Error(t, err)
-				return
+				assert.Nil(t, result)
 			}
+		})
+	}
+}
+
+// Teste para verificar que ILIKE funciona com DeleteOne
+func TestSQLDeleteOneILike(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	// Setup
+	testDoc := TestSQLEntity{Name: "TeStE CaSe InSeNsItIvE", Age: 25, Active: true}
+	_, _ = store.Save(ctx, &testDoc)
+
+	// Verifica que o registro existe
+	count, _ := store.Count(ctx, map[string]any{"name__ilike": "%teste%"})
+	assert.Equal(t, int64(1), *count)
+
+	// Deleta usando ILIKE
+	err = store.DeleteOne(ctx, map[string]any{"name__ilike": "%TESTE CASE%"})
+	assert.NoError(t, err)
+
+	// Verifica que foi deletado
+	count, _ = store.Count(ctx, map[string]any{"name__ilike": "%teste%"})
+	assert.Equal(t, int64(0), *count)
+}
+
+// Teste para verificar que ILIKE funciona com Count
+func TestSQLCountILike(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	// Setup
+	testDocs := []TestSQLEntity{
+		{Name: "TESTE UM", Age: 25, Active: true},
+		{Name: "teste dois", Age: 30, Active: true},
+		{Name: "TeSte TrÊs", Age: 35, Active: false},
+		{Name: "Outro Nome", Age: 40, Active: true},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	tests := []struct {
+		name      string
+		filter    map[string]any
+		wantCount int64
+	}{
+		{
+			name:      "deve contar todos com 'teste' (case insensitive)",
+			filter:    map[string]any{"name__ilike": "%teste%"},
+			wantCount: 3,
+		},
+		{
+			name:      "deve contar com busca UPPERCASE",
+			filter:    map[string]any{"name__ilike": "%TESTE%"},
+			wantCount: 3,
+		},
+		{
+			name:      "deve contar zero quando não encontra",
+			filter:    map[string]any{"name__ilike": "%xyz%"},
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := store.Count(ctx, tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, *count)
+		})
+	}
+}
+
+func TestSQLFindLast(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for i := 1; i <= 6; i++ {
+		store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Registro %d", i), Age: i * 10})
+	}
+
+	t.Run("deve retornar os últimos registros em ordem crescente", func(t *testing.T) {
+		results, err := store.FindLast(ctx, 3, nil, "age")
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, []int{40, 50, 60}, []int{results[0].Age, results[1].Age, results[2].Age})
+	})
+
+	t.Run("deve aplicar o filtro", func(t *testing.T) {
+		results, err := store.FindLast(ctx, 2, map[string]any{"age__lt": 40}, "age")
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "Registro 2", results[0].Name)
+		assert.Equal(t, "Registro 3", results[1].Name)
+	})
+
+	t.Run("deve retornar erro para campo de ordenação não mapeado", func(t *testing.T) {
+		_, err := store.FindLast(ctx, 3, nil, "inexistente")
+		assert.Error(t, err)
+	})
+
+	t.Run("deve retornar erro para quantidade inválida", func(t *testing.T) {
+		_, err := store.FindLast(ctx, 0, nil, "age")
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLFindAll_Projection(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestSQLEntity{Name: "Projeção", Age: 30, Score: 9.5})
+
+	t.Run("deve retornar apenas os campos informados em Fields", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"id", "name"}})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.NotZero(t, results[0].ID)
+		assert.Equal(t, "Projeção", results[0].Name)
+		assert.Zero(t, results[0].Age)
+	})
+
+	t.Run("deve omitir os campos informados em ExcludeFields", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{ExcludeFields: []string{"score"}})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Projeção", results[0].Name)
+		assert.Equal(t, 30, results[0].Age)
+		assert.Zero(t, results[0].Score)
+	})
+
+	t.Run("deve aplicar a projeção com IncludeTotalCount", func(t *testing.T) {
+		var total int64
+		results, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"name"}, IncludeTotalCount: true, TotalCount: &total})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, int64(1), total)
+		assert.Zero(t, results[0].Age)
+	})
+
+	t.Run("deve retornar erro ao informar Fields e ExcludeFields juntos", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"name"}, ExcludeFields: []string{"score"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("deve retornar erro para campo não mapeado", func(t *testing.T) {
+		_, err := store.FindAll(ctx, nil, FindOptions{Fields: []string{"inexistente"}})
+		assert.Error(t, err)
+	})
+}
+
+// ==================== TESTES FIND ALL JOINED ====================
+
+func TestSQLFindAllJoined(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	result, err := store.FindAllJoined(ctx, []LookupSpec{{From: "other"}}, nil, FindOptions{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not implemented")
+}
+
+// ==================== TESTES SEARCH TEXT ====================
+
+func TestSQLSearch(t *testing.T) {
+	db, err := setupSQLDBWithoutTimestamps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "simple_entities", "id", true)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "João Silva"})
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Maria Souza"})
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Pedro SILVA"})
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Desconto 50% off"})
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Desconto 500 off"})
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "nome_social"})
+	store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "nomeXsocial"})
+
+	tests := []struct {
+		name      string
+		term      string
+		fields    []string
+		opts      FindOptions
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "deve buscar sem diferenciar maiúsculas e minúsculas",
+			term:      "silva",
+			fields:    []string{"name"},
+			wantCount: 2,
+		},
+		{
+			name:      "deve paginar os resultados",
+			term:      "silva",
+			fields:    []string{"name"},
+			opts:      FindOptions{Page: 1, Limit: 1},
+			wantCount: 1,
+		},
+		{
+			name:      "deve retornar todos os registros com termo vazio",
+			term:      "",
+			fields:    []string{"name"},
+			wantCount: 7,
+		},
+		{
+			name:      "deve buscar % literalmente",
+			term:      "50%",
+			fields:    []string{"name"},
+			wantCount: 1,
+		},
+		{
+			name:      "deve buscar _ literalmente",
+			term:      "e_s",
+			fields:    []string{"name"},
+			wantCount: 1,
+		},
+		{
+			name:    "deve retornar erro sem campos",
+			term:    "silva",
+			wantErr: true,
+		},
+		{
+			name:    "deve retornar erro para campo não mapeado",
+			term:    "silva",
+			fields:  []string{"name; DROP TABLE simple_entities"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.Search(ctx, tt.term, tt.fields, tt.opts)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, results, tt.wantCount)
+		})
+	}
+}
+
+func TestSQLSearchText(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	result, err := store.SearchText(ctx, "João", FindOptions{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "not implemented")
+}
+
+// ==================== TESTES COUNT ====================
+
+func TestSQLCount(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	// Setup
+	testDocs := []TestSQLEntity{
+		{Name: "João", Age: 25, Active: true},
+		{Name: "Maria", Age: 30, Active: true},
+		{Name: "Pedro", Age: 35, Active: false},
+	}
+	for _, doc := range testDocs {
+		_, _ = store.Save(ctx, &doc)
+	}
+
+	tests := []struct {
+		name      string
+		filter    map[string]any
+		wantCount int64
+		wantErr   bool
+	}{
+		{
+			name:      "deve contar todos os registros",
+			filter:    map[string]any{},
+			wantCount: 3,
+		},
+		{
+			name:      "deve contar com filtro booleano",
+			filter:    map[string]any{"active": true},
+			wantCount: 2,
+		},
+		{
+			name:      "deve contar com operador __gt",
+			filter:    map[string]any{"age__gt": 25},
+			wantCount: 2,
+		},
+		{
+			name:      "deve retornar zero quando não encontra",
+			filter:    map[string]any{"name": "NaoExiste"},
+			wantCount: 0,
+		},
+		{
+			name:      "deve contar com múltiplos filtros",
+			filter:    map[string]any{"active": true, "age__gte": 30},
+			wantCount: 1,
+		},
+		{
+			name:      "deve contar com operador __in",
+			filter:    map[string]any{"name__in": []string{"João", "Maria"}},
+			wantCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := store.Count(ctx, tt.filter)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, *count)
+		})
+	}
+}
+
+func TestSQLCount_WithCountCache(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithCountCache(time.Minute))
+	ctx := context.Background()
+
+	_, err = store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25, Active: true})
+	assert.NoError(t, err)
+
+	// Inserção direta no banco, sem passar pelo store: só é vista quando o Count consulta o banco
+	insertExternal := func() {
+		_, err := db.Exec("INSERT INTO test_entities (name, age, active) VALUES ('Externo', 40, true)")
+		assert.NoError(t, err)
+	}
+
+	t.Run("deve reutilizar o total dentro do ttl", func(t *testing.T) {
+		count, err := store.Count(ctx, map[string]any{"active": true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+
+		insertExternal()
+
+		count, err = store.Count(ctx, map[string]any{"active": true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+	})
+
+	t.Run("deve separar o cache por filtro", func(t *testing.T) {
+		count, err := store.Count(ctx, map[string]any{"age__gt": 30})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+	})
+
+	t.Run("deve diferenciar filtros pelo tipo dos valores", func(t *testing.T) {
+		assert.NotEqual(t, countCacheKey("test_entities", map[string]any{"age": 1}), countCacheKey("test_entities", map[string]any{"age": "1"}))
+		assert.NotEqual(t, countCacheKey("test_entities", nil), countCacheKey("test_entities", map[string]any{}))
+		assert.Equal(t,
+			countCacheKey("test_entities", map[string]any{"age": 1, "name": "João"}),
+			countCacheKey("test_entities", map[string]any{"name": "João", "age": 1}))
+	})
+
+	t.Run("deve invalidar o cache no Save", func(t *testing.T) {
+		_, err := store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 30, Active: true})
+		assert.NoError(t, err)
+
+		count, err := store.Count(ctx, map[string]any{"active": true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), *count)
+	})
+
+	t.Run("deve invalidar o cache explicitamente", func(t *testing.T) {
+		insertExternal()
+		store.InvalidateCount()
+
+		count, err := store.Count(ctx, map[string]any{"active": true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), *count)
+	})
+
+	t.Run("deve expirar após o ttl", func(t *testing.T) {
+		short := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithCountCache(10*time.Millisecond))
+
+		count, err := short.Count(ctx, nil)
+		assert.NoError(t, err)
+
+		insertExternal()
+		time.Sleep(20 * time.Millisecond)
+
+		after, err := short.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, *count+1, *after)
+	})
+}
+
+// ==================== TESTES HAS ====================
+
+func TestSQLHas(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	// Setup
+	saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Existe"})
+
+	tests := []struct {
+		name string
+		id   any
+		want bool
+	}{
+		{
+			name: "deve retornar true para registro existente",
+			id:   saved.ID,
+			want: true,
+		},
+		{
+			name: "deve retornar false para registro inexistente",
+			id:   99999,
+			want: false,
+		},
+		{
+			name: "deve retornar false para ID zero",
+			id:   0,
+			want: false,
+		},
+		{
+			name: "deve retornar false para ID negativo",
+			id:   -1,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := store.Has(ctx, tt.id)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestSQLHasAll(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	first, _ := store.Save(ctx, &TestSQLEntity{Name: "Primeiro"})
+	second, _ := store.Save(ctx, &TestSQLEntity{Name: "Segundo"})
+
+	t.Run("deve informar quais IDs existem", func(t *testing.T) {
+		result, err := store.HasAll(ctx, []any{first.ID, second.ID, 99999})
+		assert.NoError(t, err)
+		assert.Equal(t, map[any]bool{first.ID: true, second.ID: true, 99999: false}, result)
+	})
+
+	t.Run("deve marcar presentes e ausentes em um lote misto", func(t *testing.T) {
+		third, _ := store.Save(ctx, &TestSQLEntity{Name: "Terceiro"})
+
+		result, err := store.HasAll(ctx, []any{first.ID, 88888, second.ID, third.ID, 99999})
+		assert.NoError(t, err)
+		assert.Len(t, result, 5)
+		assert.True(t, result[first.ID])
+		assert.True(t, result[second.ID])
+		assert.True(t, result[third.ID])
+		assert.False(t, result[88888])
+		assert.False(t, result[99999])
+	})
+
+	t.Run("deve retornar mapa vazio sem IDs", func(t *testing.T) {
+		result, err := store.HasAll(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestSQLHasWhere(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	store.Save(ctx, &TestSQLEntity{Name: "Existe", Age: 30})
+
+	tests := []struct {
+		name    string
+		filter  map[string]any
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "deve retornar true quando algum registro corresponde",
+			filter: map[string]any{"name": "Existe", "age__gte": 18},
+			want:   true,
+		},
+		{
+			name:   "deve retornar false quando nenhum registro corresponde",
+			filter: map[string]any{"name": "Não Existe"},
+			want:   false,
+		},
+		{
+			name:   "deve considerar todos os registros com filtro vazio",
+			filter: map[string]any{},
+			want:   true,
+		},
+		{
+			name:    "deve propagar o erro da consulta",
+			filter:  map[string]any{"coluna_inexistente": 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.HasWhere(ctx, tt.filter)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestSQLMissingIds(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	first, _ := store.Save(ctx, &TestSQLEntity{Name: "João"})
+	second, _ := store.Save(ctx, &TestSQLEntity{Name: "Maria"})
+
+	t.Run("deve retornar os ids ausentes na ordem de entrada", func(t *testing.T) {
+		missing, err := store.MissingIds(ctx, []any{999, first.ID, 998, second.ID, 999})
+		assert.NoError(t, err)
+		assert.Equal(t, []any{999, 998}, missing)
+	})
+
+	t.Run("deve retornar lista vazia quando todos existem", func(t *testing.T) {
+		missing, err := store.MissingIds(ctx, []any{first.ID, second.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("deve retornar lista vazia sem ids", func(t *testing.T) {
+		missing, err := store.MissingIds(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+}
+
+// ==================== TESTES UPDATE ====================
+
+func TestSQLUpdate(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func() *TestSQLEntity
+		update  func(*TestSQLEntity) *TestSQLEntity
+		check   func(*testing.T, *TestSQLEntity)
+		wantErr bool
+	}{
+		{
+			name: "deve atualizar campo string",
+			setup: func() *TestSQLEntity {
+				doc := &TestSQLEntity{Name: "Original", Age: 25}
+				store.Save(ctx, doc)
+				return doc
+			},
+			update: func(e *TestSQLEntity) *TestSQLEntity {
+				e.Name = "Atualizado"
+				return e
+			},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.Equal(t, "Atualizado", result.Name)
+				assert.Equal(t, 25, result.Age)
+			},
+		},
+		{
+			name: "deve atualizar campo numérico",
+			setup: func() *TestSQLEntity {
+				doc := &TestSQLEntity{Name: "Teste", Age: 25, Score: 80}
+				store.Save(ctx, doc)
+				return doc
+			},
+			update: func(e *TestSQLEntity) *TestSQLEntity {
+				e.Age = 30
+				e.Score = 95.5
+				return e
+			},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				found, _ := store.FindById(ctx, result.ID)
+				assert.Equal(t, 30, found.Age)
+				assert.Equal(t, 95.5, found.Score)
+			},
+		},
+		{
+			name: "deve atualizar campo booleano",
+			setup: func() *TestSQLEntity {
+				doc := &TestSQLEntity{Name: "Teste", Active: false}
+				store.Save(ctx, doc)
+				return doc
+			},
+			update: func(e *TestSQLEntity) *TestSQLEntity {
+				e.Active = true
+				return e
+			},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				found, _ := store.FindById(ctx, result.ID)
+				assert.True(t, found.Active)
+			},
+		},
+		{
+			name: "deve atualizar UpdatedAt automaticamente",
+			setup: func() *TestSQLEntity {
+				doc := &TestSQLEntity{Name: "Teste"}
+				store.Save(ctx, doc)
+				time.Sleep(10 * time.Millisecond)
+				return doc
+			},
+			update: func(e *TestSQLEntity) *TestSQLEntity {
+				e.Name = "Atualizado"
+				return e
+			},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				assert.True(t, time.Since(result.UpdatedAt) < time.Minute)
+			},
+		},
+		{
+			name: "deve atualizar múltiplos campos",
+			setup: func() *TestSQLEntity {
+				doc := &TestSQLEntity{Name: "Original", Age: 20, Score: 50, Active: false}
+				store.Save(ctx, doc)
+				return doc
+			},
+			update: func(e *TestSQLEntity) *TestSQLEntity {
+				e.Name = "Atualizado"
+				e.Age = 30
+				e.Score = 100
+				e.Active = true
+				return e
+			},
+			check: func(t *testing.T, result *TestSQLEntity) {
+				found, _ := store.FindById(ctx, result.ID)
+				assert.Equal(t, "Atualizado", found.Name)
+				assert.Equal(t, 30, found.Age)
+				assert.Equal(t, 100.0, found.Score)
+				assert.True(t, found.Active)
+			},
+		},
+		{
+			name: "deve retornar erro para registro inexistente",
+			setup: func() *TestSQLEntity {
+				return &TestSQLEntity{ID: 99999, Name: "Teste"}
+			},
+			update: func(e *TestSQLEntity) *TestSQLEntity {
+				return e
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Limpa tabela
+			db.Exec("DELETE FROM test_entities")
+
+			entity := tt.setup()
+			toUpdate := tt.update(entity)
+
+			result, err := store.Update(ctx, toUpdate)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestSQLWithManagedTimestamps(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithManagedTimestamps(true, false))
+	ctx := context.Background()
+
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	saved, err := store.Save(ctx, &TestSQLEntity{Name: "João", CreatedAt: past, UpdatedAt: past})
+	assert.NoError(t, err)
+
+	updatedAt := func(t *testing.T) time.Time {
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		return found.UpdatedAt.UTC()
+	}
+
+	t.Run("não deve sobrescrever updated_at no Update", func(t *testing.T) {
+		saved.Name = "João Silva"
+		result, err := store.Update(ctx, saved)
+		assert.NoError(t, err)
+		assert.True(t, result.UpdatedAt.Equal(past))
+		assert.Equal(t, past, updatedAt(t))
+	})
+
+	t.Run("não deve incluir updated_at no UpdateFields e no UpdateMany", func(t *testing.T) {
+		saved.Age = 40
+		_, err := store.UpdateFields(ctx, saved, "age")
+		assert.NoError(t, err)
+
+		_, err = store.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"id": saved.ID}, Fields: map[string]any{"score": 9.5}}})
+		assert.NoError(t, err)
+		assert.Equal(t, past, updatedAt(t))
+	})
+
+	t.Run("deve gravar o updated_at informado no UpdateMany", func(t *testing.T) {
+		informed := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+		_, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"id": saved.ID}, Fields: map[string]any{"updated_at": informed}}})
+		assert.NoError(t, err)
+		assert.Equal(t, informed, updatedAt(t))
+	})
+
+	t.Run("deve continuar preenchendo updated_at por padrão", func(t *testing.T) {
+		managed := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+
+		_, err := managed.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"id": saved.ID}, Fields: map[string]any{"score": 1.0}}})
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), updatedAt(t), time.Minute)
+	})
+
+	t.Run("não deve preencher os timestamps desativados no SaveMany", func(t *testing.T) {
+		unmanaged := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithManagedTimestamps(false, false))
+
+		entities := []TestSQLEntity{{Name: "Maria", CreatedAt: past, UpdatedAt: past}}
+		_, err := unmanaged.SaveMany(ctx, entities)
+		assert.NoError(t, err)
+
+		found, err := unmanaged.FindOne(ctx, map[string]any{"name": "Maria"})
+		assert.NoError(t, err)
+		assert.Equal(t, past, found.UpdatedAt.UTC())
+	})
+}
+
+// ==================== TESTES UPDATE FIELDS ====================
+
+func TestSQLUpdateFields(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve atualizar apenas o nome preservando os demais campos", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 30, Score: 80.5, Active: true})
+
+		result, err := store.UpdateFields(ctx, &TestSQLEntity{ID: saved.ID, Name: "Atualizado"}, "name")
+		assert.NoError(t, err)
+		assert.Equal(t, "Atualizado", result.Name)
+		assert.Equal(t, 30, result.Age)
+		assert.Equal(t, 80.5, result.Score)
+		assert.True(t, result.Active)
+	})
+
+	t.Run("deve permitir atualizar campo para o valor zero", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 30})
+
+		result, err := store.UpdateFields(ctx, &TestSQLEntity{ID: saved.ID}, "age")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Age)
+		assert.Equal(t, "Original", result.Name)
+	})
+
+	t.Run("deve retornar erro sem campos informados", func(t *testing.T) {
+		_, err := store.UpdateFields(ctx, &TestSQLEntity{ID: 1, Name: "Teste"})
+		assert.Error(t, err)
+	})
+
+	t.Run("deve retornar erro para campo inexistente", func(t *testing.T) {
+		_, err := store.UpdateFields(ctx, &TestSQLEntity{ID: 1}, "inexistente")
+		assert.Error(t, err)
+	})
+
+	t.Run("deve retornar erro para registro inexistente", func(t *testing.T) {
+		_, err := store.UpdateFields(ctx, &TestSQLEntity{ID: 99999, Name: "Teste"}, "name")
+		assert.Error(t, err)
+	})
+}
+
+func TestSQLErrNotFound(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"FindById", func() error { _, err := store.FindById(ctx, 99999); return err }},
+		{"FindByIdInto", func() error { return store.FindByIdInto(ctx, 99999, &TestSQLEntity{}) }},
+		{"FindOne", func() error { _, err := store.FindOne(ctx, map[string]any{"name": "Inexistente"}); return err }},
+		{"Update", func() error { _, err := store.Update(ctx, &TestSQLEntity{ID: 99999, Name: "Teste"}); return err }},
+		{"UpdateFields", func() error {
+			_, err := store.UpdateFields(ctx, &TestSQLEntity{ID: 99999, Name: "Teste"}, "name")
+			return err
+		}},
+		{"Delete", func() error { return store.Delete(ctx, 99999) }},
+		{"DeleteOne", func() error { return store.DeleteOne(ctx, map[string]any{"name": "Inexistente"}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run("deve retornar ErrNotFound no "+tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.call(), ErrNotFound)
+		})
+	}
+}
+
+func TestSQLUpdateWhere(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve atualizar quando o registro atende ao filtro extra", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Pendente", Age: 30})
+		saved.Name = "Processado"
+
+		result, err := store.UpdateWhere(ctx, saved, map[string]any{"name": "Pendente", "age__gte": 18})
+		assert.NoError(t, err)
+		assert.Equal(t, "Processado", result.Name)
+
+		found, _ := store.FindById(ctx, saved.ID)
+		assert.Equal(t, "Processado", found.Name)
+	})
+
+	t.Run("deve retornar ErrConflict quando o filtro extra não é atendido", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Processado", Age: 30})
+		saved.Name = "Outro"
+
+		_, err := store.UpdateWhere(ctx, saved, map[string]any{"name": "Pendente"})
+		assert.ErrorIs(t, err, ErrConflict)
+
+		found, _ := store.FindById(ctx, saved.ID)
+		assert.Equal(t, "Processado", found.Name)
+	})
+
+	t.Run("deve retornar ErrNotFound para registro inexistente", func(t *testing.T) {
+		_, err := store.UpdateWhere(ctx, &TestSQLEntity{ID: 99999, Name: "Teste"}, map[string]any{"name": "Pendente"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("deve se comportar como Update sem filtro extra", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Original"})
+		saved.Name = "Atualizado"
+
+		result, err := store.UpdateWhere(ctx, saved, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Atualizado", result.Name)
+	})
+}
+
+// ==================== TESTES UPDATE MANY ====================
+
+func TestSQLUpdateMany(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		input   []EntityFieldsToUpdate
+		check   func(*testing.T, *BulkWriteResult)
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "deve atualizar um único registro",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 25})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name": "Original"},
+					Fields: map[string]any{"name": "Atualizado"},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(1), result.ModifiedCount)
+
+				records, _ := store.FindAll(ctx, map[string]any{"name": "Atualizado"}, FindOptions{})
+				assert.Equal(t, 1, len(records))
+			},
+		},
+		{
+			name: "deve atualizar múltiplos registros com filtros diferentes",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Pedro", Age: 35})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name": "João"},
+					Fields: map[string]any{"name": "João Atualizado"},
+				},
+				{
+					Filter: map[string]any{"name": "Maria"},
+					Fields: map[string]any{"name": "Maria Atualizada"},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.ModifiedCount)
+
+				records, _ := store.FindAll(ctx, map[string]any{"name": "Pedro"}, FindOptions{})
+				assert.Equal(t, 1, len(records))
+			},
+		},
+		{
+			name: "deve atualizar vários registros com mesmo filtro",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Grupo A", Active: true})
+				store.Save(ctx, &TestSQLEntity{Name: "Grupo A", Active: true})
+				store.Save(ctx, &TestSQLEntity{Name: "Grupo B", Active: false})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"active": true},
+					Fields: map[string]any{"name": "Grupo A Atualizado"},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.ModifiedCount)
+
+				records, _ := store.FindAll(ctx, map[string]any{"name": "Grupo A Atualizado"}, FindOptions{})
+				assert.Equal(t, 2, len(records))
+			},
+		},
+		{
+			name: "deve atualizar updated_at automaticamente",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original"})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name": "Original"},
+					Fields: map[string]any{"name": "Atualizado"},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				records, _ := store.FindAll(ctx, map[string]any{"name": "Atualizado"}, FindOptions{})
+				assert.True(t, time.Since(records[0].UpdatedAt) < time.Minute)
+			},
+		},
+		{
+			name: "deve usar operador __like no filtro",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João Silva", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "João Santos", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria Silva", Age: 35})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name__like": "João%"},
+					Fields: map[string]any{"active": true},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.ModifiedCount)
+			},
+		},
+		{
+			name: "deve usar operador __in no filtro",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Registro 1", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "Registro 2", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Registro 3", Age: 35})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"age__in": []int{25, 35}},
+					Fields: map[string]any{"active": true},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.ModifiedCount)
+			},
+		},
+		{
+			name: "deve usar operador __gte no filtro",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Jovem", Age: 20})
+				store.Save(ctx, &TestSQLEntity{Name: "Adulto", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Senior", Age: 50})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"age__gte": 30},
+					Fields: map[string]any{"active": true},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.ModifiedCount)
+			},
+		},
+		{
+			name:    "deve retornar erro quando slice vazio",
+			setup:   func() {},
+			input:   []EntityFieldsToUpdate{},
+			wantErr: true,
+			errMsg:  "nenhum update fornecido",
+		},
+		{
+			name: "deve retornar erro quando filtro vazio",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original"})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{},
+					Fields: map[string]any{"name": "Atualizado"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "filtro é obrigatório para update 0",
+		},
+		{
+			name: "deve retornar erro quando campos vazios",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original"})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name": "Original"},
+					Fields: map[string]any{},
+				},
+			},
+			wantErr: true,
+			errMsg:  "campos para atualização são obrigatórios para update 0",
+		},
+		{
+			name: "deve retornar zero quando filtro não encontra",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original"})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name": "NaoExiste"},
+					Fields: map[string]any{"name": "Atualizado"},
+				},
+			},
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(0), result.ModifiedCount)
+			},
+		},
+		{
+			name: "deve fazer rollback em caso de erro no meio da transação",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original 1"})
+				store.Save(ctx, &TestSQLEntity{Name: "Original 2"})
+			},
+			input: []EntityFieldsToUpdate{
+				{
+					Filter: map[string]any{"name": "Original 1"},
+					Fields: map[string]any{"name": "Atualizado 1"},
+				},
+				{
+					Filter: map[string]any{},
+					Fields: map[string]any{"name": "Atualizado 2"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "filtro é obrigatório para update 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("DELETE FROM test_entities")
+			tt.setup()
+
+			result, err := store.UpdateMany(ctx, tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestSQLUpdateMany_WithoutUpdatedAt(t *testing.T) {
+	db, err := setupSQLDBWithoutTimestamps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "simple_entities", "id", true)
+	ctx := context.Background()
+
+	saved, _ := store.Save(ctx, &TestSQLEntityWithoutTimestamps{Name: "Original"})
+
+	result, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{
+		{
+			Filter: map[string]any{"id": saved.ID},
+			Fields: map[string]any{"name": "Atualizado"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.MatchedCount)
+
+	found, err := store.FindById(ctx, saved.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Atualizado", found.Name)
+}
+
+func TestSQLUpdateMany_MatchedCount(t *testing.T) {
+	ctx := context.Background()
+
+	seed := func(t *testing.T, db *sql.DB) {
+		for i := range 4 {
+			_, err := db.Exec("INSERT INTO test_entities (name, age, active) VALUES (?, ?, ?)", fmt.Sprintf("Registro %d", i), 20+i, i == 1)
+			assert.NoError(t, err)
+		}
+	}
+
+	t.Run("deve contar encontrados e alterados separadamente no MySQL", func(t *testing.T) {
+		db, err := setupSQLDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		// Reproduz o RowsAffected do MySQL, que ignora as linhas cujo valor não muda
+		_, err = db.Exec(`
+			CREATE TRIGGER skip_unchanged BEFORE UPDATE ON test_entities
+			WHEN NEW.active IS OLD.active
+			BEGIN SELECT RAISE(IGNORE); END;
+		`)
+		assert.NoError(t, err)
+		seed(t, db)
+
+		// O SQLite aceita as queries geradas para o MySQL, exercitando a contagem por COUNT(*)
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverMysql, "test_entities", "id", true)
+
+		result, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{
+			{Filter: map[string]any{"age__gte": 21}, Fields: map[string]any{"active": true}},
+			{Filter: map[string]any{"age__gt": 99}, Fields: map[string]any{"active": false}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.MatchedCount)
+		assert.Equal(t, int64(2), result.ModifiedCount)
+		assert.Greater(t, result.MatchedCount, result.ModifiedCount)
+	})
+
+	t.Run("deve usar as linhas afetadas como encontrados nos demais drivers", func(t *testing.T) {
+		db, err := setupSQLDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		seed(t, db)
+
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+
+		result, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{
+			{Filter: map[string]any{"age__gte": 21}, Fields: map[string]any{"active": true}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.MatchedCount)
+		assert.Equal(t, int64(3), result.ModifiedCount)
+	})
+}
+
+func TestSQLUpdateMany_ProtectedColumns(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	saved, err := store.Save(ctx, &TestSQLEntity{Name: "Protegido", CreatedAt: createdAt, UpdatedAt: createdAt})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		fields map[string]any
+	}{
+		{name: "deve rejeitar alteração da chave primária", fields: map[string]any{"id": 999, "name": "Novo"}},
+		{name: "deve rejeitar alteração de created_at", fields: map[string]any{"created_at": time.Now()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{
+				{Filter: map[string]any{"id": saved.ID}, Fields: tt.fields},
+			})
+			assert.ErrorIs(t, err, ErrProtectedColumn)
+
+			found, err := store.FindById(ctx, saved.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "Protegido", found.Name)
+			assert.True(t, found.CreatedAt.Equal(createdAt))
+		})
+	}
+
+	t.Run("deve ignorar updated_at informado e usar o horário do store", func(t *testing.T) {
+		_, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{
+			{Filter: map[string]any{"id": saved.ID}, Fields: map[string]any{"name": "Atualizado", "updated_at": createdAt}},
+		})
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Atualizado", found.Name)
+		assert.True(t, time.Since(found.UpdatedAt) < time.Minute)
+	})
+}
+
+// ==================== TESTES UPSERT ====================
+
+func TestSQLUpsert(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		input   *TestSQLEntity
+		filters []StoreUpsertFilter
+		check   func(*testing.T, *UpdateResult)
+		wantErr bool
+	}{
+		{
+			name:  "deve inserir novo registro quando não existe",
+			setup: func() {},
+			input: &TestSQLEntity{
+				Name:   "Novo Registro",
+				Age:    25,
+				Active: true,
+			},
+			filters: nil,
+			check: func(t *testing.T, result *UpdateResult) {
+				assert.Equal(t, int64(1), result.UpsertedCount)
+
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+			},
+		},
+		{
+			name: "deve atualizar registro existente (SQLite usa INSERT OR REPLACE)",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 25})
+			},
+			input: &TestSQLEntity{
+				ID:   1,
+				Name: "Atualizado",
+				Age:  30,
+			},
+			filters: nil,
+			check: func(t *testing.T, result *UpdateResult) {
+				assert.Equal(t, int64(1), result.UpsertedCount)
+
+				found, _ := store.FindById(ctx, 1)
+				assert.Equal(t, "Atualizado", found.Name)
+				assert.Equal(t, 30, found.Age)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("DELETE FROM test_entities")
+			tt.setup()
+
+			result, err := store.Upsert(ctx, tt.input, tt.filters)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+
+	t.Run("deve rejeitar filtros com Or", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+		filters := []StoreUpsertFilter{{UpsertFieldKey: "id", Or: true}, {UpsertFieldKey: "name", Or: true}}
+
+		_, err := store.Upsert(ctx, &TestSQLEntity{Name: "Ana"}, filters)
+		assert.ErrorIs(t, err, ErrUnsupported)
+
+		_, err = store.UpsertMany(ctx, []TestSQLEntity{{Name: "Ana"}}, filters)
+		assert.ErrorIs(t, err, ErrUnsupported)
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(0), *count)
+	})
+}
+
+func TestSQLUpsertReturning(t *testing.T) {
+	db, err := setupSQLDBWithDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntityWithDefaults](db, enum.DatabaseDriverSqlite, "default_entities", "id", true)
+	ctx := context.Background()
+
+	saved, err := store.Save(ctx, &TestSQLEntityWithDefaults{Name: "Original"})
+	assert.NoError(t, err)
+
+	t.Run("deve retornar o registro gravado no caminho de atualização", func(t *testing.T) {
+		result, err := store.UpsertReturning(ctx, &TestSQLEntityWithDefaults{ID: saved.ID, Name: "Atualizado"}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, saved.ID, result.ID)
+		assert.Equal(t, "Atualizado", result.Name)
+		// Colunas preenchidas pelo banco voltam no registro retornado
+		assert.Equal(t, "pendente", result.Status)
+		assert.False(t, result.CreatedAt.IsZero())
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(1), *count)
+	})
+
+	t.Run("deve ler o registro pelas colunas de conflito", func(t *testing.T) {
+		result, err := store.UpsertReturning(ctx, &TestSQLEntityWithDefaults{ID: saved.ID, Name: "Pelo Nome"}, []StoreUpsertFilter{
+			{UpsertFieldKey: "name", UpsertBsonKey: "Name"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, saved.ID, result.ID)
+		assert.Equal(t, "Pelo Nome", result.Name)
+	})
+
+	t.Run("deve retornar o registro inserido com a chave gerada", func(t *testing.T) {
+		first, err := store.UpsertReturning(ctx, &TestSQLEntityWithDefaults{Name: "Novo 1"}, nil)
+		assert.NoError(t, err)
+		assert.NotZero(t, first.ID)
+		assert.Equal(t, "Novo 1", first.Name)
+		assert.Equal(t, "pendente", first.Status)
+
+		second, err := store.UpsertReturning(ctx, &TestSQLEntityWithDefaults{Name: "Novo 2"}, nil)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first.ID, second.ID)
+
+		found, err := store.FindById(ctx, first.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Novo 1", found.Name)
+
+		db.Exec("DELETE FROM default_entities WHERE id <> ?", saved.ID)
+	})
+
+	t.Run("deve falhar com coluna de conflito desconhecida", func(t *testing.T) {
+		_, err := store.UpsertReturning(ctx, &TestSQLEntityWithDefaults{ID: saved.ID, Name: "X"}, []StoreUpsertFilter{
+			{UpsertFieldKey: "email", UpsertBsonKey: "Email"},
+		})
+
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+
+		found, _ := store.FindById(ctx, saved.ID)
+		assert.Equal(t, "Pelo Nome", found.Name)
+	})
+}
+
+func TestSQLUpsertManyWith(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	existing, err := store.Save(ctx, &TestSQLEntity{Name: "Original", Age: 20})
+	assert.NoError(t, err)
+
+	result, err := store.UpsertManyWith(ctx, []UpsertItem[TestSQLEntity]{
+		{Entity: TestSQLEntity{ID: existing.ID, Name: "Atualizado", Age: 21}},
+		{Entity: TestSQLEntity{Name: "Novo", Age: 30}, Filters: []StoreUpsertFilter{{UpsertFieldKey: "name", UpsertBsonKey: "Name"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.InsertedCount)
+
+	updated, err := store.FindById(ctx, existing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Atualizado", updated.Name)
+	assert.Equal(t, 21, updated.Age)
+
+	inserted, err := store.FindOne(ctx, map[string]any{"name": "Novo"})
+	assert.NoError(t, err)
+	assert.Equal(t, 30, inserted.Age)
+
+	count, err := store.Count(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *count)
+
+	empty, err := store.UpsertManyWith(ctx, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+// recordingConnector driver que apenas registra os comandos executados, para verificar o SQL
+// gerado para bancos que não rodam nos testes (ex.: Oracle)
+type recordingConnector struct {
+	queries []string
+	args    [][]any
+	// result retornado pelo ExecContext; nil retorna uma linha afetada
+	result driver.Result
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *recordingConnector) Driver() driver.Driver                        { return nil }
+func (c *recordingConnector) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare não suportado")
+}
+func (c *recordingConnector) Close() error              { return nil }
+func (c *recordingConnector) Begin() (driver.Tx, error) { return c, nil }
+func (c *recordingConnector) Commit() error             { return nil }
+func (c *recordingConnector) Rollback() error           { return nil }
+
+func (c *recordingConnector) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, values)
+	if c.result != nil {
+		return c.result, nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// QueryContext registra a consulta e retorna um resultado vazio
+func (c *recordingConnector) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, values)
+	return emptyRows{}, nil
+}
+
+// emptyRows resultado sem colunas nem linhas do recordingConnector
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+type oracleUpsertEntity struct {
+	ID        int64     `db:"id"`
+	Email     string    `db:"email"`
+	Tenant    string    `db:"tenant"`
+	Name      string    `db:"name"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func TestSQLFindLast_Oracle(t *testing.T) {
+	connector := &recordingConnector{}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	store := NewSQLStore[oracleUpsertEntity](db, enum.DatabaseDriverOracle, "users", "id", false)
+
+	_, err := store.FindLast(context.Background(), 5, nil, "updated_at")
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users ORDER BY updated_at DESC FETCH FIRST :1 ROWS ONLY", connector.queries[0])
+	assert.Equal(t, []any{int64(5)}, connector.args[0])
+
+	_, err = store.FindLast(context.Background(), 3, map[string]any{"tenant": "acme"}, "updated_at")
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE tenant = ? ORDER BY updated_at DESC FETCH FIRST :2 ROWS ONLY", connector.queries[1])
+	assert.Equal(t, []any{"acme", int64(3)}, connector.args[1])
+}
+
+func TestSQLUpsert_OracleMerge(t *testing.T) {
+	filters := []StoreUpsertFilter{{UpsertFieldKey: "email"}, {UpsertFieldKey: "tenant"}}
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctx := context.Background()
+
+	t.Run("deve gerar MERGE com binds posicionais na ordem das colunas", func(t *testing.T) {
+		connector := &recordingConnector{}
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		store := NewSQLStore[oracleUpsertEntity](db, enum.DatabaseDriverOracle, "users", "id", false)
+		entity := &oracleUpsertEntity{ID: 7, Email: "ana@example.com", Tenant: "acme", Name: "Ana", UpdatedAt: updatedAt}
+
+		_, err := store.Upsert(ctx, entity, filters)
+		assert.NoError(t, err)
+		assert.Len(t, connector.queries, 1)
+
+		assert.Equal(t, "MERGE INTO users t USING (SELECT :1 AS id, :2 AS email, :3 AS tenant, :4 AS name, :5 AS updated_at FROM dual) s "+
+			"ON (t.email = s.email AND t.tenant = s.tenant) "+
+			"WHEN MATCHED THEN UPDATE SET t.name = s.name, t.updated_at = :6 "+
+			"WHEN NOT MATCHED THEN INSERT (id, email, tenant, name, updated_at) VALUES (s.id, s.email, s.tenant, s.name, s.updated_at)",
+			connector.queries[0])
+
+		args := connector.args[0]
+		assert.Len(t, args, 6)
+		assert.Equal(t, []any{int64(7), "ana@example.com", "acme", "Ana", updatedAt}, args[:5])
+		assert.IsType(t, time.Time{}, args[5])
+		assert.NotEqual(t, updatedAt, args[5])
+	})
+
+	t.Run("deve vincular a chave gerada ausente das colunas do INSERT", func(t *testing.T) {
+		connector := &recordingConnector{}
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		store := NewSQLStore[oracleUpsertEntity](db, enum.DatabaseDriverOracle, "users", "id", true)
+		items := []UpsertItem[oracleUpsertEntity]{
+			{Entity: oracleUpsertEntity{Email: "ana@example.com", Tenant: "acme", Name: "Ana"}, Filters: []StoreUpsertFilter{{UpsertFieldKey: "id"}, {UpsertFieldKey: "tenant"}}},
+		}
+
+		_, err := store.(*SQLStore[oracleUpsertEntity]).UpsertManyWith(ctx, items)
+		assert.NoError(t, err)
+		assert.Len(t, connector.queries, 1)
+
+		assert.Equal(t, "MERGE INTO users t USING (SELECT :1 AS email, :2 AS tenant, :3 AS name, :4 AS updated_at, :5 AS id FROM dual) s "+
+			"ON (t.id = s.id AND t.tenant = s.tenant) "+
+			"WHEN MATCHED THEN UPDATE SET t.email = s.email, t.name = s.name, t.updated_at = :6 "+
+			"WHEN NOT MATCHED THEN INSERT (email, tenant, name, updated_at) VALUES (s.email, s.tenant, s.name, s.updated_at)",
+			connector.queries[0])
+		assert.Equal(t, []any{"ana@example.com", "acme", "Ana"}, connector.args[0][:3])
+		assert.Equal(t, int64(0), connector.args[0][4])
+	})
+
+	t.Run("deve omitir o UPDATE quando todas as colunas são de conflito", func(t *testing.T) {
+		connector := &recordingConnector{}
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverOracle, "simple_entities", "id", false)
+
+		_, err := store.Upsert(ctx, &TestSQLEntityWithoutTimestamps{ID: 1, Name: "Ana"}, []StoreUpsertFilter{{UpsertFieldKey: "id"}, {UpsertFieldKey: "name"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "MERGE INTO simple_entities t USING (SELECT :1 AS id, :2 AS name FROM dual) s "+
+			"ON (t.id = s.id AND t.name = s.name) "+
+			"WHEN NOT MATCHED THEN INSERT (id, name) VALUES (s.id, s.name)",
+			connector.queries[0])
+		assert.Equal(t, []any{int64(1), "Ana"}, connector.args[0])
+	})
+}
+
+func TestSQLUpsertMany_Unordered(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE checked_entities (
+			id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL CHECK (name <> 'inválido')
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "checked_entities", "id", true)
+	ctx := context.Background()
+
+	// O segundo registro viola o CHECK da tabela
+	batch := []TestSQLEntityWithoutTimestamps{{Name: "João"}, {Name: "inválido"}, {Name: "Maria"}}
+
+	t.Run("deve desfazer o lote inteiro por padrão", func(t *testing.T) {
+		_, err := store.UpsertMany(ctx, batch, nil)
+		assert.Error(t, err)
+
+		total, err := store.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Zero(t, *total)
+	})
+
+	t.Run("deve aplicar os demais registros sem ordenação", func(t *testing.T) {
+		result, err := store.Scoped(WithOrderedWrites(false)).UpsertMany(ctx, batch, nil)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "operação 1")
+
+		assert.NotNil(t, result)
+		assert.Len(t, result.Errors, 1)
+		assert.ErrorContains(t, result.Errors[1], "CHECK")
+		assert.Equal(t, int64(2), result.UpsertedCount)
+		assert.Equal(t, int64(2), result.InsertedCount)
+		assert.Contains(t, result.UpsertedIDs, int64(0))
+		assert.Contains(t, result.UpsertedIDs, int64(2))
+
+		results, err := store.FindAll(ctx, nil, FindOptions{SortBy: "id", OrderBy: "ASC"})
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, "João", results[0].Name)
+		assert.Equal(t, "Maria", results[1].Name)
+	})
+
+	t.Run("não deve reportar erros quando todos os registros são aplicados", func(t *testing.T) {
+		result, err := store.Scoped(WithOrderedWrites(false)).UpsertMany(ctx, []TestSQLEntityWithoutTimestamps{{Name: "Ana"}}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Errors)
+	})
+}
+
+func TestSQLUpsertMany_UnorderedRowsAffectedError(t *testing.T) {
+	// O driver não informa as linhas afetadas, então o resultado do MySQL não pode ser contabilizado
+	connector := &recordingConnector{result: driver.ResultNoRows}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	store := NewSQLStore[oracleUpsertEntity](db, enum.DatabaseDriverMysql, "users", "id", false, WithOrderedWrites(false))
+
+	result, err := store.UpsertMany(context.Background(), []oracleUpsertEntity{{ID: 1, Email: "ana@exemplo.com"}}, nil)
+	assert.Error(t, err)
+	if assert.NotNil(t, result) {
+		assert.Len(t, result.Errors, 1)
+		assert.ErrorContains(t, result.Errors[0], "linhas afetadas")
+		assert.Zero(t, result.UpsertedCount)
+		assert.Zero(t, result.InsertedCount)
+	}
+}
+
+func TestSQLTimestampColumns(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type agenda struct {
+		ID        int64     `db:"id"`
+		Title     string    `db:"title"`
+		CreatedAt time.Time `db:"criado_em"`
+		UpdatedAt time.Time `db:"alterado_em"`
+	}
+
+	_, err = db.Exec(`CREATE TABLE agenda (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT,
+		criado_em TIMESTAMP,
+		alterado_em TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[agenda](db, enum.DatabaseDriverSqlite, "agenda", "id", true)
+	ctx := context.Background()
+
+	for i, title := range []string{"Terceiro", "Primeiro", "Segundo"} {
+		createdAt := time.Date(2024, 1, []int{3, 1, 2}[i], 0, 0, 0, 0, time.UTC)
+		_, err := store.Save(ctx, &agenda{Title: title, CreatedAt: createdAt})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, timestampFields{created: "criado_em", updated: "alterado_em"}, store.(*SQLStore[agenda]).timestamps)
+
+	t.Run("deve ordenar pela coluna do CreatedAt por padrão", func(t *testing.T) {
+		results, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+
+		titles := make([]string, len(results))
+		for i, result := range results {
+			titles[i] = result.Title
+		}
+		assert.Equal(t, []string{"Primeiro", "Segundo", "Terceiro"}, titles)
+	})
+
+	t.Run("deve aceitar createdAt como alias da coluna", func(t *testing.T) {
+		result, err := store.FindOne(ctx, nil, FindOptions{SortBy: "createdAt", OrderBy: "DESC"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Terceiro", result.Title)
+	})
+
+	t.Run("deve preencher a coluna do UpdatedAt no UpdateMany", func(t *testing.T) {
+		_, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"title": "Primeiro"}, Fields: map[string]any{"title": "Primeiro!"}}})
+		assert.NoError(t, err)
+
+		found, err := store.FindOne(ctx, map[string]any{"title": "Primeiro!"})
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), found.UpdatedAt, time.Minute)
+	})
+
+	t.Run("deve proteger a coluna do CreatedAt no UpdateMany", func(t *testing.T) {
+		_, err := store.UpdateMany(ctx, []EntityFieldsToUpdate{{Filter: map[string]any{"title": "Segundo"}, Fields: map[string]any{"criado_em": time.Now()}}})
+		assert.ErrorIs(t, err, ErrProtectedColumn)
+	})
+}
+
+// ==================== TESTES UPSERT MANY ====================
+
+func TestSQLUpsertMany(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		input   []TestSQLEntity
+		filters []StoreUpsertFilter
+		check   func(*testing.T, *BulkWriteResult)
+		wantErr bool
+	}{
+		{
+			name:  "deve inserir múltiplos novos registros",
+			setup: func() {},
+			input: []TestSQLEntity{
+				{Name: "Doc 1", Age: 25},
+				{Name: "Doc 2", Age: 30},
+				{Name: "Doc 3", Age: 35},
+			},
+			filters: nil,
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(3), result.UpsertedCount)
+
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(3), *count)
+			},
+		},
+		{
+			name:  "deve informar os registros inseridos e seus ids",
+			setup: func() {},
+			input: []TestSQLEntity{
+				{Name: "Novo 1", Age: 25},
+				{Name: "Novo 2", Age: 30},
+			},
+			filters: nil,
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.InsertedCount)
+				assert.Len(t, result.UpsertedIDs, 2)
+
+				for index, id := range result.UpsertedIDs {
+					found, err := store.FindById(ctx, id)
+					assert.NoError(t, err)
+					assert.Equal(t, fmt.Sprintf("Novo %d", index+1), found.Name)
+				}
+			},
+		},
+		{
+			name: "deve informar apenas os ids dos registros novos em lote misto",
+			setup: func() {
+				db.Exec("INSERT INTO test_entities (id, name, age) VALUES (100, 'Existente', 20)")
+			},
+			input: []TestSQLEntity{
+				{ID: 100, Name: "Existente Atualizado", Age: 21},
+				{Name: "Novo 1", Age: 25},
+				{Name: "Novo 2", Age: 30},
+			},
+			filters: nil,
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Equal(t, int64(2), result.InsertedCount)
+				assert.Len(t, result.UpsertedIDs, 2)
+				assert.NotContains(t, result.UpsertedIDs, int64(0))
+
+				for index, id := range result.UpsertedIDs {
+					assert.NotEqual(t, int64(100), id)
+
+					found, err := store.FindById(ctx, id)
+					assert.NoError(t, err)
+					assert.Equal(t, fmt.Sprintf("Novo %d", index), found.Name)
+				}
+
+				existing, err := store.FindById(ctx, 100)
+				assert.NoError(t, err)
+				assert.Equal(t, "Existente Atualizado", existing.Name)
+			},
+		},
+		{
+			name:    "deve retornar nil para slice vazio",
+			setup:   func() {},
+			input:   []TestSQLEntity{},
+			filters: nil,
+			check: func(t *testing.T, result *BulkWriteResult) {
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("DELETE FROM test_entities")
+			tt.setup()
+
+			result, err := store.UpsertMany(ctx, tt.input, tt.filters)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+// ==================== TESTES DELETE ====================
+
+func TestSQLDelete(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func() int
+		check   func(*testing.T, int)
+		wantErr bool
+	}{
+		{
+			name: "deve deletar registro existente",
+			setup: func() int {
+				saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar"})
+				return saved.ID
+			},
+			check: func(t *testing.T, id int) {
+				exists := store.Has(ctx, id)
+				assert.False(t, exists)
+			},
+		},
+		{
+			name: "deve retornar ErrNotFound para registro inexistente",
+			setup: func() int {
+				return 99999
+			},
+			wantErr: true,
+		},
+		{
+			name: "deve manter outros registros intactos",
+			setup: func() int {
+				store.Save(ctx, &TestSQLEntity{Name: "Manter 1"})
+				toDelete, _ := store.Save(ctx, &TestSQLEntity{Name: "Deletar"})
+				store.Save(ctx, &TestSQLEntity{Name: "Manter 2"})
+				return toDelete.ID
+			},
+			check: func(t *testing.T, id int) {
+				assert.False(t, store.Has(ctx, id))
+
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(2), *count)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("DELETE FROM test_entities")
+
+			id := tt.setup()
+			err := store.Delete(ctx, id)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrNotFound)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			if tt.check != nil {
+				tt.check(t, id)
+			}
+		})
+	}
+}
+
+// ==================== TESTES DELETE IF EXISTS ====================
+
+func TestSQLDeleteIfExists(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve retornar true ao remover registro existente", func(t *testing.T) {
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar"})
+
+		deleted, err := store.DeleteIfExists(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.False(t, store.Has(ctx, saved.ID))
+	})
+
+	t.Run("deve retornar false sem erro para registro inexistente", func(t *testing.T) {
+		deleted, err := store.DeleteIfExists(ctx, 99999)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+}
+
+func TestSQLDeleteReturning(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve retornar a quantidade removida", func(t *testing.T) {
+		saved, _ := store.Save(ctx, &TestSQLEntity{Name: "Para Deletar"})
+
+		result, err := store.DeleteReturning(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.DeletedCount)
+		assert.False(t, store.Has(ctx, saved.ID))
+	})
+
+	t.Run("deve retornar zero sem erro para registro inexistente", func(t *testing.T) {
+		result, err := store.DeleteReturning(ctx, 99999)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), result.DeletedCount)
+	})
+}
+
+// ==================== TESTES DELETE ONE ====================
+
+func TestSQLDeleteOne(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		filter  map[string]interface{}
+		check   func(*testing.T)
+		wantErr bool
+	}{
+		{
+			name: "deve deletar registro com filtro simples",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 30})
+			},
+			filter: map[string]interface{}{"name": "João"},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+
+				result, _ := store.FindOne(ctx, map[string]interface{}{"name": "Maria"})
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name: "deve deletar com filtro booleano",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc1", Active: true})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc2", Active: false})
+			},
+			filter: map[string]interface{}{"active": true},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+
+				result, _ := store.FindOne(ctx, map[string]interface{}{"active": false})
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name: "deve deletar com operador __gt",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 35})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 40})
+			},
+			filter: map[string]interface{}{"age__gt": 30},
+			check: func(t *testing.T) {
+				// Deve deletar apenas um (o primeiro que encontrar > 30)
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(2), *count)
+			},
+		},
+		{
+			name: "deve deletar com operador __gte",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
+			},
+			filter: map[string]interface{}{"age__gte": 30},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+			},
+		},
+		{
+			name: "deve deletar com operador __lt",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
+			},
+			filter: map[string]interface{}{"age__lt": 25},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+			},
+		},
+		{
+			name: "deve deletar com operador __lte",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
+			},
+			filter: map[string]interface{}{"age__lte": 25},
+			check: func(t *testing.T) {
+				// Deve deletar apenas um
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(2), *count)
+			},
+		},
+		{
+			name: "deve deletar com operador __like",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João Silva"})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria Santos"})
+			},
+			filter: map[string]interface{}{"name__like": "%Silva%"},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+
+				result, _ := store.FindOne(ctx, map[string]interface{}{"name": "Maria Santos"})
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name: "deve deletar com operador __in",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João"})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria"})
+				store.Save(ctx, &TestSQLEntity{Name: "Pedro"})
+			},
+			filter: map[string]interface{}{"name__in": []string{"João", "Maria"}},
+			check: func(t *testing.T) {
+				// Deve deletar apenas um
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(2), *count)
+			},
+		},
+		{
+			name: "deve deletar com múltiplos filtros",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "João", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria", Age: 25})
+			},
+			filter: map[string]interface{}{"name": "João", "age": 25},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(2), *count)
+
+				result, _ := store.FindOne(ctx, map[string]interface{}{"name": "João", "age": 30})
+				assert.NotNil(t, result)
+			},
+		},
+		{
+			name: "deve retornar erro quando nenhum registro é encontrado",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João"})
+			},
+			filter:  map[string]interface{}{"name": "NaoExiste"},
+			wantErr: true,
+		},
+		{
+			name:    "deve retornar erro quando filtro é nulo",
+			setup:   func() {},
+			filter:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "deve retornar erro quando filtro é vazio",
+			setup:   func() {},
+			filter:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name: "deve manter outros registros intactos",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Manter 1"})
+				store.Save(ctx, &TestSQLEntity{Name: "Deletar"})
+				store.Save(ctx, &TestSQLEntity{Name: "Manter 2"})
+			},
+			filter: map[string]interface{}{"name": "Deletar"},
+			check: func(t *testing.T) {
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(2), *count)
+
+				results, _ := store.FindAll(ctx, map[string]any{}, FindOptions{})
+				assert.Equal(t, 2, len(results))
+
+				for _, r := range results {
+					assert.NotEqual(t, "Deletar", r.Name)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("DELETE FROM test_entities")
+			tt.setup()
+
+			err := store.DeleteOne(ctx, tt.filter)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			if tt.check != nil {
+				tt.check(t)
+			}
+		})
+	}
+}
+
+// ==================== TESTES DELETE MANY ====================
+
+func TestSQLDeleteMany(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		filter  map[string]any
+		check   func(*testing.T, *DeleteResult)
+		wantErr bool
+	}{
+		{
+			name: "deve deletar múltiplos registros",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Active: true})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Active: true})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Active: false})
+			},
+			filter: map[string]any{"active": true},
+			check: func(t *testing.T, result *DeleteResult) {
+				assert.Equal(t, int64(2), result.DeletedCount)
+
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+			},
+		},
+		{
+			name: "deve usar operadores no filtro",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 20})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc", Age: 40})
+			},
+			filter: map[string]any{"age__gte": 30},
+			check: func(t *testing.T, result *DeleteResult) {
+				assert.Equal(t, int64(2), result.DeletedCount)
+			},
+		},
+		{
+			name: "deve usar operador __in",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc 1", Age: 25})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc 2", Age: 30})
+				store.Save(ctx, &TestSQLEntity{Name: "Doc 3", Age: 35})
+			},
+			filter: map[string]any{"age__in": []int{25, 35}},
+			check: func(t *testing.T, result *DeleteResult) {
+				assert.Equal(t, int64(2), result.DeletedCount)
+
+				count, _ := store.Count(ctx, map[string]any{})
+				assert.Equal(t, int64(1), *count)
+			},
+		},
+		{
+			name: "deve usar operador __like",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "João Silva"})
+				store.Save(ctx, &TestSQLEntity{Name: "João Santos"})
+				store.Save(ctx, &TestSQLEntity{Name: "Maria Silva"})
+			},
+			filter: map[string]any{"name__like": "João%"},
+			check: func(t *testing.T, result *DeleteResult) {
+				assert.Equal(t, int64(2), result.DeletedCount)
+			},
+		},
+		{
+			name: "deve retornar zero quando não encontra",
+			setup: func() {
+				store.Save(ctx, &TestSQLEntity{Name: "Doc"})
+			},
+			filter: map[string]any{"name": "NaoExiste"},
+			check: func(t *testing.T, result *DeleteResult) {
+				assert.Equal(t, int64(0), result.DeletedCount)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.Exec("DELETE FROM test_entities")
+			tt.setup()
+
+			result, err := store.DeleteMany(ctx, tt.filter)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+// ==================== TESTES DELETE BY IDS ====================
+
+func TestSQLDeleteManyBatch(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	for i, name := range []string{"Ana", "Ana", "Bruno", "Carla", "Davi"} {
+		_, err := store.Save(ctx, &TestSQLEntity{Name: name, Age: 20 + i})
+		assert.NoError(t, err)
+	}
+
+	t.Run("deve deletar os registros de cada filtro e agregar o total", func(t *testing.T) {
+		result, err := store.DeleteManyBatch(ctx, []map[string]any{
+			{"name": "Ana"},
+			{"name__in": []string{"Bruno", "Inexistente"}},
+			{"age__gt": 100},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.DeletedCount)
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+	})
+
+	t.Run("deve desfazer todos os deletes quando um falha", func(t *testing.T) {
+		_, err := store.DeleteManyBatch(ctx, []map[string]any{
+			{"name": "Carla"},
+			{"coluna_inexistente": 1},
+		})
+		assert.Error(t, err)
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+	})
+
+	t.Run("deve rejeitar lista vazia e filtro nulo", func(t *testing.T) {
+		_, err := store.DeleteManyBatch(ctx, nil)
+		assert.Error(t, err)
+
+		_, err = store.DeleteManyBatch(ctx, []map[string]any{{"name": "Carla"}, nil})
+		assert.Error(t, err)
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+	})
+}
+
+func TestSQLDeleteByIds(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	var ids []int
+	for i := range 5 {
+		saved, err := store.Save(ctx, &TestSQLEntity{Name: fmt.Sprintf("Lote %d", i)})
+		assert.NoError(t, err)
+		ids = append(ids, saved.ID)
+	}
+
+	t.Run("deve deletar apenas os ids informados", func(t *testing.T) {
+		result, err := store.DeleteByIds(ctx, []any{ids[0], ids[2], ids[4]})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), result.DeletedCount)
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+		assert.True(t, store.Has(ctx, ids[1]))
+		assert.True(t, store.Has(ctx, ids[3]))
+	})
+
+	t.Run("deve ignorar ids inexistentes na contagem", func(t *testing.T) {
+		result, err := store.DeleteByIds(ctx, []any{ids[0], 99999})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), result.DeletedCount)
+	})
+
+	t.Run("não deve executar nada para lista vazia", func(t *testing.T) {
+		result, err := store.DeleteByIds(ctx, []any{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), result.DeletedCount)
+
+		count, _ := store.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+	})
+}
+
+// ==================== TESTES WITH TRANSACTION ====================
+
+func TestSQLWithTransaction(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve executar operações em transação com sucesso", func(t *testing.T) {
+		result, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
+			return "success", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "success", result)
+	})
+
+	t.Run("deve fazer rollback em caso de erro", func(t *testing.T) {
+		_, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
+			return nil, fmt.Errorf("erro simulado")
+		})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "erro simulado")
+	})
+
+	t.Run("deve permitir operações SQL dentro da transação", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		result, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
+			tx, ok := txCtx.(*sql.Tx)
+			if !ok {
+				return nil, fmt.Errorf("contexto inválido")
+			}
+
+			_, err := tx.Exec("INSERT INTO test_entities (name, age, active, score) VALUES (?, ?, ?, ?)",
+				"Transação", 25, true, 80.0)
+			if err != nil {
+				return nil, err
+			}
+
+			return "inserted", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "inserted", result)
+
+		// Verifica se foi commitado
+		count, _ := store.Count(ctx, map[string]any{})
+		assert.Equal(t, int64(1), *count)
+	})
+
+	t.Run("deve fazer rollback quando transação falha", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		_, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
+			tx, ok := txCtx.(*sql.Tx)
+			if !ok {
+				return nil, fmt.Errorf("contexto inválido")
+			}
+
+			_, err := tx.Exec("INSERT INTO test_entities (name, age, active, score) VALUES (?, ?, ?, ?)",
+				"Vai Falhar", 25, true, 80.0)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, fmt.Errorf("erro forçado")
+		})
+
+		assert.Error(t, err)
+
+		// Verifica se foi feito rollback
+		count, _ := store.Count(ctx, map[string]any{})
+		assert.Equal(t, int64(0), *count)
+	})
+}
+
+func TestSQLWithTx(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Banco em memória: uma única conexão garante que todas as operações vejam as mesmas tabelas
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE audit_logs (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entities := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true).(*SQLStore[TestSQLEntity])
+	logs := NewSQLStore[TestSQLEntityWithoutTimestamps](db, enum.DatabaseDriverSqlite, "audit_logs", "id", true).(*SQLStore[TestSQLEntityWithoutTimestamps])
+	ctx := context.Background()
+
+	t.Run("deve confirmar as escritas de vários stores no commit", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+
+		saved, err := entities.WithTx(tx).Save(ctx, &TestSQLEntity{Name: "João", Age: 30})
+		assert.NoError(t, err)
+		_, err = logs.WithTx(tx).SaveMany(ctx, []TestSQLEntityWithoutTimestamps{{Name: "criado"}, {Name: "notificado"}})
+		assert.NoError(t, err)
+
+		// Leituras pelo store da transação enxergam as escritas ainda não confirmadas
+		found, err := entities.WithTx(tx).FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "João", found.Name)
+
+		assert.NoError(t, tx.Commit())
+
+		count, _ := entities.Count(ctx, nil)
+		assert.Equal(t, int64(1), *count)
+		count, _ = logs.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+	})
+
+	t.Run("deve descartar as escritas de vários stores no rollback", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = entities.WithTx(tx).Save(ctx, &TestSQLEntity{Name: "Maria", Age: 25})
+		assert.NoError(t, err)
+		_, err = logs.WithTx(tx).UpsertMany(ctx, []TestSQLEntityWithoutTimestamps{{Name: "descartado"}}, nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, tx.Rollback())
+
+		count, _ := entities.Count(ctx, nil)
+		assert.Equal(t, int64(1), *count)
+		count, _ = logs.Count(ctx, nil)
+		assert.Equal(t, int64(2), *count)
+	})
+
+	t.Run("deve executar o WithTransaction na transação externa", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+
+		result, err := entities.WithTx(tx).WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
+			return txCtx.(*sql.Tx) == tx, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, true, result)
+		assert.NoError(t, tx.Rollback())
+	})
+}
+func TestSQLWithRequestIDContextKey(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type requestIDKey struct{}
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-123")
+
+	t.Run("deve incluir o id da requisição no erro do banco", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "inexistente", "id", true, WithRequestIDContextKey(requestIDKey{}))
+
+		_, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.ErrorContains(t, err, "request_id=req-123")
+
+		var requestErr *RequestError
+		assert.ErrorAs(t, err, &requestErr)
+		assert.Equal(t, "req-123", requestErr.RequestID)
+	})
+
+	t.Run("deve preservar o erro original e identificar uma única vez", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithRequestIDContextKey(requestIDKey{}))
+
+		err := store.Delete(ctx, 999)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, 1, strings.Count(err.Error(), "request_id="))
+
+		missing := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "inexistente", "id", true, WithRequestIDContextKey(requestIDKey{}))
+		_, err = missing.UpsertMany(ctx, []TestSQLEntity{{Name: "João"}}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, 1, strings.Count(err.Error(), "request_id="))
+	})
+
+	t.Run("não deve alterar o erro sem id no contexto ou sem a opção", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithRequestIDContextKey(requestIDKey{}))
+		err := store.Delete(context.Background(), 999)
+		assert.NotContains(t, err.Error(), "request_id")
+
+		plain := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		err = plain.Delete(ctx, 999)
+		assert.NotContains(t, err.Error(), "request_id")
+	})
+}
+
+func TestSQLWithReadDB(t *testing.T) {
+	primary, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+
+	replica, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replica.Close()
+
+	// Bancos em memória: uma única conexão por banco mantém as tabelas visíveis
+	primary.SetMaxOpenConns(1)
+	replica.SetMaxOpenConns(1)
+
+	// Registro presente apenas na réplica, identificando as leituras feitas nela
+	_, err = replica.Exec("INSERT INTO test_entities (name, age) VALUES ('réplica', 1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntity](primary, enum.DatabaseDriverSqlite, "test_entities", "id", true, WithReadDB(replica))
+	ctx := context.Background()
+
+	saved, err := store.Save(ctx, &TestSQLEntity{Name: "primário", Age: 2})
+	assert.NoError(t, err)
+
+	t.Run("deve gravar no banco principal", func(t *testing.T) {
+		var count int
+		assert.NoError(t, primary.QueryRow("SELECT COUNT(*) FROM test_entities WHERE name = 'primário'").Scan(&count))
+		assert.Equal(t, 1, count)
+		assert.NoError(t, replica.QueryRow("SELECT COUNT(*) FROM test_entities WHERE name = 'primário'").Scan(&count))
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("deve ler da réplica", func(t *testing.T) {
+		found, err := store.FindOne(ctx, map[string]any{"name": "réplica"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, found.Age)
+
+		all, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, all, 1)
+		assert.Equal(t, "réplica", all[0].Name)
+
+		count, err := store.Count(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *count)
+
+		has, err := store.HasWhere(ctx, map[string]any{"name": "primário"})
+		assert.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("deve recarregar do banco principal após o Save", func(t *testing.T) {
+		reloading := NewSQLStore[TestSQLEntity](primary, enum.DatabaseDriverSqlite, "test_entities", "id", true,
+			WithReadDB(replica), WithReloadAfterSave())
+
+		reloaded, err := reloading.Save(ctx, &TestSQLEntity{Name: "recarregado", Age: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, "recarregado", reloaded.Name)
+		assert.Equal(t, 3, reloaded.Age)
+	})
+
+	t.Run("deve ler do banco principal dentro de uma transação", func(t *testing.T) {
+		tx, err := primary.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		found, err := store.(*SQLStore[TestSQLEntity]).WithTx(tx).FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "primário", found.Name)
+	})
+}
+
+// ==================== TESTES BUILD WHERE CLAUSE ====================
+
+func TestSQLBuildWhereClause(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true).(*SQLStore[TestSQLEntity])
+
+	tests := []struct {
+		name          string
+		filters       map[string]any
+		wantClause    string
+		wantValuesLen int
+	}{
+		{
+			name:          "deve retornar vazio para filtro nil",
+			filters:       nil,
+			wantClause:    "",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve retornar vazio para filtro vazio",
+			filters:       map[string]any{},
+			wantClause:    "",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve construir cláusula simples de igualdade",
+			filters:       map[string]any{"name": "João"},
+			wantClause:    " WHERE name = ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __gt",
+			filters:       map[string]any{"age__gt": 30},
+			wantClause:    " WHERE age > ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __gte",
+			filters:       map[string]any{"age__gte": 30},
+			wantClause:    " WHERE age >= ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __lt",
+			filters:       map[string]any{"age__lt": 30},
+			wantClause:    " WHERE age < ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __lte",
+			filters:       map[string]any{"age__lte": 30},
+			wantClause:    " WHERE age <= ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __like",
+			filters:       map[string]any{"name__like": "%João%"},
+			wantClause:    " WHERE name LIKE ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __ilike",
+			filters:       map[string]any{"name__ilike": "%joão%"},
+			wantClause:    " WHERE UPPER(name) LIKE UPPER(?)",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __not_like",
+			filters:       map[string]any{"name__not_like": "%João%"},
+			wantClause:    " WHERE name NOT LIKE ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __not",
+			filters:       map[string]any{"name__not": "João"},
+			wantClause:    " WHERE name != ?",
+			wantValuesLen: 1,
+		},
+		{
+			name:          "deve construir cláusula com operador __is_null",
+			filters:       map[string]any{"name__is_null": true},
+			wantClause:    " WHERE name IS NULL",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve construir cláusula com operador __is_not_null",
+			filters:       map[string]any{"name__is_not_null": true},
+			wantClause:    " WHERE name IS NOT NULL",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve construir cláusula com operador __is_null false",
+			filters:       map[string]any{"name__is_null": false},
+			wantClause:    " WHERE name IS NOT NULL",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve construir cláusula com operador __is_not_null false",
+			filters:       map[string]any{"name__is_not_null": false},
+			wantClause:    " WHERE name IS NULL",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve construir cláusula com operador __in ([]int)",
+			filters:       map[string]any{"age__in": []int{25, 30, 35}},
+			wantClause:    " WHERE age IN (?, ?, ?)",
+			wantValuesLen: 3,
+		},
+		{
+			name:          "deve construir cláusula com operador __in ([]string)",
+			filters:       map[string]any{"name__in": []string{"João", "Maria"}},
+			wantClause:    " WHERE name IN (?, ?)",
+			wantValuesLen: 2,
+		},
+		{
+			name:          "deve construir cláusula com operador __not_in",
+			filters:       map[string]any{"name__not_in": []string{"João", "Maria"}},
+			wantClause:    " WHERE name NOT IN (?, ?)",
+			wantValuesLen: 2,
+		},
+		{
+			name:          "deve construir cláusula com operador __exists true",
+			filters:       map[string]any{"name__exists": true},
+			wantClause:    " WHERE name IS NOT NULL",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve construir cláusula com operador __exists false",
+			filters:       map[string]any{"name__exists": false},
+			wantClause:    " WHERE name IS NULL",
+			wantValuesLen: 0,
+		},
+		{
+			name:          "deve ordenar chaves alfabeticamente",
+			filters:       map[string]any{"name": "João", "age": 30},
+			wantClause:    " WHERE age = ? AND name = ?",
+			wantValuesLen: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, values, err := store.buildWhereClause(tt.filters)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantClause, clause)
+			assert.Equal(t, tt.wantValuesLen, len(values))
+		})
+	}
+}
+
+type testStringerValue struct {
+	value string
+}
+
+func (v testStringerValue) String() string {
+	return v.value
+}
+
+// testIntEnum enum sobre int com String(), como os gerados pelo stringer
+type testIntEnum int
+
+func (e testIntEnum) String() string {
+	return [...]string{"zero", "um", "dois"}[e]
+}
+
+type testValuerValue struct {
+	value string
+}
+
+func (v testValuerValue) Value() (driver.Value, error) {
+	return v.value, nil
+}
+
+func TestSQLBuildWhereClause_ValueNormalization(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true).(*SQLStore[TestSQLEntity])
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		filters    map[string]any
+		wantValues []any
+	}{
+		{
+			name:       "deve converter float32 para float64",
+			filters:    map[string]any{"score__gte": float32(1.5)},
+			wantValues: []any{float64(1.5)},
+		},
+		{
+			name:       "deve converter *big.Rat para texto decimal exato",
+			filters:    map[string]any{"score__lte": big.NewRat(5, 2)},
+			wantValues: []any{"2.5"},
+		},
+		{
+			name:       "deve manter as casas decimais além da precisão do float64",
+			filters:    map[string]any{"score__lte": new(big.Rat).SetFrac64(12345678901234567, 100)},
+			wantValues: []any{"123456789012345.67"},
+		},
+		{
+			name:       "deve limitar a escala de frações sem representação decimal finita",
+			filters:    map[string]any{"score__lte": big.NewRat(1, 3)},
+			wantValues: []any{"0.333333333333333333333333333333"},
+		},
+		{
+			name:       "deve converter *big.Float para texto decimal",
+			filters:    map[string]any{"score": big.NewFloat(9.25)},
+			wantValues: []any{"9.25"},
+		},
+		{
+			name:       "deve enviar *big.Rat nil como NULL nas comparações",
+			filters:    map[string]any{"score__gte": (*big.Rat)(nil)},
+			wantValues: []any{nil},
+		},
+		{
+			name:       "deve enviar *big.Float nil como NULL nas comparações",
+			filters:    map[string]any{"score__lt": (*big.Float)(nil)},
+			wantValues: []any{nil},
+		},
+		{
+			name:       "deve aceitar *big.Rat nil no operador __in",
+			filters:    map[string]any{"score__in": []*big.Rat{big.NewRat(3, 2), nil}},
+			wantValues: []any{"1.5", nil},
+		},
+		{
+			name:       "deve manter driver.Valuer sem alteração",
+			filters:    map[string]any{"name": testValuerValue{value: "João"}},
+			wantValues: []any{testValuerValue{value: "João"}},
+		},
+		{
+			name:       "deve converter fmt.Stringer para string",
+			filters:    map[string]any{"name": testStringerValue{value: "Maria"}},
+			wantValues: []any{"Maria"},
+		},
+		{
+			name:       "deve usar o valor base de enums sobre int com String()",
+			filters:    map[string]any{"age": testIntEnum(2)},
+			wantValues: []any{int64(2)},
+		},
+		{
+			name:       "deve manter time.Time sem alteração",
+			filters:    map[string]any{"created_at__gte": now},
+			wantValues: []any{now},
+		},
+		{
+			name:       "deve converter valores float32 do operador __in",
+			filters:    map[string]any{"score__in": []float32{1.5, 2.5}},
+			wantValues: []any{float64(1.5), float64(2.5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, values, err := store.buildWhereClause(tt.filters)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValues, values)
+		})
+	}
+
+	t.Run("deve filtrar registros usando float32", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+		ctx := context.Background()
+
+		store.Save(ctx, &TestSQLEntity{Name: "Baixo", Score: 10.5})
+		store.Save(ctx, &TestSQLEntity{Name: "Alto", Score: 90.5})
+
+		results, err := store.FindAll(ctx, map[string]any{"score__gte": float32(50.5)}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Alto", results[0].Name)
+	})
+
+	t.Run("deve filtrar registros usando *big.Rat", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+		ctx := context.Background()
+
+		store.Save(ctx, &TestSQLEntity{Name: "Baixo", Score: 10.5})
+		store.Save(ctx, &TestSQLEntity{Name: "Alto", Score: 90.5})
+
+		results, err := store.FindAll(ctx, map[string]any{"score__gte": big.NewRat(101, 2)}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Alto", results[0].Name)
+
+		results, err = store.FindAll(ctx, map[string]any{"score__gte": (*big.Rat)(nil)}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("deve filtrar registros usando enum sobre int", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+		ctx := context.Background()
+
+		store.Save(ctx, &TestSQLEntity{Name: "Um", Age: 1})
+		store.Save(ctx, &TestSQLEntity{Name: "Dois", Age: 2})
+
+		results, err := store.FindAll(ctx, map[string]any{"age": testIntEnum(2)}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Dois", results[0].Name)
+	})
+}
+
+func TestSQLDatePartExpression(t *testing.T) {
+	tests := []struct {
+		driver   enum.DatabaseDriver
+		part     string
+		expected string
+	}{
+		{enum.DatabaseDriverSqlite, "month", "CAST(strftime('%m', created_at) AS INTEGER)"},
+		{enum.DatabaseDriverSqlite, "dow", "CAST(strftime('%w', created_at) AS INTEGER)"},
+		{enum.DatabaseDriverPostgres, "year", "EXTRACT(YEAR FROM created_at)"},
+		{enum.DatabaseDriverPostgres, "dow", "EXTRACT(DOW FROM created_at)"},
+		{enum.DatabaseDriverMysql, "month", "MONTH(created_at)"},
+		{enum.DatabaseDriverMariaDB, "dow", "(DAYOFWEEK(created_at) - 1)"},
+		{enum.DatabaseDriverOracle, "day", "EXTRACT(DAY FROM created_at)"},
+		{enum.DatabaseDriverOracle, "dow", "MOD(TRUNC(created_at) - TRUNC(created_at, 'IW') + 1, 7)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("deve extrair %s no %s", tt.part, tt.driver), func(t *testing.T) {
+			store := &SQLStore[TestSQLEntity]{driver: tt.driver}
+			assert.Equal(t, tt.expected, store.datePartExpression("created_at", tt.part))
+		})
+	}
+}
+
+// ==================== TESTES AGGREGATE INTO ====================
+
+func TestSQLAggregateInto(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+
+	result, err := AggregateInto[map[string]any](context.Background(), store, nil)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "only supported by Mongo")
+}
+
+// ==================== TESTES CONSTRUTOR ====================
+
+func TestNewSQLStore_NonStructEntity(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	t.Run("deve falhar na construção para entidade que não é struct", func(t *testing.T) {
+		assert.PanicsWithValue(t,
+			"NewSQLStore: o tipo da entidade deve ser uma struct, recebido map[string]interface {}",
+			func() {
+				NewSQLStore[map[string]any](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+			},
+		)
+	})
+
+	t.Run("deve falhar na construção para ponteiro", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewSQLStore[*TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		})
+	})
+
+	t.Run("deve aceitar struct", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		})
+	})
+}
+
+// ==================== TESTES DESCRIBE ====================
+
+func TestSQLDescribe(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+
+	sqlStore, ok := store.(*SQLStore[TestSQLEntity])
+	assert.True(t, ok)
+	assert.Equal(t, "test_entities", sqlStore.TableName())
+	assert.Equal(t, enum.DatabaseDriverSqlite, sqlStore.Driver())
+	assert.Equal(t, "sql:sqlite/test_entities", store.Describe())
+}
+func TestSQLEnsureSchema(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deve criar a tabela a partir de TestSQLEntity", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "generated_entities", "id", true)
+		assert.NoError(t, store.(*SQLStore[TestSQLEntity]).EnsureSchema(ctx))
+
+		saved, err := store.Save(ctx, &TestSQLEntity{Name: "João", Age: 30, Active: true, Score: 9.5, CreatedAt: time.Now(), UpdatedAt: time.Now()})
+		assert.NoError(t, err)
+		assert.NotZero(t, saved.ID)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "João", found.Name)
+		assert.True(t, found.Active)
+		assert.Equal(t, 9.5, found.Score)
+
+		// Executar novamente não altera a tabela existente
+		assert.NoError(t, store.(*SQLStore[TestSQLEntity]).EnsureSchema(ctx))
+	})
+
+	t.Run("deve adicionar as colunas que faltam sem remover as existentes", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+
+		_, err = db.Exec(`
+			CREATE TABLE partial_entities (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, legacy TEXT);
+			INSERT INTO partial_entities (name, legacy) VALUES ('Antigo', 'mantido');
+		`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "partial_entities", "id", true)
+		assert.NoError(t, store.(*SQLStore[TestSQLEntity]).EnsureSchema(ctx))
+
+		_, err = store.Save(ctx, &TestSQLEntity{Name: "Novo", Age: 20})
+		assert.NoError(t, err)
+
+		var legacy string
+		assert.NoError(t, db.QueryRow("SELECT legacy FROM partial_entities WHERE name = 'Antigo'").Scan(&legacy))
+		assert.Equal(t, "mantido", legacy)
+	})
+
+	t.Run("deve retornar o erro do banco sem tentar criar a tabela", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.Close()
+
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "closed_entities", "id", true)
+		err = store.(*SQLStore[TestSQLEntity]).EnsureSchema(ctx)
+		assert.ErrorContains(t, err, "erro ao verificar a tabela closed_entities")
+		assert.NotContains(t, err.Error(), "erro ao criar tabela")
+	})
+
+	t.Run("deve inferir os tipos por driver", func(t *testing.T) {
+		type entity struct {
+			ID        int64      `db:"id"`
+			Name      string     `db:"name"`
+			Price     *float64   `db:"price"`
+			Data      []byte     `db:"data"`
+			CreatedAt time.Time  `db:"created_at,generated"`
+			DeletedAt *time.Time `db:"deleted_at"`
+		}
+
+		pg := NewSQLStore[entity](nil, enum.DatabaseDriverPostgres, "entities", "id", true).(*SQLStore[entity])
+		assert.Equal(t, []schemaColumn{
+			{name: "id", definition: "BIGSERIAL PRIMARY KEY"},
+			{name: "name", definition: "TEXT"},
+			{name: "price", definition: "DOUBLE PRECISION"},
+			{name: "data", definition: "BYTEA"},
+			{name: "created_at", definition: "TIMESTAMP DEFAULT CURRENT_TIMESTAMP"},
+			{name: "deleted_at", definition: "TIMESTAMP"},
+		}, pg.schemaColumns(reflect.TypeFor[entity]()))
+
+		mysql := NewSQLStore[entity](nil, enum.DatabaseDriverMysql, "entities", "id", false).(*SQLStore[entity])
+		assert.Equal(t, []schemaColumn{
+			{name: "id", definition: "BIGINT PRIMARY KEY"},
+			{name: "name", definition: "VARCHAR(255)"},
+			{name: "price", definition: "DOUBLE"},
+			{name: "data", definition: "BLOB"},
+			{name: "created_at", definition: "DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6)"},
+			{name: "deleted_at", definition: "DATETIME(6)"},
+		}, mysql.schemaColumns(reflect.TypeFor[entity]()))
+	})
+}
+
+func TestSQLDebugFields(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	t.Run("deve mapear as colunas de TestSQLEntity", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		fields := store.DebugFields()
+
+		columns := make([]string, len(fields))
+		for i, f := range fields {
+			columns[i] = f.Column
+			assert.NotEqual(t, "Internal", f.Field, "campo db:\"-\" não deve ser mapeado")
+		}
+		assert.Equal(t, []string{"id", "name", "age", "active", "score", "created_at", "updated_at"}, columns)
+
+		assert.Equal(t, FieldInfo{Field: "ID", Column: "id", PrimaryKey: true, AutoIncrement: true, Skipped: true}, fields[0])
+		assert.Equal(t, FieldInfo{Field: "Name", Column: "name"}, fields[1])
+	})
+
+	t.Run("deve marcar colunas generated como não gravadas e achatar as aninhadas", func(t *testing.T) {
+		type Address struct {
+			City string `db:"city"`
+		}
+		type entity struct {
+			ID        string    `db:"id"`
+			CreatedAt time.Time `db:"created_at,generated"`
+			Address   Address   `db:"address"`
+			secret    string    `db:"secret"`
+		}
+
+		fields := NewSQLStore[entity](db, enum.DatabaseDriverSqlite, "entities", "id", false).DebugFields()
+		assert.Equal(t, []FieldInfo{
+			{Field: "ID", Column: "id", PrimaryKey: true},
+			{Field: "CreatedAt", Column: "created_at", Flags: []string{"generated"}, Skipped: true},
+			{Field: "Address.City", Column: "address_city"},
+		}, fields)
+	})
+
+	t.Run("deve ignorar campos não exportados como o INSERT", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE secrets (id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type entity struct {
+			ID     int    `db:"id"`
+			Name   string `db:"name"`
+			secret string `db:"secret"`
+		}
+
+		store := NewSQLStore[entity](db, enum.DatabaseDriverSqlite, "secrets", "id", true)
+		assert.Equal(t, []FieldInfo{
+			{Field: "ID", Column: "id", PrimaryKey: true, AutoIncrement: true, Skipped: true},
+			{Field: "Name", Column: "name"},
+		}, store.DebugFields())
+
+		saved, err := store.Save(context.Background(), &entity{Name: "Com Segredo", secret: "oculto"})
+		assert.NoError(t, err)
+		assert.NotZero(t, saved.ID)
+	})
+}
+
+// ==================== TESTES DE EDGE CASES ====================
+
+func TestSQLEdgeCases(t *testing.T) {
+	db, err := setupSQLDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	t.Run("deve lidar com registros com campos especiais", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		doc := &TestSQLEntity{
+			Name: "Nome com 'aspas' e \"aspas duplas\"",
+		}
+
+		saved, err := store.Save(ctx, doc)
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, saved.Name, found.Name)
+	})
+
+	t.Run("deve lidar com valores extremos", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		doc := &TestSQLEntity{
+			Name:  "Extreme Values",
+			Age:   2147483647,
+			Score: 1.7976931348623157e+100,
+		}
+
+		saved, err := store.Save(ctx, doc)
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, doc.Age, found.Age)
+	})
+
+	t.Run("deve lidar com strings vazias em busca", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		store.Save(ctx, &TestSQLEntity{Name: ""})
+		store.Save(ctx, &TestSQLEntity{Name: "Teste"})
+
+		results, err := store.FindAll(ctx, map[string]any{"name": ""}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Empty(t, results[0].Name)
+	})
+
+	t.Run("deve lidar com operações em tabela vazia", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		count, err := store.Count(ctx, map[string]any{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), *count)
+
+		results, err := store.FindAll(ctx, nil, FindOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+
+		exists := store.Has(ctx, 1)
+		assert.False(t, exists)
+	})
+
+	t.Run("deve lidar com caracteres unicode", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		doc := &TestSQLEntity{
+			Name: "日本語テスト 🎉 émojis ñ ç",
+		}
+
+		saved, err := store.Save(ctx, doc)
+		assert.NoError(t, err)
+
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, doc.Name, found.Name)
+	})
+
+	t.Run("deve lidar com filtro __in com slice vazio via reflection", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
 
-			assert.NoError(t, err)
-			assert.NotNil(t, result)
+		store.Save(ctx, &TestSQLEntity{Name: "Test", Age: 25})
 
-			if tt.check != nil {
-				tt.check(t, result)
-			}
-		})
-	}
+		// Slice de float64 (não tratado explicitamente)
+		results, err := store.FindAll(ctx, map[string]any{"score__in": []float64{80.0, 90.0}}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
 }
 
-// ==================== TESTES WITH TRANSACTION ====================
+// ==================== TESTES DE PERFORMANCE ====================
+
+func TestSQLPerformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Pulando testes de performance em modo curto")
+	}
 
-func TestSQLWithTransaction(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -2216,204 +6177,219 @@ func TestSQLWithTransaction(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	t.Run("deve executar operações em transação com sucesso", func(t *testing.T) {
-		result, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
-			return "success", nil
-		})
+	t.Run("deve inserir 1000 registros em batch eficientemente", func(t *testing.T) {
+		db.Exec("DELETE FROM test_entities")
+
+		entities := make([]TestSQLEntity, 1000)
+		for i := 0; i < 1000; i++ {
+			entities[i] = TestSQLEntity{
+				Name:   fmt.Sprintf("Performance Test %d", i),
+				Age:    i % 100,
+				Active: i%2 == 0,
+				Score:  float64(i) * 1.5,
+			}
+		}
+
+		start := time.Now()
+		result, err := store.SaveMany(ctx, entities)
+		duration := time.Since(start)
 
 		assert.NoError(t, err)
-		assert.Equal(t, "success", result)
+		assert.Equal(t, 1000, len(result.InsertedIDs))
+		assert.Less(t, duration, 30*time.Second)
+
+		t.Logf("Inserção de 1000 registros: %v", duration)
 	})
 
-	t.Run("deve fazer rollback em caso de erro", func(t *testing.T) {
-		_, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
-			return nil, fmt.Errorf("erro simulado")
-		})
+	t.Run("deve buscar com filtro eficientemente", func(t *testing.T) {
+		start := time.Now()
+		results, err := store.FindAll(ctx, map[string]any{"age__gte": 50}, FindOptions{})
+		duration := time.Since(start)
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "erro simulado")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, results)
+		assert.Less(t, duration, 5*time.Second)
+
+		t.Logf("Busca com filtro: %v, resultados: %d", duration, len(results))
 	})
 
-	t.Run("deve permitir operações SQL dentro da transação", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	t.Run("deve contar registros eficientemente", func(t *testing.T) {
+		start := time.Now()
+		count, err := store.Count(ctx, map[string]any{"active": true})
+		duration := time.Since(start)
 
-		result, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
-			tx, ok := txCtx.(*sql.Tx)
-			if !ok {
-				return nil, fmt.Errorf("contexto inválido")
-			}
+		assert.NoError(t, err)
+		assert.Greater(t, *count, int64(0))
+		assert.Less(t, duration, 1*time.Second)
 
-			_, err := tx.Exec("INSERT INTO test_entities (name, age, active, score) VALUES (?, ?, ?, ?)",
-				"Transação", 25, true, 80.0)
-			if err != nil {
-				return nil, err
-			}
+		t.Logf("Contagem: %v, total: %d", duration, *count)
+	})
+}
 
-			return "inserted", nil
+func TestSQLBuildWhereClause_BoolFilter(t *testing.T) {
+	tests := []struct {
+		driver enum.DatabaseDriver
+		want   []any
+	}{
+		{driver: enum.DatabaseDriverSqlite, want: []any{0, 1}},
+		{driver: enum.DatabaseDriverOracle, want: []any{0, 1}},
+		{driver: enum.DatabaseDriverPostgres, want: []any{false, true}},
+		{driver: enum.DatabaseDriverMysql, want: []any{false, true}},
+		{driver: enum.DatabaseDriverMariaDB, want: []any{false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.driver), func(t *testing.T) {
+			store := NewSQLStore[TestSQLEntity](nil, tt.driver, "test_entities", "id", true).(*SQLStore[TestSQLEntity])
+
+			clause, values, err := store.buildWhereClause(map[string]any{"active": false, "active__in": []bool{true}})
+			assert.NoError(t, err)
+			assert.Equal(t, " WHERE active = ? AND active IN (?)", clause)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+
+	t.Run("deve filtrar booleanos armazenados como inteiros no SQLite", func(t *testing.T) {
+		db, err := setupSQLDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		ctx := context.Background()
+
+		_, err = store.SaveMany(ctx, []TestSQLEntity{
+			{Name: "Ativo", Active: true},
+			{Name: "Inativo"},
+			{Name: "Outro inativo"},
 		})
+		assert.NoError(t, err)
 
+		inactive, err := store.Count(ctx, map[string]any{"active": false})
 		assert.NoError(t, err)
-		assert.Equal(t, "inserted", result)
+		assert.Equal(t, int64(2), *inactive)
 
-		// Verifica se foi commitado
-		count, _ := store.Count(ctx, map[string]any{})
-		assert.Equal(t, int64(1), *count)
+		active, err := store.FindAll(ctx, map[string]any{"active": true}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, active, 1)
+		assert.Equal(t, "Ativo", active[0].Name)
 	})
+}
 
-	t.Run("deve fazer rollback quando transação falha", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+func TestSQLBuildWhereClause_UnknownColumn(t *testing.T) {
+	store := NewSQLStore[TestSQLEntity](nil, enum.DatabaseDriverPostgres, "test_entities", "id", true).(*SQLStore[TestSQLEntity])
 
-		_, err := store.WithTransaction(ctx, func(txCtx TransactionContext) (any, error) {
-			tx, ok := txCtx.(*sql.Tx)
-			if !ok {
-				return nil, fmt.Errorf("contexto inválido")
-			}
+	t.Run("deve rejeitar colunas de filtro não mapeadas", func(t *testing.T) {
+		for _, key := range []string{"email", "email__like", "age; DROP TABLE test_entities --", "meta.status__json_eq"} {
+			_, _, err := store.buildWhereClause(map[string]any{key: "valor"})
+			assert.ErrorIs(t, err, ErrUnknownColumn, key)
+		}
 
-			_, err := tx.Exec("INSERT INTO test_entities (name, age, active, score) VALUES (?, ?, ?, ?)",
-				"Vai Falhar", 25, true, 80.0)
-			if err != nil {
-				return nil, err
-			}
+		_, _, err := store.buildWhereClause(Where(Not(Cond(map[string]any{"email": "a@b.com"}))))
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+	})
 
-			return nil, fmt.Errorf("erro forçado")
-		})
+	t.Run("deve aceitar colunas mapeadas com operadores", func(t *testing.T) {
+		_, _, err := store.buildWhereClause(map[string]any{"name__like": "Jo%", "age__gte": 18, "created_at__year": 2024})
+		assert.NoError(t, err)
+	})
 
-		assert.Error(t, err)
+	t.Run("deve aceitar apenas identificadores simples sem colunas mapeadas", func(t *testing.T) {
+		view := NewSQLStore[struct{}](nil, enum.DatabaseDriverPostgres, "age_report", "id", true).(*SQLStore[struct{}])
 
-		// Verifica se foi feito rollback
-		count, _ := store.Count(ctx, map[string]any{})
-		assert.Equal(t, int64(0), *count)
+		_, _, err := view.buildWhereClause(map[string]any{"age_months__gte": 12})
+		assert.NoError(t, err)
+
+		_, _, err = view.buildWhereClause(map[string]any{"1=1 OR name": "x"})
+		assert.ErrorIs(t, err, ErrUnknownColumn)
 	})
 }
 
-// ==================== TESTES BUILD WHERE CLAUSE ====================
-
-func TestSQLBuildWhereClause(t *testing.T) {
-	db, err := setupSQLDB()
-	if err != nil {
-		t.Fatal(err)
+func TestSQLBuildWhereClause_JSONPath(t *testing.T) {
+	type jsonEntity struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+		Meta string `db:"meta"`
 	}
-	defer db.Close()
-
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true).(*SQLStore[TestSQLEntity])
 
 	tests := []struct {
-		name          string
-		filters       map[string]any
-		wantClause    string
-		wantValuesLen int
+		driver enum.DatabaseDriver
+		key    string
+		want   string
 	}{
-		{
-			name:          "deve retornar vazio para filtro nil",
-			filters:       nil,
-			wantClause:    "",
-			wantValuesLen: 0,
-		},
-		{
-			name:          "deve retornar vazio para filtro vazio",
-			filters:       map[string]any{},
-			wantClause:    "",
-			wantValuesLen: 0,
-		},
-		{
-			name:          "deve construir cláusula simples de igualdade",
-			filters:       map[string]any{"name": "João"},
-			wantClause:    " WHERE name = ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __gt",
-			filters:       map[string]any{"age__gt": 30},
-			wantClause:    " WHERE age > ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __gte",
-			filters:       map[string]any{"age__gte": 30},
-			wantClause:    " WHERE age >= ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __lt",
-			filters:       map[string]any{"age__lt": 30},
-			wantClause:    " WHERE age < ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __lte",
-			filters:       map[string]any{"age__lte": 30},
-			wantClause:    " WHERE age <= ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __like",
-			filters:       map[string]any{"name__like": "%João%"},
-			wantClause:    " WHERE name LIKE ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __ilike",
-			filters:       map[string]any{"name__ilike": "%joão%"},
-			wantClause:    " WHERE UPPER(name) LIKE UPPER(?)",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __not_like",
-			filters:       map[string]any{"name__not_like": "%João%"},
-			wantClause:    " WHERE name NOT LIKE ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __not",
-			filters:       map[string]any{"name__not": "João"},
-			wantClause:    " WHERE name != ?",
-			wantValuesLen: 1,
-		},
-		{
-			name:          "deve construir cláusula com operador __is_null",
-			filters:       map[string]any{"name__is_null": true},
-			wantClause:    " WHERE name IS NULL",
-			wantValuesLen: 0,
-		},
-		{
-			name:          "deve construir cláusula com operador __is_not_null",
-			filters:       map[string]any{"name__is_not_null": true},
-			wantClause:    " WHERE name IS NOT NULL",
-			wantValuesLen: 0,
-		},
-		{
-			name:          "deve construir cláusula com operador __in ([]int)",
-			filters:       map[string]any{"age__in": []int{25, 30, 35}},
-			wantClause:    " WHERE age IN (?, ?, ?)",
-			wantValuesLen: 3,
-		},
-		{
-			name:          "deve construir cláusula com operador __in ([]string)",
-			filters:       map[string]any{"name__in": []string{"João", "Maria"}},
-			wantClause:    " WHERE name IN (?, ?)",
-			wantValuesLen: 2,
-		},
-		{
-			name:          "deve ordenar chaves alfabeticamente",
-			filters:       map[string]any{"name": "João", "age": 30},
-			wantClause:    " WHERE age = ? AND name = ?",
-			wantValuesLen: 2,
-		},
+		{enum.DatabaseDriverPostgres, "meta.status__json_eq", " WHERE meta->>'status' = ?"},
+		{enum.DatabaseDriverPostgres, "meta.address.city__json_eq", " WHERE meta#>>'{address,city}' = ?"},
+		{enum.DatabaseDriverMysql, "meta.status__json_eq", " WHERE JSON_UNQUOTE(JSON_EXTRACT(meta, '$.status')) = ?"},
+		{enum.DatabaseDriverMariaDB, "meta.status-code__json_eq", ` WHERE JSON_UNQUOTE(JSON_EXTRACT(meta, '$."status-code"')) = ?`},
+		{enum.DatabaseDriverSqlite, "meta.address.city__json_eq", " WHERE json_extract(meta, '$.address.city') = ?"},
+		{enum.DatabaseDriverOracle, "meta.status__json_eq", " WHERE JSON_VALUE(meta, '$.status') = ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.driver)+"/"+tt.key, func(t *testing.T) {
+			store := NewSQLStore[jsonEntity](nil, tt.driver, "documents", "id", true).(*SQLStore[jsonEntity])
+
+			clause, _, err := store.buildWhereClause(map[string]any{tt.key: "ativo"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, clause)
+		})
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			clause, values := store.buildWhereClause(tt.filters)
+	t.Run("deve rejeitar caminhos JSON inválidos", func(t *testing.T) {
+		store := NewSQLStore[jsonEntity](nil, enum.DatabaseDriverPostgres, "documents", "id", true).(*SQLStore[jsonEntity])
+
+		for _, key := range []string{"meta.status'--__json_eq", "meta__json_eq"} {
+			_, _, err := store.buildWhereClause(map[string]any{key: "ativo"})
+			assert.ErrorIs(t, err, ErrUnknownColumn, key)
+		}
+
+		_, _, err := store.buildWhereClause(Where(Or(Cond(map[string]any{"name": "A"}), Cond(map[string]any{"meta__json_eq": "ativo"}))))
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+	})
+
+	t.Run("deve filtrar pelo caminho JSON no SQLite", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		_, err = db.Exec(`
+			CREATE TABLE documents (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, meta TEXT);
+			INSERT INTO documents (name, meta) VALUES ('A', '{"status": "ativo", "address": {"city": "Recife"}}');
+			INSERT INTO documents (name, meta) VALUES ('B', '{"status": "inativo", "address": {"city": "Natal"}}');
+			INSERT INTO documents (name, meta) VALUES ('C', '{"status": "ativo", "address": {"city": "Natal"}}');
+		`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type document struct {
+			ID   int    `db:"id"`
+			Name string `db:"name"`
+			Meta string `db:"meta"`
+		}
+
+		store := NewSQLStore[document](db, enum.DatabaseDriverSqlite, "documents", "id", true)
+		ctx := context.Background()
+
+		found, err := store.FindAll(ctx, map[string]any{
+			"meta.status__json_eq":       "ativo",
+			"meta.address.city__json_eq": "Natal",
+		}, FindOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+		assert.Equal(t, "C", found[0].Name)
 
-			assert.Equal(t, tt.wantClause, clause)
-			assert.Equal(t, tt.wantValuesLen, len(values))
-		})
-	}
+		_, err = store.FindAll(ctx, map[string]any{"meta.status;--__json_eq": "ativo"}, FindOptions{})
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+	})
 }
 
-// ==================== TESTES DE EDGE CASES ====================
+// ==================== TESTES DE CONVERSÃO DE TIPOS ====================
 
-func TestSQLEdgeCases(t *testing.T) {
+func TestSQLTypeConversion(t *testing.T) {
 	db, err := setupSQLDB()
 	if err != nil {
 		t.Fatal(err)
@@ -2423,11 +6399,17 @@ func TestSQLEdgeCases(t *testing.T) {
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	t.Run("deve lidar com registros com campos especiais", func(t *testing.T) {
+	t.Run("deve converter tipos corretamente ao ler do banco", func(t *testing.T) {
 		db.Exec("DELETE FROM test_entities")
 
+		now := time.Now()
 		doc := &TestSQLEntity{
-			Name: "Nome com 'aspas' e \"aspas duplas\"",
+			Name:      "Teste Tipos",
+			Age:       30,
+			Active:    true,
+			Score:     95.5,
+			CreatedAt: now,
+			UpdatedAt: now,
 		}
 
 		saved, err := store.Save(ctx, doc)
@@ -2435,182 +6417,433 @@ func TestSQLEdgeCases(t *testing.T) {
 
 		found, err := store.FindById(ctx, saved.ID)
 		assert.NoError(t, err)
-		assert.Equal(t, saved.Name, found.Name)
+
+		assert.IsType(t, 0, found.ID)
+		assert.IsType(t, "", found.Name)
+		assert.IsType(t, 0, found.Age)
+		assert.IsType(t, false, found.Active)
+		assert.IsType(t, 0.0, found.Score)
+		assert.IsType(t, time.Time{}, found.CreatedAt)
+		assert.IsType(t, time.Time{}, found.UpdatedAt)
 	})
+}
 
-	t.Run("deve lidar com valores extremos", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+func TestSQLSetValue_NumericConversion(t *testing.T) {
+	s := &SQLStore[TestSQLEntity]{}
+
+	var target struct {
+		Int     int
+		Int64   int64
+		Int16   int16
+		Int32   int32
+		Uint    uint
+		Uint8   uint8
+		Uint16  uint16
+		Uint32  uint32
+		Float   float64
+		Float32 float32
+	}
+	v := reflect.ValueOf(&target).Elem()
 
-		doc := &TestSQLEntity{
-			Name:  "Extreme Values",
-			Age:   2147483647,
-			Score: 1.7976931348623157e+100,
-		}
+	t.Run("deve truncar float64 em campos inteiros", func(t *testing.T) {
+		assert.NoError(t, s.setValue(v.FieldByName("Int"), 42.9))
+		assert.NoError(t, s.setValue(v.FieldByName("Int64"), float64(-7)))
+		assert.NoError(t, s.setValue(v.FieldByName("Uint"), 3.0))
 
-		saved, err := store.Save(ctx, doc)
-		assert.NoError(t, err)
+		assert.Equal(t, 42, target.Int)
+		assert.Equal(t, int64(-7), target.Int64)
+		assert.Equal(t, uint(3), target.Uint)
+	})
 
-		found, err := store.FindById(ctx, saved.ID)
-		assert.NoError(t, err)
-		assert.Equal(t, doc.Age, found.Age)
+	t.Run("deve converter inteiros em campos float", func(t *testing.T) {
+		assert.NoError(t, s.setValue(v.FieldByName("Float"), int64(30)))
+		assert.Equal(t, 30.0, target.Float)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Float"), 12))
+		assert.Equal(t, 12.0, target.Float)
 	})
 
-	t.Run("deve lidar com strings vazias em busca", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	t.Run("deve converter inteiros e texto em campos sem sinal", func(t *testing.T) {
+		assert.NoError(t, s.setValue(v.FieldByName("Uint"), int64(8)))
+		assert.Equal(t, uint(8), target.Uint)
 
-		store.Save(ctx, &TestSQLEntity{Name: ""})
-		store.Save(ctx, &TestSQLEntity{Name: "Teste"})
+		assert.NoError(t, s.setValue(v.FieldByName("Uint"), []byte("9")))
+		assert.Equal(t, uint(9), target.Uint)
 
-		results, err := store.FindAll(ctx, map[string]any{"name": ""}, FindOptions{})
-		assert.NoError(t, err)
-		assert.Equal(t, 1, len(results))
-		assert.Empty(t, results[0].Name)
+		assert.NoError(t, s.setValue(v.FieldByName("Uint"), "10"))
+		assert.Equal(t, uint(10), target.Uint)
 	})
 
-	t.Run("deve lidar com operações em tabela vazia", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	t.Run("deve rejeitar valor negativo em campo sem sinal", func(t *testing.T) {
+		assert.Error(t, s.setValue(v.FieldByName("Uint"), int64(-1)))
+		assert.Error(t, s.setValue(v.FieldByName("Uint"), -1.5))
+	})
 
-		count, err := store.Count(ctx, map[string]any{})
-		assert.NoError(t, err)
-		assert.Equal(t, int64(0), *count)
+	t.Run("deve preencher inteiros de todos os tamanhos", func(t *testing.T) {
+		assert.NoError(t, s.setValue(v.FieldByName("Int16"), int64(-300)))
+		assert.NoError(t, s.setValue(v.FieldByName("Int32"), []byte("70000")))
+		assert.NoError(t, s.setValue(v.FieldByName("Uint8"), int64(200)))
+		assert.NoError(t, s.setValue(v.FieldByName("Uint16"), 5.0))
+		assert.NoError(t, s.setValue(v.FieldByName("Uint32"), []byte("4000000000")))
+
+		assert.Equal(t, int16(-300), target.Int16)
+		assert.Equal(t, int32(70000), target.Int32)
+		assert.Equal(t, uint8(200), target.Uint8)
+		assert.Equal(t, uint16(5), target.Uint16)
+		assert.Equal(t, uint32(4000000000), target.Uint32)
+	})
 
-		results, err := store.FindAll(ctx, nil, FindOptions{})
-		assert.NoError(t, err)
-		assert.Empty(t, results)
+	t.Run("deve rejeitar valor que excede o tamanho do campo", func(t *testing.T) {
+		assert.Error(t, s.setValue(v.FieldByName("Int16"), int64(40000)))
+		assert.Error(t, s.setValue(v.FieldByName("Uint8"), int64(256)))
+	})
 
-		exists := store.Has(ctx, 1)
-		assert.False(t, exists)
+	t.Run("deve converter para campos float32", func(t *testing.T) {
+		assert.NoError(t, s.setValue(v.FieldByName("Float32"), 1.5))
+		assert.Equal(t, float32(1.5), target.Float32)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Float32"), []byte("2.25")))
+		assert.Equal(t, float32(2.25), target.Float32)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Float32"), int64(4)))
+		assert.Equal(t, float32(4), target.Float32)
 	})
 
-	t.Run("deve lidar com caracteres unicode", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	t.Run("deve rejeitar valor que excede o campo float32", func(t *testing.T) {
+		assert.Error(t, s.setValue(v.FieldByName("Float32"), 1e300))
+	})
 
-		doc := &TestSQLEntity{
-			Name: "日本語テスト 🎉 émojis ñ ç",
+	t.Run("deve aceitar inteiros de qualquer tamanho e sinal como origem", func(t *testing.T) {
+		assert.NoError(t, s.setValue(v.FieldByName("Int"), int32(5)))
+		assert.Equal(t, 5, target.Int)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Int64"), uint64(6)))
+		assert.Equal(t, int64(6), target.Int64)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Uint"), uint32(7)))
+		assert.Equal(t, uint(7), target.Uint)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Uint16"), int16(8)))
+		assert.Equal(t, uint16(8), target.Uint16)
+
+		assert.NoError(t, s.setValue(v.FieldByName("Float"), uint8(9)))
+		assert.Equal(t, 9.0, target.Float)
+
+		assert.Error(t, s.setValue(v.FieldByName("Int64"), uint64(math.MaxUint64)))
+		assert.Error(t, s.setValue(v.FieldByName("Uint"), int32(-1)))
+	})
+
+	t.Run("deve rejeitar tipos de origem não suportados", func(t *testing.T) {
+		err := s.setValue(v.FieldByName("Int"), true)
+		assert.ErrorContains(t, err, "bool")
+		assert.ErrorContains(t, err, "int")
+
+		assert.Error(t, s.setValue(v.FieldByName("Uint8"), time.Now()))
+		assert.Error(t, s.setValue(v.FieldByName("Float"), struct{}{}))
+	})
+
+	t.Run("deve manter o valor zero para NULL", func(t *testing.T) {
+		var nullable struct {
+			Int   int
+			Uint  uint
+			Float float64
 		}
+		nv := reflect.ValueOf(&nullable).Elem()
 
-		saved, err := store.Save(ctx, doc)
+		assert.NoError(t, s.setValue(nv.FieldByName("Int"), nil))
+		assert.NoError(t, s.setValue(nv.FieldByName("Uint"), nil))
+		assert.NoError(t, s.setValue(nv.FieldByName("Float"), nil))
+		assert.Zero(t, nullable)
+	})
+
+	t.Run("deve ler campos float32 salvos no banco", func(t *testing.T) {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		_, err = db.Exec(`CREATE TABLE ratings (id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, score REAL NOT NULL)`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		type rating struct {
+			ID    int     `db:"id"`
+			Score float32 `db:"score"`
+		}
+
+		store := NewSQLStore[rating](db, enum.DatabaseDriverSqlite, "ratings", "id", true)
+		ctx := context.Background()
+
+		saved, err := store.Save(ctx, &rating{Score: 1.5})
 		assert.NoError(t, err)
 
 		found, err := store.FindById(ctx, saved.ID)
 		assert.NoError(t, err)
-		assert.Equal(t, doc.Name, found.Name)
+		assert.Equal(t, float32(1.5), found.Score)
 	})
 
-	t.Run("deve lidar com filtro __in com slice vazio via reflection", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	t.Run("deve ler inteiro armazenado como REAL no SQLite", func(t *testing.T) {
+		db, err := setupSQLDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
 
-		store.Save(ctx, &TestSQLEntity{Name: "Test", Age: 25})
+		_, err = db.Exec(`INSERT INTO test_entities (name, age, score) VALUES ('Real', 30.0, 7)`)
+		assert.NoError(t, err)
+		_, err = db.Exec(`UPDATE test_entities SET age = CAST(age AS REAL) + 0.5, score = CAST(score AS INTEGER)`)
+		assert.NoError(t, err)
 
-		// Slice de float64 (não tratado explicitamente)
-		results, err := store.FindAll(ctx, map[string]any{"score__in": []float64{80.0, 90.0}}, FindOptions{})
+		store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+		found, err := store.FindOne(context.Background(), map[string]any{"name": "Real"})
 		assert.NoError(t, err)
-		assert.Empty(t, results)
+		assert.Equal(t, 30, found.Age)
+		assert.Equal(t, 7.0, found.Score)
 	})
 }
 
-// ==================== TESTES DE PERFORMANCE ====================
+func BenchmarkSQLSaveMany(b *testing.B) {
+	db, err := setupSQLDB()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
 
-func TestSQLPerformance(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Pulando testes de performance em modo curto")
+	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	ctx := context.Background()
+
+	entities := make([]TestSQLEntity, 100)
+	for i := range entities {
+		entities[i] = TestSQLEntity{Name: fmt.Sprintf("Benchmark %d", i), Age: i}
 	}
 
+	for b.Loop() {
+		if _, err := store.SaveMany(ctx, entities); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSQLFindById(b *testing.B) {
 	db, err := setupSQLDB()
 	if err != nil {
-		t.Fatal(err)
+		b.Fatal(err)
 	}
 	defer db.Close()
 
 	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
 	ctx := context.Background()
 
-	t.Run("deve inserir 1000 registros em batch eficientemente", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	saved, err := store.Save(ctx, &TestSQLEntity{Name: "Benchmark", Age: 30})
+	if err != nil {
+		b.Fatal(err)
+	}
 
-		entities := make([]TestSQLEntity, 1000)
-		for i := 0; i < 1000; i++ {
-			entities[i] = TestSQLEntity{
-				Name:   fmt.Sprintf("Performance Test %d", i),
-				Age:    i % 100,
-				Active: i%2 == 0,
-				Score:  float64(i) * 1.5,
+	b.Run("FindById", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := store.FindById(ctx, saved.ID); err != nil {
+				b.Fatal(err)
 			}
 		}
+	})
 
-		start := time.Now()
-		result, err := store.SaveMany(ctx, entities)
-		duration := time.Since(start)
+	b.Run("FindByIdInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var dst TestSQLEntity
+		for b.Loop() {
+			if err := store.FindByIdInto(ctx, saved.ID, &dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
 
-		assert.NoError(t, err)
-		assert.Equal(t, 1000, len(result.InsertedIDs))
-		assert.Less(t, duration, 30*time.Second)
+type TestSQLEntityWithEventDate struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	HappensAt time.Time `db:"happens_at"`
+}
 
-		t.Logf("Inserção de 1000 registros: %v", duration)
+func TestSQLWithTimeLayouts(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL, happens_at TEXT NOT NULL)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Formato fora dos layouts conhecidos, como gravado por outro sistema
+	_, err = db.Exec(`INSERT INTO events (name, happens_at) VALUES ('Lançamento', '16/10/2026 14h30')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	t.Run("deve falhar sem o layout do driver", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntityWithEventDate](db, enum.DatabaseDriverSqlite, "events", "id", true)
+		_, err := store.FindById(ctx, 1)
+		assert.Error(t, err)
 	})
 
-	t.Run("deve buscar com filtro eficientemente", func(t *testing.T) {
-		start := time.Now()
-		results, err := store.FindAll(ctx, map[string]any{"age__gte": 50}, FindOptions{})
-		duration := time.Since(start)
+	t.Run("deve converter usando os layouts informados em ordem", func(t *testing.T) {
+		store := NewSQLStore[TestSQLEntityWithEventDate](db, enum.DatabaseDriverSqlite, "events", "id", true,
+			WithTimeLayouts("2006-01-02T15:04", "02/01/2006 15h04"))
 
+		found, err := store.FindById(ctx, 1)
 		assert.NoError(t, err)
-		assert.NotEmpty(t, results)
-		assert.Less(t, duration, 5*time.Second)
+		assert.Equal(t, time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC), found.HappensAt)
+	})
 
-		t.Logf("Busca com filtro: %v, resultados: %d", duration, len(results))
+	t.Run("deve manter os formatos conhecidos como alternativa", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO events (name, happens_at) VALUES ('Padrão', '2026-10-17 09:00:00')`)
+		assert.NoError(t, err)
+
+		store := NewSQLStore[TestSQLEntityWithEventDate](db, enum.DatabaseDriverSqlite, "events", "id", true,
+			WithTimeLayouts("02/01/2006 15h04"))
+
+		found, err := store.FindById(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), found.HappensAt)
 	})
+}
 
-	t.Run("deve contar registros eficientemente", func(t *testing.T) {
-		start := time.Now()
-		count, err := store.Count(ctx, map[string]any{"active": true})
-		duration := time.Since(start)
+// ==================== TESTES SCANNER E VALUER ====================
+
+// TestSQLCode tipo de coluna customizado gravado como texto "PREFIXO-NÚMERO"
+type TestSQLCode struct {
+	Prefix string
+	Number int
+}
+
+func (c TestSQLCode) Value() (driver.Value, error) {
+	return fmt.Sprintf("%s-%d", c.Prefix, c.Number), nil
+}
+
+func (c *TestSQLCode) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return fmt.Errorf("tipo não suportado para TestSQLCode: %T", src)
+	}
+
+	_, err := fmt.Sscanf(strings.Replace(text, "-", " ", 1), "%s %d", &c.Prefix, &c.Number)
+	return err
+}
+
+type TestSQLEntityWithCode struct {
+	ID     int          `db:"id"`
+	Code   TestSQLCode  `db:"code"`
+	Backup *TestSQLCode `db:"backup"`
+}
+
+type TestSQLEntityWithCodeKey struct {
+	Code TestSQLCode `db:"code"`
+	Name string      `db:"name"`
+}
+
+func TestSQLSave_ScannerPrimaryKey(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE code_keys (
+			code TEXT NOT NULL PRIMARY KEY DEFAULT ('KEY-' || abs(random() % 1000)),
+			name TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithCodeKey](db, enum.DatabaseDriverSqlite, "code_keys", "code", false, WithPKMode(PKModeServerDefault))
+	ctx := context.Background()
 
+	t.Run("deve converter a chave gerada com o sql.Scanner do campo", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithCodeKey{Name: "Chave Scanner"})
 		assert.NoError(t, err)
-		assert.Greater(t, *count, int64(0))
-		assert.Less(t, duration, 1*time.Second)
+		assert.Equal(t, "KEY", saved.Code.Prefix)
 
-		t.Logf("Contagem: %v, total: %d", duration, *count)
+		found, err := store.FindById(ctx, saved.Code)
+		assert.NoError(t, err)
+		assert.Equal(t, "Chave Scanner", found.Name)
 	})
-}
 
-// ==================== TESTES DE CONVERSÃO DE TIPOS ====================
+	t.Run("deve converter as chaves geradas no SaveIgnoreDuplicates", func(t *testing.T) {
+		entities := []TestSQLEntityWithCodeKey{{Name: "Lote"}}
+		result, err := store.SaveIgnoreDuplicates(ctx, entities)
+		assert.NoError(t, err)
+		assert.Len(t, result.InsertedIDs, 1)
+	})
+}
 
-func TestSQLTypeConversion(t *testing.T) {
-	db, err := setupSQLDB()
+func TestSQLScannerValuer(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer db.Close()
 
-	store := NewSQLStore[TestSQLEntity](db, enum.DatabaseDriverSqlite, "test_entities", "id", true)
+	_, err = db.Exec(`CREATE TABLE codes (id INTEGER PRIMARY KEY AUTOINCREMENT, code TEXT NOT NULL, backup TEXT)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSQLStore[TestSQLEntityWithCode](db, enum.DatabaseDriverSqlite, "codes", "id", true)
 	ctx := context.Background()
 
-	t.Run("deve converter tipos corretamente ao ler do banco", func(t *testing.T) {
-		db.Exec("DELETE FROM test_entities")
+	t.Run("deve gravar com Value e ler com Scan", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithCode{
+			Code:   TestSQLCode{Prefix: "ABC", Number: 123},
+			Backup: &TestSQLCode{Prefix: "BKP", Number: 7},
+		})
+		assert.NoError(t, err)
 
-		now := time.Now()
-		doc := &TestSQLEntity{
-			Name:      "Teste Tipos",
-			Age:       30,
-			Active:    true,
-			Score:     95.5,
-			CreatedAt: now,
-			UpdatedAt: now,
-		}
+		var raw string
+		err = db.QueryRow("SELECT code FROM codes WHERE id = ?", saved.ID).Scan(&raw)
+		assert.NoError(t, err)
+		assert.Equal(t, "ABC-123", raw)
 
-		saved, err := store.Save(ctx, doc)
+		found, err := store.FindById(ctx, saved.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, TestSQLCode{Prefix: "ABC", Number: 123}, found.Code)
+		assert.Equal(t, &TestSQLCode{Prefix: "BKP", Number: 7}, found.Backup)
+	})
+
+	t.Run("deve manter ponteiro nil para NULL", func(t *testing.T) {
+		saved, err := store.Save(ctx, &TestSQLEntityWithCode{Code: TestSQLCode{Prefix: "XYZ", Number: 1}})
 		assert.NoError(t, err)
 
 		found, err := store.FindById(ctx, saved.ID)
 		assert.NoError(t, err)
+		assert.Nil(t, found.Backup)
+	})
 
-		assert.IsType(t, 0, found.ID)
-		assert.IsType(t, "", found.Name)
-		assert.IsType(t, 0, found.Age)
-		assert.IsType(t, false, found.Active)
-		assert.IsType(t, 0.0, found.Score)
-		assert.IsType(t, time.Time{}, found.CreatedAt)
-		assert.IsType(t, time.Time{}, found.UpdatedAt)
+	t.Run("deve filtrar usando o Value do tipo", func(t *testing.T) {
+		found, err := store.FindOne(ctx, map[string]any{"code": TestSQLCode{Prefix: "ABC", Number: 123}})
+		assert.NoError(t, err)
+		assert.Equal(t, 123, found.Code.Number)
+	})
+
+	t.Run("deve retornar o erro do Scan", func(t *testing.T) {
+		_, err := db.Exec("INSERT INTO codes (code) VALUES (?)", 42)
+		assert.NoError(t, err)
+
+		_, err = store.FindOne(ctx, map[string]any{"code": 42})
+		assert.ErrorContains(t, err, "erro ao converter coluna code")
 	})
 }
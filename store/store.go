@@ -3,8 +3,64 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/luma-sys/go-db-store/page"
 )
 
+// ErrNotFound indica que nenhum registro/documento corresponde ao ID ou filtro informado.
+// Retornado por FindById, FindByIdInto, FindOne, Update, UpdateFields, UpdateWhere, Delete e DeleteOne
+var ErrNotFound = errors.New("registro não encontrado")
+
+// ErrInvalidSortField indica que o campo de ordenação não corresponde a um campo mapeado da entidade
+var ErrInvalidSortField = errors.New("campo de ordenação inválido")
+
+// ErrUnknownColumn indica que um campo usado na consulta (projeção ou busca) não é mapeado na entidade
+var ErrUnknownColumn = errors.New("campo não mapeado na entidade")
+
+// ErrProtectedColumn indica a tentativa de alterar uma coluna gerenciada pelo store (chave primária ou created_at)
+var ErrProtectedColumn = errors.New("coluna protegida não pode ser atualizada")
+
+// ErrConflict indica que o registro existe, mas não atende às condições de uma atualização condicional
+var ErrConflict = errors.New("registro não atende às condições da atualização")
+
+// ErrNoMappedColumns indica que a entidade não tem campos com tag `db` a gravar, o que geraria um INSERT inválido
+var ErrNoMappedColumns = errors.New("entidade sem colunas mapeadas")
+
+// ErrLimitTooLarge indica um FindOptions.Limit acima do máximo do store (veja WithMaxLimit)
+var ErrLimitTooLarge = errors.New("limite de registros acima do máximo permitido")
+
+// ErrDecode indica documentos ignorados por não decodificarem na entidade (veja WithSkipDecodeErrors)
+var ErrDecode = errors.New("documentos não puderam ser decodificados")
+
+// ErrMissingTenant indica que o store roteia por tenant, mas nenhum tenant foi informado (veja WithTenantContextKey)
+var ErrMissingTenant = errors.New("tenant não informado")
+
+// ErrInvalidTenant indica um tenant com caracteres não permitidos no nome da tabela ou coleção
+var ErrInvalidTenant = errors.New("tenant inválido")
+
+// ErrUnsupported indica uma opção que o backend do store não suporta (ex.: StoreUpsertFilter.Or no SQL)
+var ErrUnsupported = errors.New("operação não suportada pelo backend")
+
+// RequestError associa um erro do store ao id da requisição lido do contexto (veja WithRequestIDContextKey)
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (request_id=%s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
 type TransactionContext any
 
 // Make sure mongo and sql implements our interface
@@ -18,6 +74,80 @@ type Transaction func(ctx TransactionContext) (any, error)
 type StoreUpsertFilter struct {
 	UpsertFieldKey string
 	UpsertBsonKey  string
+	// Or combina o filtro em um $or com os demais filtros marcados com Or, permitindo
+	// casar por qualquer uma das chaves únicas (ex.: _id ou email). Suportado apenas no Mongo:
+	// o ON CONFLICT/MERGE do SQL resolve uma única chave, e os upserts do SQLStore retornam
+	// ErrUnsupported quando algum filtro usa Or
+	Or bool
+}
+
+// UpsertItem associa uma entidade aos filtros de conflito usados no seu upsert, permitindo
+// que cada registro de um UpsertManyWith case por uma chave diferente
+type UpsertItem[T any] struct {
+	Entity  T
+	Filters []StoreUpsertFilter
+}
+
+// UpsertBy monta os filtros de upsert do Mongo a partir dos nomes dos campos Go de T, resolvendo
+// a chave do documento pela tag `bson` (ou pelo nome em minúsculas, como o driver). Campos
+// aninhados usam ponto ("Address.City"). Um campo inexistente em T, ou ignorado (bson:"-"),
+// retorna ErrUnknownColumn:
+//
+//	filters, err := store.UpsertBy[User]("Email")
+func UpsertBy[T any](fields ...string) ([]StoreUpsertFilter, error) {
+	filters := make([]StoreUpsertFilter, len(fields))
+	for i, field := range fields {
+		key, ok := bsonFieldKey(reflect.TypeFor[T](), field)
+		if !ok {
+			return nil, fmt.Errorf("%w: campo %s não encontrado em %s", ErrUnknownColumn, field, reflect.TypeFor[T]())
+		}
+
+		filters[i] = StoreUpsertFilter{UpsertFieldKey: field, UpsertBsonKey: key}
+	}
+
+	return filters, nil
+}
+
+// MustUpsertBy é como o UpsertBy, mas entra em pânico com um campo inexistente. Indicado para
+// filtros fixos no código, em que o campo inválido é erro de programação:
+//
+//	s.Upsert(ctx, user, store.MustUpsertBy[User]("Email"))
+func MustUpsertBy[T any](fields ...string) []StoreUpsertFilter {
+	filters, err := UpsertBy[T](fields...)
+	if err != nil {
+		panic(fmt.Sprintf("MustUpsertBy: %v", err))
+	}
+
+	return filters
+}
+
+// upsertItems associa os mesmos filtros a todas as entidades
+func upsertItems[T any](entities []T, f []StoreUpsertFilter) []UpsertItem[T] {
+	items := make([]UpsertItem[T], len(entities))
+	for i, entity := range entities {
+		items[i] = UpsertItem[T]{Entity: entity, Filters: f}
+	}
+
+	return items
+}
+
+// LookupSpec descreve um join ($lookup) com outra coleção
+type LookupSpec struct {
+	From         string // coleção de origem do join
+	LocalField   string // campo do documento atual
+	ForeignField string // campo do documento da coleção From
+	As           string // campo onde os documentos encontrados são incluídos
+}
+
+// FieldInfo descreve como um campo da entidade é mapeado pelo store, para diagnosticar
+// tags incorretas ou campos que não são persistidos
+type FieldInfo struct {
+	Field         string   // nome do campo Go (com o caminho para structs aninhadas, ex.: "Address.City")
+	Column        string   // coluna (tag `db`) ou chave do documento (tag `bson`)
+	Flags         []string // opções declaradas na tag após o nome (ex.: generated, omitempty)
+	PrimaryKey    bool     // campo mapeado para a chave primária ou _id
+	AutoIncrement bool     // chave primária gerada pelo banco
+	Skipped       bool     // campo lido nas consultas, mas não gravado nas escritas
 }
 
 type EntityFieldsToUpdate struct {
@@ -31,7 +161,36 @@ type BulkWriteResult struct {
 	ModifiedCount int64
 	DeletedCount  int64
 	UpsertedCount int64
-	UpsertedIDs   map[int64]any
+	// UpsertedIDs ids das linhas inseridas (não atualizadas) pelo índice na lista de entrada. No
+	// SQL vem do RETURNING (PostgreSQL) ou do LastInsertId (MySQL, MariaDB e SQLite, neste só para
+	// registros novos com chave gerada); o MERGE do Oracle não informa as linhas inseridas
+	UpsertedIDs map[int64]any
+	// Errors reúne, nas escritas desordenadas (WithOrderedWrites(false)), o erro de cada operação
+	// que falhou pelo índice na lista de entrada; as demais operações são aplicadas
+	Errors map[int64]error
+}
+
+// addError registra o erro da operação de índice index
+func (r *BulkWriteResult) addError(index int64, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[int64]error)
+	}
+	r.Errors[index] = err
+}
+
+// itemsError resume os erros por operação em um único erro, em ordem de índice. Retorna nil
+// quando todas as operações foram aplicadas
+func (r *BulkWriteResult) itemsError() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(r.Errors))
+	for _, index := range slices.Sorted(maps.Keys(r.Errors)) {
+		errs = append(errs, fmt.Errorf("operação %d: %w", index, r.Errors[index]))
+	}
+
+	return fmt.Errorf("%d operações falharam: %w", len(errs), errors.Join(errs...))
 }
 
 type InsertOneResult struct {
@@ -40,6 +199,25 @@ type InsertOneResult struct {
 
 type InsertManyResult struct {
 	InsertedIDs []any
+	// CommittedCount e StoppedAt são preenchidos no SaveMany com WithSaveCheckpoint: a quantidade
+	// de registros confirmados no banco e o índice do registro que interrompeu a inserção
+	// (len(entities) quando todos foram salvos). A retomada deve partir de CommittedCount
+	CommittedCount int64
+	StoppedAt      int
+}
+
+// detailed converte o resultado em um InsertOneResult por registro, na ordem de entrada
+func (r *InsertManyResult) detailed() []InsertOneResult {
+	if r == nil {
+		return nil
+	}
+
+	results := make([]InsertOneResult, len(r.InsertedIDs))
+	for i, id := range r.InsertedIDs {
+		results[i] = InsertOneResult{InsertedID: id}
+	}
+
+	return results
 }
 
 type UpdateResult struct {
@@ -58,6 +236,25 @@ type FindOptions struct {
 	Limit   int64 // the 0 value of limit means the will return all items
 	OrderBy string
 	SortBy  string
+
+	// Fields limita as colunas/campos retornados; ExcludeFields retorna todos exceto os informados.
+	// Os dois não podem ser usados juntos
+	Fields        []string
+	ExcludeFields []string
+
+	// IncludeTotalCount faz o FindAll calcular, na mesma consulta, o total de registros
+	// do filtro (ignorando a paginação) e gravá-lo em TotalCount
+	IncludeTotalCount bool
+	TotalCount        *int64
+}
+
+// validateProjection garante que Fields e ExcludeFields não foram informados juntos
+func (o *FindOptions) validateProjection() error {
+	if len(o.Fields) > 0 && len(o.ExcludeFields) > 0 {
+		return fmt.Errorf("Fields e ExcludeFields não podem ser usados juntos")
+	}
+
+	return nil
 }
 
 func (o *FindOptions) Initialize() {
@@ -68,33 +265,154 @@ func (o *FindOptions) Initialize() {
 		o.Limit = 10
 	}
 	if o.SortBy == "" {
-		o.SortBy = "createdAt"
+		o.SortBy = defaultSortField
 	}
 	if o.OrderBy == "" {
 		o.OrderBy = "ASC"
 	}
 }
 
+// defaultSortField campo de ordenação padrão do Initialize. Os stores o resolvem para o nome do
+// campo CreatedAt de T no backend quando a entidade não tem um campo com esse nome
+const defaultSortField = "createdAt"
+
+// timestampFields nomes, no backend, dos campos CreatedAt e UpdatedAt da entidade (colunas no
+// SQL, lidas das tags `db`; chaves no Mongo, lidas das tags `bson`). Assim a mesma struct
+// funciona nos dois backends com db:"created_at" e bson:"createdAt", por exemplo
+type timestampFields struct {
+	created string
+	updated string
+}
+
+// setSaveTimestamps preenche os timestamps de criação: CreatedAt só é definido quando está
+// zerado, preservando datas informadas (ex.: importação de históricos), e UpdatedAt é sempre
+// atualizado. Campos que não são time.Time ou não gerenciados (WithManagedTimestamps) são ignorados
+func (o storeOptions) setSaveTimestamps(value reflect.Value, now time.Time) {
+	if created := value.FieldByName("CreatedAt"); created.IsValid() && created.Type() == timeType && created.IsZero() && o.managesCreatedAt() {
+		created.Set(reflect.ValueOf(now))
+	}
+	if updated := value.FieldByName("UpdatedAt"); updated.IsValid() && updated.Type() == timeType && o.managesUpdatedAt() {
+		updated.Set(reflect.ValueOf(now))
+	}
+}
+
+// mustBeStruct interrompe a construção do store quando T não é uma struct, pois o
+// mapeamento de campos por reflexão depende dos campos da entidade
+func mustBeStruct[T any](constructor string) {
+	if t := reflect.TypeFor[T](); t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%s: o tipo da entidade deve ser uma struct, recebido %s", constructor, t))
+	}
+}
+
+// findPage busca a página informada e monta o page.Page com o total calculado na mesma
+// consulta (IncludeTotalCount). Compartilhado pelas implementações de FindPage
+func findPage[T any](ctx context.Context, s Store[T], f map[string]any, p, limit int64) (*page.Page[T], error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limite da página deve ser maior que zero")
+	}
+
+	if p < 1 {
+		p = 1
+	}
+
+	items, total, err := findAllWithCount(ctx, s, f, FindOptions{Page: p, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.New(items, p, limit, total), nil
+}
+
+// findAllWithCount executa o FindAll com IncludeTotalCount, retornando os itens e o total do
+// filtro. Compartilhado pelas implementações de FindAllWithCount
+func findAllWithCount[T any](ctx context.Context, s Store[T], f map[string]any, opts FindOptions) ([]T, int64, error) {
+	var total int64
+	opts.IncludeTotalCount = true
+	opts.TotalCount = &total
+
+	items, err := s.FindAll(ctx, f, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// missingIds retorna, na ordem de entrada e sem repetições, os ids que não existem, usando a
+// consulta única do HasAll. Compartilhado pelas implementações de MissingIds
+func missingIds[T any](ctx context.Context, s Store[T], ids []any) ([]any, error) {
+	exists, err := s.HasAll(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make([]any, 0)
+	seen := make(map[any]bool, len(ids))
+	for _, id := range ids {
+		if exists[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		missing = append(missing, id)
+	}
+
+	return missing, nil
+}
+
 type Store[T any] interface {
 	WithTransaction(ctx context.Context, fn Transaction) (any, error)
 	Has(ctx context.Context, id any) bool
+	HasAll(ctx context.Context, ids []any) (map[any]bool, error)
+	HasWhere(ctx context.Context, f map[string]any) (bool, error)
+	MissingIds(ctx context.Context, ids []any) ([]any, error)
 	Count(ctx context.Context, f map[string]any) (*int64, error)
+	// InvalidateCount descarta os totais memorizados pelo WithCountCache
+	InvalidateCount()
 
 	FindAll(ctx context.Context, f map[string]any, opts FindOptions) ([]T, error)
+	FindAllWithCount(ctx context.Context, f map[string]any, opts FindOptions) ([]T, int64, error)
+	FindAllJoined(ctx context.Context, lookups []LookupSpec, f map[string]any, opts FindOptions) ([]T, error)
 	FindById(ctx context.Context, id any) (*T, error)
-	FindOne(ctx context.Context, f map[string]interface{}) (*T, error)
+	FindByIdInto(ctx context.Context, id any, dst *T) error
+	FindLast(ctx context.Context, n int64, f map[string]any, sortField string) ([]T, error)
+	// FindOne retorna o primeiro registro do filtro. Com FindOptions, SortBy e OrderBy definem
+	// qual registro é o primeiro (ex.: o mais recente ou o de menor preço)
+	FindOne(ctx context.Context, f map[string]interface{}, opts ...FindOptions) (*T, error)
+	FindPage(ctx context.Context, f map[string]any, page, limit int64) (*page.Page[T], error)
+	Search(ctx context.Context, term string, fields []string, opts FindOptions) ([]T, error)
+	SearchText(ctx context.Context, query string, opts FindOptions) ([]T, error)
 
 	Save(ctx context.Context, e *T) (*T, error)
 	SaveMany(ctx context.Context, e []T) (*InsertManyResult, error)
+	SaveManyDetailed(ctx context.Context, e []T) ([]InsertOneResult, error)
 	SaveManyNotOrdered(ctx context.Context, e []T) (*InsertManyResult, error)
+	SaveIgnoreDuplicates(ctx context.Context, e []T) (*InsertManyResult, error)
 
 	Update(ctx context.Context, e *T) (*T, error)
+	UpdateFields(ctx context.Context, e *T, fields ...string) (*T, error)
+	UpdateWhere(ctx context.Context, e *T, extra map[string]any) (*T, error)
 	UpdateMany(ctx context.Context, fd []EntityFieldsToUpdate) (*BulkWriteResult, error)
 
 	Upsert(ctx context.Context, e *T, f []StoreUpsertFilter) (*UpdateResult, error)
 	UpsertMany(ctx context.Context, e []T, f []StoreUpsertFilter) (*BulkWriteResult, error)
+	// UpsertManyWith executa o upsert de cada item com os seus próprios filtros de conflito
+	UpsertManyWith(ctx context.Context, items []UpsertItem[T]) (*BulkWriteResult, error)
+	UpsertReturning(ctx context.Context, e *T, f []StoreUpsertFilter) (*T, error)
 
 	Delete(ctx context.Context, id any) error
+	DeleteIfExists(ctx context.Context, id any) (bool, error)
+	// DeleteReturning exclui pelo id e retorna o DeletedCount (0 quando o registro não existe, sem erro)
+	DeleteReturning(ctx context.Context, id any) (*DeleteResult, error)
 	DeleteOne(ctx context.Context, f map[string]interface{}) error
 	DeleteMany(ctx context.Context, f map[string]any) (*DeleteResult, error)
+	// DeleteManyBatch remove, em uma única ida ao banco, os registros de cada filtro
+	DeleteManyBatch(ctx context.Context, filters []map[string]any) (*BulkWriteResult, error)
+	DeleteByIds(ctx context.Context, ids []any) (*DeleteResult, error)
+
+	// Describe descreve o backend e a tabela ou coleção do store, para logs e diagnósticos
+	Describe() string
+	// DebugFields retorna o mapeamento entre os campos de T e as colunas ou chaves do documento
+	DebugFields() []FieldInfo
+	// Scoped retorna uma cópia leve do store com as opções aplicadas sobre as atuais
+	Scoped(opts ...Option) Store[T]
 }